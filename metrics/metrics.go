@@ -34,7 +34,7 @@ var (
 		&metrics.CounterOpts{
 			Subsystem:      DeschedulerSubsystem,
 			Name:           "pods_evicted",
-			Help:           "Number of evicted pods, by the result, by the strategy, by the namespace, by the node name. 'error' result means a pod could not be evicted",
+			Help:           "Number of evicted pods, by the result, by the strategy, by the namespace, by the node name. 'error' result means a pod could not be evicted, 'denied' result means the eviction approval webhook denied the eviction",
 			StabilityLevel: metrics.ALPHA,
 		}, []string{"result", "strategy", "profile", "namespace", "node"})
 
@@ -66,11 +66,29 @@ var (
 			Buckets:        []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100},
 		}, []string{"strategy", "profile"})
 
+	NotificationsDropped = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "notifications_dropped",
+			Help:           "Number of eviction notifications which were not delivered, by the sink and by the reason",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"sink", "reason"})
+
+	PodsProtected = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DeschedulerSubsystem,
+			Name:           "pods_protected",
+			Help:           "Number of pods not evicted because they are protected from eviction, counted once per descheduling cycle, by the reason, by the namespace",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"reason", "namespace"})
+
 	metricsList = []metrics.Registerable{
 		PodsEvicted,
 		buildInfo,
 		DeschedulerLoopDuration,
 		DeschedulerStrategyDuration,
+		NotificationsDropped,
+		PodsProtected,
 	}
 )
 
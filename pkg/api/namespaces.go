@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IsNamespacePattern returns true when the entry of an include/exclude
+// list is a glob pattern rather than a namespace name.
+func IsNamespacePattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// ValidateNamespaces checks the glob patterns and label selectors of the namespaces.
+func ValidateNamespaces(namespaces *Namespaces) error {
+	if namespaces == nil {
+		return nil
+	}
+	for _, names := range [][]string{namespaces.Include, namespaces.Exclude} {
+		for _, name := range names {
+			if _, err := path.Match(name, ""); err != nil {
+				return fmt.Errorf("invalid namespace pattern %q: %v", name, err)
+			}
+		}
+	}
+	for _, selector := range []*metav1.LabelSelector{namespaces.IncludeSelector, namespaces.ExcludeSelector} {
+		if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+			return fmt.Errorf("invalid namespace selector: %v", err)
+		}
+	}
+	return nil
+}
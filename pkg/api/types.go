@@ -18,6 +18,7 @@ package api
 
 import (
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -38,13 +39,110 @@ type DeschedulerPolicy struct {
 
 	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
 	MaxNoOfPodsToEvictPerNamespace *uint
+
+	// EvictionBackend selects how pods are removed once they are evicted.
+	// The Eviction API is used when not set.
+	EvictionBackend *EvictionBackend
+
+	// EvictionApproval asks an external endpoint to approve every eviction.
+	EvictionApproval *EvictionApproval
+
+	// Notifications publishes evictions and cycle summaries to external sinks.
+	Notifications *Notifications
+
+	// Audit writes every eviction decision to an append-only audit log.
+	Audit *AuditLog
+}
+
+type EvictionBackendType string
+
+const (
+	// EvictionBackendEvictionAPI evicts pods through the Eviction API respecting PDBs.
+	EvictionBackendEvictionAPI EvictionBackendType = "EvictionAPI"
+	// EvictionBackendDelete deletes pods directly.
+	EvictionBackendDelete EvictionBackendType = "Delete"
+	// EvictionBackendRolloutRestart restarts the pod owner instead of evicting the pod.
+	EvictionBackendRolloutRestart EvictionBackendType = "RolloutRestart"
+	// EvictionBackendRecordOnly only records evictions, as in dry run mode.
+	EvictionBackendRecordOnly EvictionBackendType = "RecordOnly"
+	// EvictionBackendWebhook delegates evictions to an external endpoint.
+	EvictionBackendWebhook EvictionBackendType = "Webhook"
+)
+
+// EvictionBackend configures how pods are removed once they are evicted
+type EvictionBackend struct {
+	// Type of the backend
+	Type EvictionBackendType
+	// Webhook configures the endpoint used by the Webhook backend
+	Webhook *WebhookClientConfig
+}
+
+type FailurePolicyType string
+
+const (
+	// FailurePolicyIgnore allows the eviction when the approval endpoint can not be reached.
+	FailurePolicyIgnore FailurePolicyType = "Ignore"
+	// FailurePolicyFail denies the eviction when the approval endpoint can not be reached.
+	FailurePolicyFail FailurePolicyType = "Fail"
+)
+
+// EvictionApproval configures an external endpoint approving evictions
+type EvictionApproval struct {
+	// Webhook configures the approval endpoint
+	Webhook WebhookClientConfig
+	// FailurePolicy defines how errors calling the endpoint are handled, Fail when not set
+	FailurePolicy FailurePolicyType
+}
+
+// Notifications configures where eviction notifications are published
+type Notifications struct {
+	// Sinks receiving the notifications
+	Sinks []NotificationSink
+}
+
+// NotificationSink configures a single destination, exactly one of Webhook or File must be set
+type NotificationSink struct {
+	// Webhook receives batches of CloudEvents
+	Webhook *WebhookClientConfig
+	// File appends CloudEvents to a local file, one per line
+	File *FileSink
+}
+
+// FileSink describes a local file
+type FileSink struct {
+	// Path of the file
+	Path string
+}
+
+// AuditLog configures the audit log file and its rotation
+type AuditLog struct {
+	// Path of the audit log file
+	Path string
+	// MaxSize of the file before it is rotated, never rotated by size when not set
+	MaxSize *resource.Quantity
+	// MaxAge of the file before it is rotated, never rotated by age when not set
+	MaxAge *metav1.Duration
+}
+
+// WebhookClientConfig describes how to reach an HTTP(S) endpoint
+type WebhookClientConfig struct {
+	// URL of the endpoint
+	URL string
+	// CAFile is a path to a PEM encoded CA bundle used to verify the server certificate
+	CAFile string
+	// Timeout of a single request, 10s when not set
+	Timeout *metav1.Duration
 }
 
 // Namespaces carries a list of included/excluded namespaces
-// for which a given strategy is applicable
+// for which a given strategy is applicable. Include and Exclude entries
+// are namespace names or glob patterns (e.g. "team-*"). The selectors
+// are matched against the labels of the Namespace objects.
 type Namespaces struct {
-	Include []string `json:"include"`
-	Exclude []string `json:"exclude"`
+	Include         []string              `json:"include"`
+	Exclude         []string              `json:"exclude"`
+	IncludeSelector *metav1.LabelSelector `json:"includeSelector,omitempty"`
+	ExcludeSelector *metav1.LabelSelector `json:"excludeSelector,omitempty"`
 }
 
 type (
@@ -24,11 +24,14 @@ import (
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
@@ -72,6 +75,14 @@ func (ei *evictorImpl) NodeLimitExceeded(node *v1.Node) bool {
 	return ei.podEvictor.NodeLimitExceeded(node)
 }
 
+func (ei *evictorImpl) EvictionsAllowed(pods []*v1.Pod) bool {
+	return ei.podEvictor.EvictionsAllowed(pods)
+}
+
+func (ei *evictorImpl) EvictedOwnerPods(ownerUID types.UID) sets.Set[types.UID] {
+	return ei.podEvictor.EvictedOwnerPods(ownerUID)
+}
+
 // handleImpl implements the framework handle which gets passed to plugins
 type handleImpl struct {
 	clientSet                 clientset.Interface
@@ -102,6 +113,11 @@ func (hi *handleImpl) Evictor() frameworktypes.Evictor {
 	return hi.evictor
 }
 
+// NodeSnapshot is not available when converting the policy
+func (hi *handleImpl) NodeSnapshot() *nodeutil.Snapshot {
+	return nil
+}
+
 func Convert_v1alpha1_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
 	klog.V(1).Info("Warning: v1alpha1 API is deprecated and will be removed in a future release. Use v1alpha2 API instead.")
 
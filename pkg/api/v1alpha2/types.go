@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha2
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -37,6 +38,75 @@ type DeschedulerPolicy struct {
 
 	// MaxNoOfPodsToEvictPerNamespace restricts maximum of pods to be evicted per namespace.
 	MaxNoOfPodsToEvictPerNamespace *uint `json:"maxNoOfPodsToEvictPerNamespace,omitempty"`
+
+	// EvictionBackend selects how pods are removed once they are evicted.
+	// The Eviction API is used when not set.
+	EvictionBackend *EvictionBackend `json:"evictionBackend,omitempty"`
+
+	// EvictionApproval asks an external endpoint to approve every eviction.
+	EvictionApproval *EvictionApproval `json:"evictionApproval,omitempty"`
+
+	// Notifications publishes evictions and cycle summaries to external sinks.
+	Notifications *Notifications `json:"notifications,omitempty"`
+
+	// Audit writes every eviction decision to an append-only audit log.
+	Audit *AuditLog `json:"audit,omitempty"`
+}
+
+// AuditLog configures the audit log file and its rotation
+type AuditLog struct {
+	// Path of the audit log file
+	Path string `json:"path"`
+	// MaxSize of the file before it is rotated, never rotated by size when not set
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+	// MaxAge of the file before it is rotated, never rotated by age when not set
+	MaxAge *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+// Notifications configures where eviction notifications are published
+type Notifications struct {
+	// Sinks receiving the notifications
+	Sinks []NotificationSink `json:"sinks"`
+}
+
+// NotificationSink configures a single destination, exactly one of Webhook or File must be set
+type NotificationSink struct {
+	// Webhook receives batches of CloudEvents
+	Webhook *WebhookClientConfig `json:"webhook,omitempty"`
+	// File appends CloudEvents to a local file, one per line
+	File *FileSink `json:"file,omitempty"`
+}
+
+// FileSink describes a local file
+type FileSink struct {
+	// Path of the file
+	Path string `json:"path"`
+}
+
+// EvictionApproval configures an external endpoint approving evictions
+type EvictionApproval struct {
+	// Webhook configures the approval endpoint
+	Webhook WebhookClientConfig `json:"webhook"`
+	// FailurePolicy defines how errors calling the endpoint are handled, one of Ignore or Fail (default)
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+}
+
+// EvictionBackend configures how pods are removed once they are evicted
+type EvictionBackend struct {
+	// Type of the backend, one of EvictionAPI, Delete, RolloutRestart, RecordOnly or Webhook
+	Type string `json:"type"`
+	// Webhook configures the endpoint used by the Webhook backend
+	Webhook *WebhookClientConfig `json:"webhook,omitempty"`
+}
+
+// WebhookClientConfig describes how to reach an HTTP(S) endpoint
+type WebhookClientConfig struct {
+	// URL of the endpoint
+	URL string `json:"url"`
+	// CAFile is a path to a PEM encoded CA bundle used to verify the server certificate
+	CAFile string `json:"caFile,omitempty"`
+	// Timeout of a single request, 10s when not set
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 type DeschedulerProfile struct {
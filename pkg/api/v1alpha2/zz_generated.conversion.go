@@ -24,6 +24,8 @@ package v1alpha2
 import (
 	unsafe "unsafe"
 
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	api "sigs.k8s.io/descheduler/pkg/api"
@@ -36,6 +38,16 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AuditLog)(nil), (*api.AuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AuditLog_To_api_AuditLog(a.(*AuditLog), b.(*api.AuditLog), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.AuditLog)(nil), (*AuditLog)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_AuditLog_To_v1alpha2_AuditLog(a.(*api.AuditLog), b.(*AuditLog), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DeschedulerProfile)(nil), (*api.DeschedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DeschedulerProfile_To_api_DeschedulerProfile(a.(*DeschedulerProfile), b.(*api.DeschedulerProfile), scope)
 	}); err != nil {
@@ -46,6 +58,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EvictionApproval)(nil), (*api.EvictionApproval)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionApproval_To_api_EvictionApproval(a.(*EvictionApproval), b.(*api.EvictionApproval), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionApproval)(nil), (*EvictionApproval)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionApproval_To_v1alpha2_EvictionApproval(a.(*api.EvictionApproval), b.(*EvictionApproval), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EvictionBackend)(nil), (*api.EvictionBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EvictionBackend_To_api_EvictionBackend(a.(*EvictionBackend), b.(*api.EvictionBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.EvictionBackend)(nil), (*EvictionBackend)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_EvictionBackend_To_v1alpha2_EvictionBackend(a.(*api.EvictionBackend), b.(*EvictionBackend), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FileSink)(nil), (*api.FileSink)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_FileSink_To_api_FileSink(a.(*FileSink), b.(*api.FileSink), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.FileSink)(nil), (*FileSink)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_FileSink_To_v1alpha2_FileSink(a.(*api.FileSink), b.(*FileSink), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NotificationSink)(nil), (*api.NotificationSink)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NotificationSink_To_api_NotificationSink(a.(*NotificationSink), b.(*api.NotificationSink), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.NotificationSink)(nil), (*NotificationSink)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_NotificationSink_To_v1alpha2_NotificationSink(a.(*api.NotificationSink), b.(*NotificationSink), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Notifications)(nil), (*api.Notifications)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Notifications_To_api_Notifications(a.(*Notifications), b.(*api.Notifications), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.Notifications)(nil), (*Notifications)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_Notifications_To_v1alpha2_Notifications(a.(*api.Notifications), b.(*Notifications), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*api.PluginConfig)(nil), (*PluginConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_api_PluginConfig_To_v1alpha2_PluginConfig(a.(*api.PluginConfig), b.(*PluginConfig), scope)
 	}); err != nil {
@@ -71,6 +133,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WebhookClientConfig)(nil), (*api.WebhookClientConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_WebhookClientConfig_To_api_WebhookClientConfig(a.(*WebhookClientConfig), b.(*api.WebhookClientConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*api.WebhookClientConfig)(nil), (*WebhookClientConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_api_WebhookClientConfig_To_v1alpha2_WebhookClientConfig(a.(*api.WebhookClientConfig), b.(*WebhookClientConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*api.DeschedulerPolicy)(nil), (*DeschedulerPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(a.(*api.DeschedulerPolicy), b.(*DeschedulerPolicy), scope)
 	}); err != nil {
@@ -89,6 +161,30 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha2_AuditLog_To_api_AuditLog(in *AuditLog, out *api.AuditLog, s conversion.Scope) error {
+	out.Path = in.Path
+	out.MaxSize = (*resource.Quantity)(unsafe.Pointer(in.MaxSize))
+	out.MaxAge = (*v1.Duration)(unsafe.Pointer(in.MaxAge))
+	return nil
+}
+
+// Convert_v1alpha2_AuditLog_To_api_AuditLog is an autogenerated conversion function.
+func Convert_v1alpha2_AuditLog_To_api_AuditLog(in *AuditLog, out *api.AuditLog, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AuditLog_To_api_AuditLog(in, out, s)
+}
+
+func autoConvert_api_AuditLog_To_v1alpha2_AuditLog(in *api.AuditLog, out *AuditLog, s conversion.Scope) error {
+	out.Path = in.Path
+	out.MaxSize = (*resource.Quantity)(unsafe.Pointer(in.MaxSize))
+	out.MaxAge = (*v1.Duration)(unsafe.Pointer(in.MaxAge))
+	return nil
+}
+
+// Convert_api_AuditLog_To_v1alpha2_AuditLog is an autogenerated conversion function.
+func Convert_api_AuditLog_To_v1alpha2_AuditLog(in *api.AuditLog, out *AuditLog, s conversion.Scope) error {
+	return autoConvert_api_AuditLog_To_v1alpha2_AuditLog(in, out, s)
+}
+
 func autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *DeschedulerPolicy, out *api.DeschedulerPolicy, s conversion.Scope) error {
 	if in.Profiles != nil {
 		in, out := &in.Profiles, &out.Profiles
@@ -104,6 +200,10 @@ func autoConvert_v1alpha2_DeschedulerPolicy_To_api_DeschedulerPolicy(in *Desched
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.EvictionBackend = (*api.EvictionBackend)(unsafe.Pointer(in.EvictionBackend))
+	out.EvictionApproval = (*api.EvictionApproval)(unsafe.Pointer(in.EvictionApproval))
+	out.Notifications = (*api.Notifications)(unsafe.Pointer(in.Notifications))
+	out.Audit = (*api.AuditLog)(unsafe.Pointer(in.Audit))
 	return nil
 }
 
@@ -122,6 +222,10 @@ func autoConvert_api_DeschedulerPolicy_To_v1alpha2_DeschedulerPolicy(in *api.Des
 	out.NodeSelector = (*string)(unsafe.Pointer(in.NodeSelector))
 	out.MaxNoOfPodsToEvictPerNode = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNode))
 	out.MaxNoOfPodsToEvictPerNamespace = (*uint)(unsafe.Pointer(in.MaxNoOfPodsToEvictPerNamespace))
+	out.EvictionBackend = (*EvictionBackend)(unsafe.Pointer(in.EvictionBackend))
+	out.EvictionApproval = (*EvictionApproval)(unsafe.Pointer(in.EvictionApproval))
+	out.Notifications = (*Notifications)(unsafe.Pointer(in.Notifications))
+	out.Audit = (*AuditLog)(unsafe.Pointer(in.Audit))
 	return nil
 }
 
@@ -173,6 +277,116 @@ func Convert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in *api.Desch
 	return autoConvert_api_DeschedulerProfile_To_v1alpha2_DeschedulerProfile(in, out, s)
 }
 
+func autoConvert_v1alpha2_EvictionApproval_To_api_EvictionApproval(in *EvictionApproval, out *api.EvictionApproval, s conversion.Scope) error {
+	if err := Convert_v1alpha2_WebhookClientConfig_To_api_WebhookClientConfig(&in.Webhook, &out.Webhook, s); err != nil {
+		return err
+	}
+	out.FailurePolicy = api.FailurePolicyType(in.FailurePolicy)
+	return nil
+}
+
+// Convert_v1alpha2_EvictionApproval_To_api_EvictionApproval is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionApproval_To_api_EvictionApproval(in *EvictionApproval, out *api.EvictionApproval, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionApproval_To_api_EvictionApproval(in, out, s)
+}
+
+func autoConvert_api_EvictionApproval_To_v1alpha2_EvictionApproval(in *api.EvictionApproval, out *EvictionApproval, s conversion.Scope) error {
+	if err := Convert_api_WebhookClientConfig_To_v1alpha2_WebhookClientConfig(&in.Webhook, &out.Webhook, s); err != nil {
+		return err
+	}
+	out.FailurePolicy = string(in.FailurePolicy)
+	return nil
+}
+
+// Convert_api_EvictionApproval_To_v1alpha2_EvictionApproval is an autogenerated conversion function.
+func Convert_api_EvictionApproval_To_v1alpha2_EvictionApproval(in *api.EvictionApproval, out *EvictionApproval, s conversion.Scope) error {
+	return autoConvert_api_EvictionApproval_To_v1alpha2_EvictionApproval(in, out, s)
+}
+
+func autoConvert_v1alpha2_EvictionBackend_To_api_EvictionBackend(in *EvictionBackend, out *api.EvictionBackend, s conversion.Scope) error {
+	out.Type = api.EvictionBackendType(in.Type)
+	out.Webhook = (*api.WebhookClientConfig)(unsafe.Pointer(in.Webhook))
+	return nil
+}
+
+// Convert_v1alpha2_EvictionBackend_To_api_EvictionBackend is an autogenerated conversion function.
+func Convert_v1alpha2_EvictionBackend_To_api_EvictionBackend(in *EvictionBackend, out *api.EvictionBackend, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EvictionBackend_To_api_EvictionBackend(in, out, s)
+}
+
+func autoConvert_api_EvictionBackend_To_v1alpha2_EvictionBackend(in *api.EvictionBackend, out *EvictionBackend, s conversion.Scope) error {
+	out.Type = string(in.Type)
+	out.Webhook = (*WebhookClientConfig)(unsafe.Pointer(in.Webhook))
+	return nil
+}
+
+// Convert_api_EvictionBackend_To_v1alpha2_EvictionBackend is an autogenerated conversion function.
+func Convert_api_EvictionBackend_To_v1alpha2_EvictionBackend(in *api.EvictionBackend, out *EvictionBackend, s conversion.Scope) error {
+	return autoConvert_api_EvictionBackend_To_v1alpha2_EvictionBackend(in, out, s)
+}
+
+func autoConvert_v1alpha2_FileSink_To_api_FileSink(in *FileSink, out *api.FileSink, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1alpha2_FileSink_To_api_FileSink is an autogenerated conversion function.
+func Convert_v1alpha2_FileSink_To_api_FileSink(in *FileSink, out *api.FileSink, s conversion.Scope) error {
+	return autoConvert_v1alpha2_FileSink_To_api_FileSink(in, out, s)
+}
+
+func autoConvert_api_FileSink_To_v1alpha2_FileSink(in *api.FileSink, out *FileSink, s conversion.Scope) error {
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_api_FileSink_To_v1alpha2_FileSink is an autogenerated conversion function.
+func Convert_api_FileSink_To_v1alpha2_FileSink(in *api.FileSink, out *FileSink, s conversion.Scope) error {
+	return autoConvert_api_FileSink_To_v1alpha2_FileSink(in, out, s)
+}
+
+func autoConvert_v1alpha2_NotificationSink_To_api_NotificationSink(in *NotificationSink, out *api.NotificationSink, s conversion.Scope) error {
+	out.Webhook = (*api.WebhookClientConfig)(unsafe.Pointer(in.Webhook))
+	out.File = (*api.FileSink)(unsafe.Pointer(in.File))
+	return nil
+}
+
+// Convert_v1alpha2_NotificationSink_To_api_NotificationSink is an autogenerated conversion function.
+func Convert_v1alpha2_NotificationSink_To_api_NotificationSink(in *NotificationSink, out *api.NotificationSink, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NotificationSink_To_api_NotificationSink(in, out, s)
+}
+
+func autoConvert_api_NotificationSink_To_v1alpha2_NotificationSink(in *api.NotificationSink, out *NotificationSink, s conversion.Scope) error {
+	out.Webhook = (*WebhookClientConfig)(unsafe.Pointer(in.Webhook))
+	out.File = (*FileSink)(unsafe.Pointer(in.File))
+	return nil
+}
+
+// Convert_api_NotificationSink_To_v1alpha2_NotificationSink is an autogenerated conversion function.
+func Convert_api_NotificationSink_To_v1alpha2_NotificationSink(in *api.NotificationSink, out *NotificationSink, s conversion.Scope) error {
+	return autoConvert_api_NotificationSink_To_v1alpha2_NotificationSink(in, out, s)
+}
+
+func autoConvert_v1alpha2_Notifications_To_api_Notifications(in *Notifications, out *api.Notifications, s conversion.Scope) error {
+	out.Sinks = *(*[]api.NotificationSink)(unsafe.Pointer(&in.Sinks))
+	return nil
+}
+
+// Convert_v1alpha2_Notifications_To_api_Notifications is an autogenerated conversion function.
+func Convert_v1alpha2_Notifications_To_api_Notifications(in *Notifications, out *api.Notifications, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Notifications_To_api_Notifications(in, out, s)
+}
+
+func autoConvert_api_Notifications_To_v1alpha2_Notifications(in *api.Notifications, out *Notifications, s conversion.Scope) error {
+	out.Sinks = *(*[]NotificationSink)(unsafe.Pointer(&in.Sinks))
+	return nil
+}
+
+// Convert_api_Notifications_To_v1alpha2_Notifications is an autogenerated conversion function.
+func Convert_api_Notifications_To_v1alpha2_Notifications(in *api.Notifications, out *Notifications, s conversion.Scope) error {
+	return autoConvert_api_Notifications_To_v1alpha2_Notifications(in, out, s)
+}
+
 func autoConvert_v1alpha2_PluginConfig_To_api_PluginConfig(in *PluginConfig, out *api.PluginConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&in.Args, &out.Args, s); err != nil {
@@ -269,3 +483,27 @@ func autoConvert_api_Plugins_To_v1alpha2_Plugins(in *api.Plugins, out *Plugins,
 func Convert_api_Plugins_To_v1alpha2_Plugins(in *api.Plugins, out *Plugins, s conversion.Scope) error {
 	return autoConvert_api_Plugins_To_v1alpha2_Plugins(in, out, s)
 }
+
+func autoConvert_v1alpha2_WebhookClientConfig_To_api_WebhookClientConfig(in *WebhookClientConfig, out *api.WebhookClientConfig, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CAFile = in.CAFile
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_v1alpha2_WebhookClientConfig_To_api_WebhookClientConfig is an autogenerated conversion function.
+func Convert_v1alpha2_WebhookClientConfig_To_api_WebhookClientConfig(in *WebhookClientConfig, out *api.WebhookClientConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_WebhookClientConfig_To_api_WebhookClientConfig(in, out, s)
+}
+
+func autoConvert_api_WebhookClientConfig_To_v1alpha2_WebhookClientConfig(in *api.WebhookClientConfig, out *WebhookClientConfig, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CAFile = in.CAFile
+	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	return nil
+}
+
+// Convert_api_WebhookClientConfig_To_v1alpha2_WebhookClientConfig is an autogenerated conversion function.
+func Convert_api_WebhookClientConfig_To_v1alpha2_WebhookClientConfig(in *api.WebhookClientConfig, out *WebhookClientConfig, s conversion.Scope) error {
+	return autoConvert_api_WebhookClientConfig_To_v1alpha2_WebhookClientConfig(in, out, s)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// Outcome of an eviction decision
+type Outcome string
+
+const (
+	// OutcomeEvicted means the pod was evicted.
+	OutcomeEvicted Outcome = "Evicted"
+	// OutcomeFailed means the eviction was attempted but failed.
+	OutcomeFailed Outcome = "Failed"
+	// OutcomeDenied means the eviction approval webhook denied the eviction.
+	OutcomeDenied Outcome = "Denied"
+	// OutcomeLimitReached means the eviction was skipped due to the per node or per namespace limits.
+	OutcomeLimitReached Outcome = "LimitReached"
+	// OutcomeFiltered means an evictor plugin filtered the pod out.
+	OutcomeFiltered Outcome = "Filtered"
+)
+
+// Record is a single line of the audit log. Fields are only ever added
+// to keep the schema stable for consumers.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	CycleID   string    `json:"cycleID"`
+	DryRun    bool      `json:"dryRun"`
+	Profile   string    `json:"profile"`
+	Plugin    string    `json:"plugin"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	PodUID    string    `json:"podUID"`
+	Owner     string    `json:"owner"`
+	Node      string    `json:"node"`
+	Outcome   Outcome   `json:"outcome"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// NewRecord fills the pod related fields of a record.
+func NewRecord(pod *v1.Pod, outcome Outcome) Record {
+	record := Record{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		PodUID:    string(pod.UID),
+		Node:      pod.Spec.NodeName,
+		Outcome:   outcome,
+	}
+	if owner := metav1.GetControllerOfNoCopy(pod); owner != nil {
+		record.Owner = owner.Kind + "/" + owner.Name
+	} else if len(pod.OwnerReferences) > 0 {
+		record.Owner = pod.OwnerReferences[0].Kind + "/" + pod.OwnerReferences[0].Name
+	}
+	return record
+}
+
+// Writer persists audit records.
+type Writer interface {
+	Write(record Record) error
+}
+
+// Recorder records eviction decisions. Recording never fails, errors
+// are only logged so auditing does not interfere with evictions.
+type Recorder interface {
+	Record(record Record)
+}
+
+type cycleRecorder struct {
+	writer  Writer
+	cycleID string
+	dryRun  bool
+}
+
+var _ Recorder = &cycleRecorder{}
+
+// NewCycleRecorder stamps every record with the current time and the
+// descheduling cycle before it is written.
+func NewCycleRecorder(writer Writer, cycleID string, dryRun bool) Recorder {
+	return &cycleRecorder{
+		writer:  writer,
+		cycleID: cycleID,
+		dryRun:  dryRun,
+	}
+}
+
+func (r *cycleRecorder) Record(record Record) {
+	record.Timestamp = time.Now().UTC()
+	record.CycleID = r.cycleID
+	record.DryRun = r.dryRun
+	if err := r.writer.Write(record); err != nil {
+		klog.ErrorS(err, "Unable to write audit record", "pod", klog.KRef(record.Namespace, record.Pod), "outcome", record.Outcome)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// rotatedFileTimeFormat is appended to the name of rotated files.
+const rotatedFileTimeFormat = "2006-01-02T15-04-05.000000000"
+
+// FileWriterOptions configures the rotation of the audit log.
+type FileWriterOptions struct {
+	// MaxSize rotates the file before it grows beyond the given number of bytes, never when 0.
+	MaxSize int64
+	// MaxAge rotates the file once its first record is older, never when 0.
+	MaxAge time.Duration
+}
+
+// FileWriter appends records to a file, one JSON encoded record per line.
+// Rotated files are renamed with the rotation time as suffix and never removed.
+type FileWriter struct {
+	path string
+	opts FileWriterOptions
+
+	lock      sync.Mutex
+	file      *os.File
+	size      int64
+	createdAt time.Time
+	now       func() time.Time
+}
+
+var _ Writer = &FileWriter{}
+
+// NewFileWriter opens path for appending, creating it if needed.
+func NewFileWriter(path string, opts FileWriterOptions) (*FileWriter, error) {
+	w := &FileWriter{
+		path: path,
+		opts: opts,
+		now:  time.Now,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *FileWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open audit log %q: %v", w.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("unable to stat audit log %q: %v", w.path, err)
+	}
+	w.file = f
+	w.size = info.Size()
+	w.createdAt = w.now()
+	if w.size > 0 {
+		w.createdAt = firstRecordTime(w.path, info.ModTime())
+	}
+	return nil
+}
+
+// firstRecordTime returns the timestamp of the first record in the file, so
+// the age of a file is kept across restarts.
+func firstRecordTime(path string, fallback time.Time) time.Time {
+	f, err := os.Open(path)
+	if err != nil {
+		return fallback
+	}
+	defer f.Close()
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return fallback
+	}
+	record := Record{}
+	if err := json.Unmarshal(line, &record); err != nil || record.Timestamp.IsZero() {
+		return fallback
+	}
+	return record.Timestamp
+}
+
+// Write appends the record, rotating the file first when needed.
+func (w *FileWriter) Write(record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("unable to encode audit record: %v", err)
+	}
+	line = append(line, '\n')
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.file == nil {
+		return fmt.Errorf("audit log %q is closed", w.path)
+	}
+	if w.shouldRotate(int64(len(line))) {
+		if err := w.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := w.file.Write(line)
+	w.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("unable to write audit log %q: %v", w.path, err)
+	}
+	return nil
+}
+
+func (w *FileWriter) shouldRotate(size int64) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.opts.MaxSize > 0 && w.size+size > w.opts.MaxSize {
+		return true
+	}
+	return w.opts.MaxAge > 0 && w.now().Sub(w.createdAt) >= w.opts.MaxAge
+}
+
+func (w *FileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("unable to close audit log %q: %v", w.path, err)
+	}
+	w.file = nil
+	rotated := w.path + "." + w.now().UTC().Format(rotatedFileTimeFormat)
+	renameErr := os.Rename(w.path, rotated)
+	if err := w.open(); err != nil {
+		return err
+	}
+	if renameErr != nil {
+		return fmt.Errorf("unable to rotate audit log %q: %v", w.path, renameErr)
+	}
+	return nil
+}
+
+// Close closes the file, later writes fail.
+func (w *FileWriter) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
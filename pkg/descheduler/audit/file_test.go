@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func readRecords(t *testing.T, path string) []Record {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Unable to open %q: %v", path, err)
+	}
+	defer f.Close()
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		record := Record{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Unable to decode %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestCycleRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writer, err := NewFileWriter(path, FileWriterOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer writer.Close()
+
+	pod := test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+		pod.OwnerReferences = test.GetReplicaSetOwnerRefList()
+	})
+	record := NewRecord(pod, OutcomeEvicted)
+	record.Profile = "profile"
+	record.Plugin = "plugin"
+	record.Reason = "testing"
+	NewCycleRecorder(writer, "cycle", true).Record(record)
+
+	records := readRecords(t, path)
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(records))
+	}
+	got := records[0]
+	if got.Timestamp.IsZero() {
+		t.Errorf("Expected the record to have a timestamp")
+	}
+	got.Timestamp = time.Time{}
+	expected := Record{
+		CycleID:   "cycle",
+		DryRun:    true,
+		Profile:   "profile",
+		Plugin:    "plugin",
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		PodUID:    string(pod.UID),
+		Owner:     "ReplicaSet/replicaset-1",
+		Node:      "node1",
+		Outcome:   OutcomeEvicted,
+		Reason:    "testing",
+	}
+	if got != expected {
+		t.Errorf("Expected record %+v, got %+v", expected, got)
+	}
+}
+
+func TestFileWriterRotation(t *testing.T) {
+	pod := test.BuildTestPod("p1", 100, 0, "node1", nil)
+
+	t.Run("rotates by size", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "audit.jsonl")
+		line, _ := json.Marshal(NewRecord(pod, OutcomeEvicted))
+		writer, err := NewFileWriter(path, FileWriterOptions{MaxSize: int64(2*len(line) + 2)})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer writer.Close()
+
+		for i := 0; i < 5; i++ {
+			if err := writer.Write(NewRecord(pod, OutcomeEvicted)); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		files, _ := filepath.Glob(filepath.Join(dir, "audit.jsonl*"))
+		if len(files) != 3 {
+			t.Errorf("Expected 3 files, got %v", files)
+		}
+		if records := readRecords(t, path); len(records) != 1 {
+			t.Errorf("Expected the current file to hold 1 record, got %d", len(records))
+		}
+	})
+
+	t.Run("rotates by age", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "audit.jsonl")
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		writer, err := NewFileWriter(path, FileWriterOptions{MaxAge: time.Hour})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer writer.Close()
+		writer.now = func() time.Time { return now }
+		writer.createdAt = now
+
+		for _, offset := range []time.Duration{0, 30 * time.Minute, time.Hour, 90 * time.Minute} {
+			now = now.Add(offset)
+			if err := writer.Write(NewRecord(pod, OutcomeEvicted)); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		files, _ := filepath.Glob(filepath.Join(dir, "audit.jsonl*"))
+		if len(files) != 3 {
+			t.Errorf("Expected 3 files, got %v", files)
+		}
+	})
+
+	t.Run("keeps the age across restarts", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "audit.jsonl")
+		created := time.Now().Add(-48 * time.Hour).UTC()
+		record := NewRecord(pod, OutcomeEvicted)
+		record.Timestamp = created
+		line, _ := json.Marshal(record)
+		if err := os.WriteFile(path, append(line, '\n'), 0o600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		writer, err := NewFileWriter(path, FileWriterOptions{MaxAge: 24 * time.Hour})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer writer.Close()
+		if !writer.createdAt.Equal(created) {
+			t.Errorf("Expected the file age to start at %v, got %v", created, writer.createdAt)
+		}
+		if err := writer.Write(NewRecord(pod, OutcomeEvicted)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		files, _ := filepath.Glob(filepath.Join(dir, "audit.jsonl*"))
+		if len(files) != 2 {
+			t.Errorf("Expected the old file to be rotated, got %v", files)
+		}
+	})
+}
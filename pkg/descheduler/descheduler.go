@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -37,6 +38,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	utilversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
@@ -55,13 +57,19 @@ import (
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
 	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	"sigs.k8s.io/descheduler/pkg/descheduler/notifications"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	frameworkprofile "sigs.k8s.io/descheduler/pkg/framework/profile"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
 
+// notificationsFlushTimeout bounds the time spent delivering pending
+// notifications when the descheduler stops.
+const notificationsFlushTimeout = 30 * time.Second
+
 type eprunner func(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status
 
 type profileRunner struct {
@@ -77,9 +85,12 @@ type descheduler struct {
 	priorityClassLister        schedulingv1.PriorityClassLister
 	getPodsAssignedToNode      podutil.GetPodsAssignedToNodeFunc
 	sharedInformerFactory      informers.SharedInformerFactory
+	clusterInformerFactory     informers.SharedInformerFactory
 	evictionPolicyGroupVersion string
 	deschedulerPolicy          *api.DeschedulerPolicy
 	eventRecorder              events.EventRecorder
+	notifier                   notifications.Publisher
+	auditWriter                audit.Writer
 }
 
 func newDescheduler(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string, eventRecorder events.EventRecorder, sharedInformerFactory informers.SharedInformerFactory) (*descheduler, error) {
@@ -102,6 +113,7 @@ func newDescheduler(ctx context.Context, rs *options.DeschedulerServer, deschedu
 		priorityClassLister:        priorityClassLister,
 		getPodsAssignedToNode:      getPodsAssignedToNode,
 		sharedInformerFactory:      sharedInformerFactory,
+		clusterInformerFactory:     sharedInformerFactory,
 		evictionPolicyGroupVersion: evictionPolicyGroupVersion,
 		deschedulerPolicy:          deschedulerPolicy,
 		eventRecorder:              eventRecorder,
@@ -112,9 +124,10 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "runDeschedulerLoop")
 	defer span.End()
+	loopStartTime := time.Now()
 	defer func(loopStartDuration time.Time) {
 		metrics.DeschedulerLoopDuration.With(map[string]string{}).Observe(time.Since(loopStartDuration).Seconds())
-	}(time.Now())
+	}(loopStartTime)
 
 	// if len is still <= 1 error out
 	if len(nodes) <= 1 {
@@ -123,13 +136,15 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 	}
 
 	var client clientset.Interface
+	var evictionBackend evictions.EvictionBackend
+	var evictorOpts []evictions.Option
 	// When the dry mode is enable, collect all the relevant objects (mostly pods) under a fake client.
 	// So when evicting pods while running multiple strategies in a row have the cummulative effect
 	// as is when evicting pods for real.
 	if d.rs.DryRun {
 		klog.V(3).Infof("Building a cached client from the cluster for the dry run")
 		// Create a new cache so we start from scratch without any leftovers
-		fakeClient, err := cachedClient(d.rs.Client, d.podLister, d.nodeLister, d.namespaceLister, d.priorityClassLister)
+		fakeClient, err := cachedClient(d.rs.Client, d.podLister, d.nodeLister, d.namespaceLister, d.priorityClassLister, d.clusterInformerFactory)
 		if err != nil {
 			return err
 		}
@@ -142,6 +157,9 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 			return fmt.Errorf("build get pods assigned to node function error: %v", err)
 		}
 
+		// register the informers of the plugins as well
+		d.registerInformers(fakeClient, fakeSharedInformerFactory)
+
 		fakeCtx, cncl := context.WithCancel(context.TODO())
 		defer cncl()
 		fakeSharedInformerFactory.Start(fakeCtx.Done())
@@ -149,33 +167,72 @@ func (d *descheduler) runDeschedulerLoop(ctx context.Context, nodes []*v1.Node)
 
 		client = fakeClient
 		d.sharedInformerFactory = fakeSharedInformerFactory
+		evictionBackend = evictions.NewRecordOnlyBackend(fakeClient, d.evictionPolicyGroupVersion)
 	} else {
 		client = d.rs.Client
+		var err error
+		evictionBackend, err = newEvictionBackend(client, d.evictionPolicyGroupVersion, d.deschedulerPolicy.EvictionBackend)
+		if err != nil {
+			return fmt.Errorf("unable to build eviction backend: %v", err)
+		}
+		if d.deschedulerPolicy.EvictionApproval != nil {
+			approver, err := newWebhookApprover(d.deschedulerPolicy.EvictionApproval)
+			if err != nil {
+				return fmt.Errorf("unable to build eviction approver: %v", err)
+			}
+			evictorOpts = append(evictorOpts, evictions.WithApprover(approver))
+		}
+		if d.notifier != nil {
+			evictorOpts = append(evictorOpts, evictions.WithNotifier(d.notifier))
+		}
+	}
+
+	var auditRecorder audit.Recorder
+	if d.auditWriter != nil {
+		auditRecorder = audit.NewCycleRecorder(d.auditWriter, string(uuid.NewUUID()), d.rs.DryRun)
+		evictorOpts = append(evictorOpts, evictions.WithAuditRecorder(auditRecorder))
 	}
 
 	klog.V(3).Infof("Building a pod evictor")
 	podEvictor := evictions.NewPodEvictor(
-		client,
-		d.evictionPolicyGroupVersion,
-		d.rs.DryRun,
+		evictionBackend,
 		d.deschedulerPolicy.MaxNoOfPodsToEvictPerNode,
 		d.deschedulerPolicy.MaxNoOfPodsToEvictPerNamespace,
 		nodes,
 		!d.rs.DisableMetrics,
 		d.eventRecorder,
+		evictorOpts...,
 	)
 
-	d.runProfiles(ctx, client, nodes, podEvictor)
+	// the snapshot is shared by all the profiles so every plugin sees the evictions of the cycle
+	snapshot := nodeutil.NewSnapshot(client, d.sharedInformerFactory.Core().V1().Nodes().Lister(), d.getPodsAssignedToNode)
+
+	d.runProfiles(ctx, client, nodes, podEvictor, auditRecorder, snapshot)
 
 	klog.V(1).InfoS("Number of evicted pods", "totalEvicted", podEvictor.TotalEvicted())
 
+	if d.notifier != nil {
+		nodeEvicted := make(map[string]uint)
+		for _, node := range nodes {
+			if count := podEvictor.NodeEvicted(node); count > 0 {
+				nodeEvicted[node.Name] = count
+			}
+		}
+		d.notifier.Publish(notifications.NewEvent(notifications.CycleCompletedEventType, notifications.CycleSummaryData{
+			StartTime:    loopStartTime.UTC(),
+			Duration:     time.Since(loopStartTime).String(),
+			TotalEvicted: podEvictor.TotalEvicted(),
+			NodeEvicted:  nodeEvicted,
+		}))
+	}
+
 	return nil
 }
 
 // runProfiles runs all the deschedule plugins of all profiles and
 // later runs through all balance plugins of all profiles. (All Balance plugins should come after all Deschedule plugins)
 // see https://github.com/kubernetes-sigs/descheduler/issues/979
-func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interface, nodes []*v1.Node, podEvictor *evictions.PodEvictor) {
+func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interface, nodes []*v1.Node, podEvictor *evictions.PodEvictor, auditRecorder audit.Recorder, snapshot *nodeutil.Snapshot) {
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "runProfiles")
 	defer span.End()
@@ -188,6 +245,8 @@ func (d *descheduler) runProfiles(ctx context.Context, client clientset.Interfac
 			frameworkprofile.WithSharedInformerFactory(d.sharedInformerFactory),
 			frameworkprofile.WithPodEvictor(podEvictor),
 			frameworkprofile.WithGetPodsAssignedToNodeFnc(d.getPodsAssignedToNode),
+			frameworkprofile.WithAuditRecorder(auditRecorder),
+			frameworkprofile.WithNodeSnapshot(snapshot),
 		)
 		if err != nil {
 			klog.ErrorS(err, "unable to create a profile", "profile", profile.Name)
@@ -261,6 +320,16 @@ func Run(ctx context.Context, rs *options.DeschedulerServer) error {
 		return fmt.Errorf("leaderElection must be used with deschedulingInterval")
 	}
 
+	if rs.DryRun && deschedulerPolicy.EvictionBackend != nil {
+		klog.V(1).InfoS("Warning: DryRun is set to True. The configured eviction backend is replaced with the RecordOnly backend.", "evictionBackend", deschedulerPolicy.EvictionBackend.Type)
+	}
+	if rs.DryRun && deschedulerPolicy.Notifications != nil {
+		klog.V(1).InfoS("Warning: DryRun is set to True. Eviction notifications are not published.")
+	}
+	if rs.DryRun && deschedulerPolicy.EvictionApproval != nil {
+		klog.V(1).InfoS("Warning: DryRun is set to True. The eviction approval webhook is not called.")
+	}
+
 	if rs.LeaderElection.LeaderElect && rs.DryRun {
 		klog.V(1).Info("Warning: DryRun is set to True. You need to disable it to use Leader Election.")
 	}
@@ -312,6 +381,7 @@ func cachedClient(
 	nodeLister listersv1.NodeLister,
 	namespaceLister listersv1.NamespaceLister,
 	priorityClassLister schedulingv1.PriorityClassLister,
+	informerFactory informers.SharedInformerFactory,
 ) (clientset.Interface, error) {
 	fakeClient := fakeclientset.NewSimpleClientset()
 	// simulate a pod eviction by deleting a pod
@@ -379,9 +449,76 @@ func cachedClient(
 		}
 	}
 
+	// Copy the workloads the plugins look up through informers, e.g. the owners of pods.
+	// Only the informers registered by the plugins are running, the listers of the
+	// remaining ones are never started and stay empty.
+	workloads := map[string]func() ([]runtime.Object, error){
+		"replicasets": func() ([]runtime.Object, error) {
+			items, err := informerFactory.Apps().V1().ReplicaSets().Lister().List(labels.Everything())
+			return toObjects(items), err
+		},
+		"deployments": func() ([]runtime.Object, error) {
+			items, err := informerFactory.Apps().V1().Deployments().Lister().List(labels.Everything())
+			return toObjects(items), err
+		},
+		"statefulsets": func() ([]runtime.Object, error) {
+			items, err := informerFactory.Apps().V1().StatefulSets().Lister().List(labels.Everything())
+			return toObjects(items), err
+		},
+		"daemonsets": func() ([]runtime.Object, error) {
+			items, err := informerFactory.Apps().V1().DaemonSets().Lister().List(labels.Everything())
+			return toObjects(items), err
+		},
+		"controllerrevisions": func() ([]runtime.Object, error) {
+			items, err := informerFactory.Apps().V1().ControllerRevisions().Lister().List(labels.Everything())
+			return toObjects(items), err
+		},
+		"jobs": func() ([]runtime.Object, error) {
+			items, err := informerFactory.Batch().V1().Jobs().Lister().List(labels.Everything())
+			return toObjects(items), err
+		},
+	}
+	for resource, list := range workloads {
+		items, err := list()
+		if err != nil {
+			return nil, fmt.Errorf("unable to list %s: %v", resource, err)
+		}
+		for _, item := range items {
+			if err := fakeClient.Tracker().Add(item); err != nil {
+				return nil, fmt.Errorf("unable to copy %s: %v", resource, err)
+			}
+		}
+	}
+
 	return fakeClient, nil
 }
 
+func toObjects[T runtime.Object](items []T) []runtime.Object {
+	objects := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		objects = append(objects, item)
+	}
+	return objects
+}
+
+// registerInformers builds the profiles once so the plugins request the informers
+// they need from the shared informer factory before the factory gets started.
+func (d *descheduler) registerInformers(client clientset.Interface, sharedInformerFactory informers.SharedInformerFactory) {
+	podEvictor := evictions.NewPodEvictor(evictions.NewRecordOnlyBackend(client, d.evictionPolicyGroupVersion), nil, nil, nil, false, d.eventRecorder)
+	for _, profile := range d.deschedulerPolicy.Profiles {
+		if _, err := frameworkprofile.NewProfile(
+			profile,
+			pluginregistry.PluginRegistry,
+			frameworkprofile.WithClientSet(client),
+			frameworkprofile.WithSharedInformerFactory(sharedInformerFactory),
+			frameworkprofile.WithPodEvictor(podEvictor),
+			frameworkprofile.WithGetPodsAssignedToNodeFnc(d.getPodsAssignedToNode),
+		); err != nil {
+			klog.ErrorS(err, "unable to create a profile", "profile", profile.Name)
+		}
+	}
+}
+
 func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer, deschedulerPolicy *api.DeschedulerPolicy, evictionPolicyGroupVersion string) error {
 	var span trace.Span
 	ctx, span = tracing.Tracer().Start(ctx, "RunDeschedulerStrategies")
@@ -409,9 +546,29 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 		span.AddEvent("Failed to create new descheduler", trace.WithAttributes(attribute.String("err", err.Error())))
 		return err
 	}
+	if deschedulerPolicy.Audit != nil {
+		auditWriter, err := newAuditWriter(deschedulerPolicy.Audit)
+		if err != nil {
+			return fmt.Errorf("unable to open audit log: %v", err)
+		}
+		defer auditWriter.Close()
+		descheduler.auditWriter = auditWriter
+	}
+
+	if deschedulerPolicy.Notifications != nil && !rs.DryRun {
+		dispatcher, err := newNotificationDispatcher(deschedulerPolicy.Notifications)
+		if err != nil {
+			return fmt.Errorf("unable to build notification sinks: %v", err)
+		}
+		dispatcher.Start()
+		defer dispatcher.Stop(notificationsFlushTimeout)
+		descheduler.notifier = dispatcher
+	}
+
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	descheduler.registerInformers(rs.Client, sharedInformerFactory)
 	sharedInformerFactory.Start(ctx.Done())
 	sharedInformerFactory.WaitForCacheSync(ctx.Done())
 
@@ -442,6 +599,83 @@ func RunDeschedulerStrategies(ctx context.Context, rs *options.DeschedulerServer
 	return nil
 }
 
+// newEvictionBackend builds the eviction backend configured in the policy.
+// The Eviction API is used when no backend is configured.
+func newEvictionBackend(client clientset.Interface, evictionPolicyGroupVersion string, config *api.EvictionBackend) (evictions.EvictionBackend, error) {
+	if config == nil {
+		return evictions.NewEvictionAPIBackend(client, evictionPolicyGroupVersion), nil
+	}
+	switch config.Type {
+	case "", api.EvictionBackendEvictionAPI:
+		return evictions.NewEvictionAPIBackend(client, evictionPolicyGroupVersion), nil
+	case api.EvictionBackendDelete:
+		return evictions.NewDeleteBackend(client), nil
+	case api.EvictionBackendRolloutRestart:
+		return evictions.NewRolloutRestartBackend(client), nil
+	case api.EvictionBackendRecordOnly:
+		return evictions.NewRecordOnlyBackend(nil, evictionPolicyGroupVersion), nil
+	case api.EvictionBackendWebhook:
+		if config.Webhook == nil {
+			return nil, fmt.Errorf("webhook eviction backend requires a webhook configuration")
+		}
+		httpClient, err := newWebhookHTTPClient(config.Webhook)
+		if err != nil {
+			return nil, err
+		}
+		return evictions.NewWebhookBackend(config.Webhook.URL, httpClient), nil
+	default:
+		return nil, fmt.Errorf("unknown eviction backend %q", config.Type)
+	}
+}
+
+// newWebhookApprover builds the approver asking the configured endpoint to
+// approve evictions. Evictions are denied on errors unless the failure policy is Ignore.
+func newWebhookApprover(config *api.EvictionApproval) (evictions.EvictionApprover, error) {
+	httpClient, err := newWebhookHTTPClient(&config.Webhook)
+	if err != nil {
+		return nil, err
+	}
+	return evictions.NewWebhookApprover(config.Webhook.URL, httpClient, config.FailurePolicy == api.FailurePolicyIgnore), nil
+}
+
+// newAuditWriter opens the configured audit log.
+func newAuditWriter(config *api.AuditLog) (*audit.FileWriter, error) {
+	opts := audit.FileWriterOptions{}
+	if config.MaxSize != nil {
+		opts.MaxSize = config.MaxSize.Value()
+	}
+	if config.MaxAge != nil {
+		opts.MaxAge = config.MaxAge.Duration
+	}
+	return audit.NewFileWriter(config.Path, opts)
+}
+
+// newNotificationDispatcher builds a dispatcher delivering to the configured sinks.
+func newNotificationDispatcher(config *api.Notifications) (*notifications.Dispatcher, error) {
+	var sinks []notifications.Sink
+	for _, sink := range config.Sinks {
+		switch {
+		case sink.Webhook != nil:
+			httpClient, err := newWebhookHTTPClient(sink.Webhook)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, notifications.NewWebhookSink(sink.Webhook.URL, httpClient))
+		case sink.File != nil:
+			sinks = append(sinks, notifications.NewFileSink(sink.File.Path))
+		}
+	}
+	return notifications.NewDispatcher(sinks, notifications.DefaultDispatcherOptions()), nil
+}
+
+func newWebhookHTTPClient(config *api.WebhookClientConfig) (*http.Client, error) {
+	var timeout time.Duration
+	if config.Timeout != nil {
+		timeout = config.Timeout.Duration
+	}
+	return evictions.NewWebhookHTTPClient(config.CAFile, timeout)
+}
+
 func GetPluginConfig(pluginName string, pluginConfigs []api.PluginConfig) (*api.PluginConfig, int) {
 	for idx, pluginConfig := range pluginConfigs {
 		if pluginConfig.Name == pluginName {
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	policy "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	apiversion "k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/informers"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"sigs.k8s.io/descheduler/cmd/descheduler/app/options"
@@ -323,3 +325,41 @@ func podEvictionReactionFuc(evictedPods *[]string) func(action core.Action) (boo
 		return false, nil, nil // fallback to the default reactor
 	}
 }
+
+func TestCachedClientCopiesWorkloads(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "rs", Namespace: "dev"}}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "dev"}}
+	client := fakeclientset.NewSimpleClientset(rs, deployment)
+
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	podLister := sharedInformerFactory.Core().V1().Pods().Lister()
+	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+	namespaceLister := sharedInformerFactory.Core().V1().Namespaces().Lister()
+	priorityClassLister := sharedInformerFactory.Scheduling().V1().PriorityClasses().Lister()
+	// only the replica set informer is requested by a plugin
+	sharedInformerFactory.Apps().V1().ReplicaSets().Informer()
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	fakeClient, err := cachedClient(client, podLister, nodeLister, namespaceLister, priorityClassLister, sharedInformerFactory)
+	if err != nil {
+		t.Fatalf("Unable to build the cached client: %v", err)
+	}
+
+	if _, err := fakeClient.AppsV1().ReplicaSets("dev").Get(ctx, "rs", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the replica set to be copied: %v", err)
+	}
+	if _, err := fakeClient.AppsV1().Deployments("dev").Get(ctx, "deployment", metav1.GetOptions{}); err == nil {
+		t.Errorf("Expected the deployment not requested by any plugin not to be copied")
+	}
+	// writes never reach the cluster
+	if err := fakeClient.AppsV1().ReplicaSets("dev").Delete(ctx, "rs", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Unable to delete the replica set: %v", err)
+	}
+	if _, err := client.AppsV1().ReplicaSets("dev").Get(ctx, "rs", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the replica set to stay in the cluster: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// EvictionApprover has the final say on whether a pod can be evicted.
+// It is consulted by PodEvictor after the eviction limits are checked
+// and before the pod is handed over to the EvictionBackend.
+type EvictionApprover interface {
+	// Approve decides on the pending eviction. A non-nil error reports a
+	// failure to reach a decision, the returned response is still honored.
+	Approve(ctx context.Context, pod *v1.Pod, opts EvictOptions) (EvictionApprovalResponse, error)
+}
+
+// EvictionApprovalResponse is the answer of an approval endpoint.
+type EvictionApprovalResponse struct {
+	Allowed bool   `json:"allowed"`
+	Message string `json:"message,omitempty"`
+}
+
+type webhookApprover struct {
+	url      string
+	client   *http.Client
+	failOpen bool
+}
+
+var _ EvictionApprover = &webhookApprover{}
+
+// NewWebhookApprover asks an external HTTP(S) endpoint to approve evictions.
+// Each pending eviction is sent as a JSON encoded EvictionRequest in a POST
+// request and the endpoint answers with a JSON encoded EvictionApprovalResponse.
+// When the endpoint can not be reached or answers with an unexpected response
+// the eviction is allowed if failOpen is set and denied otherwise.
+func NewWebhookApprover(url string, client *http.Client, failOpen bool) EvictionApprover {
+	return &webhookApprover{
+		url:      url,
+		client:   client,
+		failOpen: failOpen,
+	}
+}
+
+func (a *webhookApprover) Approve(ctx context.Context, pod *v1.Pod, opts EvictOptions) (EvictionApprovalResponse, error) {
+	response, err := a.approve(ctx, pod, opts)
+	if err != nil {
+		return EvictionApprovalResponse{Allowed: a.failOpen, Message: err.Error()}, err
+	}
+	return response, nil
+}
+
+func (a *webhookApprover) approve(ctx context.Context, pod *v1.Pod, opts EvictOptions) (EvictionApprovalResponse, error) {
+	response := EvictionApprovalResponse{}
+	body, err := json.Marshal(NewEvictionRequest(pod, opts))
+	if err != nil {
+		return response, fmt.Errorf("unable to encode eviction request: %v", err)
+	}
+	resp, err := PostJSON(ctx, a.client, a.url, body)
+	if err != nil {
+		return response, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return response, fmt.Errorf("approval webhook returned %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return response, fmt.Errorf("unable to decode approval response: %v", err)
+	}
+	return response, nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestWebhookApprover(t *testing.T) {
+	node := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod := test.BuildTestPod("p1", 100, 0, node.Name, nil)
+
+	tests := []struct {
+		description string
+		handler     http.HandlerFunc
+		failOpen    bool
+		expectErr   bool
+		expectEvict bool
+	}{
+		{
+			description: "endpoint allows the eviction",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(EvictionApprovalResponse{Allowed: true})
+			},
+			expectEvict: true,
+		},
+		{
+			description: "endpoint denies the eviction",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(EvictionApprovalResponse{Allowed: false, Message: "incident freeze"})
+			},
+			failOpen: true,
+		},
+		{
+			description: "endpoint fails, fail closed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			expectErr: true,
+		},
+		{
+			description: "endpoint fails, fail open",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			failOpen:    true,
+			expectErr:   true,
+			expectEvict: true,
+		},
+		{
+			description: "endpoint returns an invalid response, fail closed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("not json"))
+			},
+			expectErr: true,
+		},
+		{
+			description: "endpoint times out, fail closed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(200 * time.Millisecond)
+				json.NewEncoder(w).Encode(EvictionApprovalResponse{Allowed: true})
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.Background()
+			requests := make(chan EvictionRequest, 2)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var received EvictionRequest
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("Unable to decode request: %v", err)
+				}
+				requests <- received
+				tc.handler(w, r)
+			}))
+			defer server.Close()
+
+			client := server.Client()
+			client.Timeout = 50 * time.Millisecond
+			approver := NewWebhookApprover(server.URL, client, tc.failOpen)
+
+			opts := EvictOptions{Reason: "testing", StrategyName: "plugin", ProfileName: "profile"}
+			response, err := approver.Approve(ctx, pod, opts)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("Expected error: %v, got: %v", tc.expectErr, err)
+			}
+			expected := EvictionRequest{Pod: pod.Name, Namespace: pod.Namespace, UID: string(pod.UID), Node: node.Name, Profile: "profile", Plugin: "plugin", Reason: "testing"}
+			if received := <-requests; received != expected {
+				t.Errorf("Expected request %+v, got %+v", expected, received)
+			}
+
+			podEvictor := NewPodEvictor(NewRecordOnlyBackend(nil, "v1"), nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{}, WithApprover(approver))
+			if evicted := podEvictor.EvictPod(ctx, pod, opts); evicted != tc.expectEvict {
+				t.Errorf("Expected pod to be evicted: %v, got: %v (%v)", tc.expectEvict, evicted, response.Message)
+			}
+			if tc.expectEvict != (podEvictor.TotalEvicted() == 1) {
+				t.Errorf("Unexpected number of evictions: %d", podEvictor.TotalEvicted())
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
+)
+
+const (
+	// restartedAtAnnotationKey is the pod template annotation kubectl sets
+	// when performing a rollout restart.
+	restartedAtAnnotationKey = "kubectl.kubernetes.io/restartedAt"
+	// DefaultWebhookTimeout is used when a webhook does not configure its own timeout.
+	DefaultWebhookTimeout = 10 * time.Second
+)
+
+// ErrOwnerAlreadyRestarted is returned by the RolloutRestart backend when the owner
+// of the pod is already rolling out. The pod is going to be replaced by the rollout,
+// but it was not evicted.
+var ErrOwnerAlreadyRestarted = errors.New("owner is already rolling out")
+
+// EvictionBackend removes a pod once PodEvictor decided it can be evicted.
+// Eviction limits, metrics and events are handled by PodEvictor, a backend
+// is only responsible for the actual disruption.
+type EvictionBackend interface {
+	// Name identifies the backend in logs and traces.
+	Name() string
+	// Evict removes the pod. A non-nil error means the pod was not evicted.
+	Evict(ctx context.Context, pod *v1.Pod, opts EvictOptions) error
+}
+
+// EvictionRequest describes an eviction to an external HTTP endpoint.
+type EvictionRequest struct {
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+	Node      string `json:"node"`
+	Profile   string `json:"profile"`
+	Plugin    string `json:"plugin"`
+	Reason    string `json:"reason"`
+}
+
+// NewEvictionRequest describes the eviction of the given pod.
+func NewEvictionRequest(pod *v1.Pod, opts EvictOptions) EvictionRequest {
+	return EvictionRequest{
+		Pod:       pod.Name,
+		Namespace: pod.Namespace,
+		UID:       string(pod.UID),
+		Node:      pod.Spec.NodeName,
+		Profile:   opts.ProfileName,
+		Plugin:    opts.StrategyName,
+		Reason:    opts.Reason,
+	}
+}
+
+type evictionAPIBackend struct {
+	client             clientset.Interface
+	policyGroupVersion string
+}
+
+var _ EvictionBackend = &evictionAPIBackend{}
+
+// NewEvictionAPIBackend evicts pods through the policy Eviction API,
+// which respects PodDisruptionBudgets.
+func NewEvictionAPIBackend(client clientset.Interface, policyGroupVersion string) EvictionBackend {
+	return &evictionAPIBackend{
+		client:             client,
+		policyGroupVersion: policyGroupVersion,
+	}
+}
+
+func (b *evictionAPIBackend) Name() string {
+	return "EvictionAPI"
+}
+
+func (b *evictionAPIBackend) Evict(ctx context.Context, pod *v1.Pod, _ EvictOptions) error {
+	return evictPod(ctx, b.client, pod, b.policyGroupVersion)
+}
+
+type deleteBackend struct {
+	client clientset.Interface
+}
+
+var _ EvictionBackend = &deleteBackend{}
+
+// NewDeleteBackend deletes pods directly. PodDisruptionBudgets are not respected.
+func NewDeleteBackend(client clientset.Interface) EvictionBackend {
+	return &deleteBackend{client: client}
+}
+
+func (b *deleteBackend) Name() string {
+	return "Delete"
+}
+
+func (b *deleteBackend) Evict(ctx context.Context, pod *v1.Pod, _ EvictOptions) error {
+	deleteOptions := metav1.DeleteOptions{}
+	if pod.UID != "" {
+		// Make sure a pod recreated under the same name is not deleted instead.
+		deleteOptions.Preconditions = metav1.NewUIDPreconditions(string(pod.UID))
+	}
+	err := b.client.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, deleteOptions)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("pod not found when deleting %q: %v", pod.Name, err)
+	}
+	return err
+}
+
+type rolloutRestartBackend struct {
+	client clientset.Interface
+	// restarted keeps owners already restarted by this backend so all
+	// their pods count as evicted without triggering another rollout.
+	restarted sets.Set[types.UID]
+}
+
+var _ EvictionBackend = &rolloutRestartBackend{}
+
+// NewRolloutRestartBackend replaces pods by triggering a rollout restart of their
+// Deployment, StatefulSet or DaemonSet, the same way kubectl rollout restart does.
+// Each owner is restarted at most once per backend instance and owners still
+// rolling out are not restarted again.
+func NewRolloutRestartBackend(client clientset.Interface) EvictionBackend {
+	return &rolloutRestartBackend{
+		client:    client,
+		restarted: sets.New[types.UID](),
+	}
+}
+
+func (b *rolloutRestartBackend) Name() string {
+	return "RolloutRestart"
+}
+
+func (b *rolloutRestartBackend) Evict(ctx context.Context, pod *v1.Pod, _ EvictOptions) error {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		return fmt.Errorf("pod %q has no controller to restart", pod.Name)
+	}
+
+	kind, name, uid := ownerRef.Kind, ownerRef.Name, ownerRef.UID
+	if kind == "ReplicaSet" {
+		rs, err := b.client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to get owner of pod %q: %v", pod.Name, err)
+		}
+		rsOwnerRef := metav1.GetControllerOf(rs)
+		if rsOwnerRef == nil || rsOwnerRef.Kind != "Deployment" {
+			return fmt.Errorf("replicaset %q of pod %q is not owned by a deployment", name, pod.Name)
+		}
+		kind, name, uid = rsOwnerRef.Kind, rsOwnerRef.Name, rsOwnerRef.UID
+	}
+
+	if b.restarted.Has(uid) {
+		klog.V(3).InfoS("Owner already restarted", "pod", klog.KObj(pod), "kind", kind, "name", name)
+		return ErrOwnerAlreadyRestarted
+	}
+
+	// owners restarted in a previous cycle are still rolling out, restarting
+	// them again would only start the rollout over
+	inProgress, err := b.rolloutInProgress(ctx, pod.Namespace, kind, name)
+	if err != nil {
+		return err
+	}
+	if inProgress {
+		klog.V(3).InfoS("Owner is still rolling out", "pod", klog.KObj(pod), "kind", kind, "name", name)
+		return ErrOwnerAlreadyRestarted
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, restartedAtAnnotationKey, time.Now().Format(time.RFC3339)))
+	switch kind {
+	case "Deployment":
+		_, err = b.client.AppsV1().Deployments(pod.Namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = b.client.AppsV1().StatefulSets(pod.Namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = b.client.AppsV1().DaemonSets(pod.Namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to restart %s %q: %v", kind, name, err)
+	}
+	b.restarted.Insert(uid)
+	return nil
+}
+
+// rolloutInProgress tells whether the owner has not finished rolling out its
+// current revision yet, the same way kubectl rollout status does.
+func (b *rolloutRestartBackend) rolloutInProgress(ctx context.Context, namespace, kind, name string) (bool, error) {
+	switch kind {
+	case "Deployment":
+		deployment, err := b.client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("unable to get %s %q: %v", kind, name, err)
+		}
+		return deploymentRolloutInProgress(deployment), nil
+	case "StatefulSet":
+		statefulSet, err := b.client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("unable to get %s %q: %v", kind, name, err)
+		}
+		return statefulSetRolloutInProgress(statefulSet), nil
+	case "DaemonSet":
+		daemonSet, err := b.client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("unable to get %s %q: %v", kind, name, err)
+		}
+		return daemonSetRolloutInProgress(daemonSet), nil
+	}
+	return false, fmt.Errorf("rollout restart of %s %q is not supported", kind, name)
+}
+
+func deploymentRolloutInProgress(deployment *appsv1.Deployment) bool {
+	status := deployment.Status
+	return status.ObservedGeneration < deployment.Generation ||
+		status.UpdatedReplicas < replicas(deployment.Spec.Replicas) ||
+		status.Replicas > status.UpdatedReplicas ||
+		status.AvailableReplicas < status.UpdatedReplicas
+}
+
+func statefulSetRolloutInProgress(statefulSet *appsv1.StatefulSet) bool {
+	status := statefulSet.Status
+	desired := replicas(statefulSet.Spec.Replicas)
+	if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		// pods below the partition keep running the previous revision
+		desired -= *rollingUpdate.Partition
+	}
+	return status.ObservedGeneration < statefulSet.Generation ||
+		status.UpdatedReplicas < desired ||
+		status.ReadyReplicas < replicas(statefulSet.Spec.Replicas)
+}
+
+func daemonSetRolloutInProgress(daemonSet *appsv1.DaemonSet) bool {
+	status := daemonSet.Status
+	return status.ObservedGeneration < daemonSet.Generation ||
+		status.UpdatedNumberScheduled < status.DesiredNumberScheduled ||
+		status.NumberAvailable < status.DesiredNumberScheduled
+}
+
+// replicas returns the desired number of replicas, which defaults to 1
+func replicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+type recordOnlyBackend struct {
+	cache              clientset.Interface
+	policyGroupVersion string
+}
+
+var _ EvictionBackend = &recordOnlyBackend{}
+
+// NewRecordOnlyBackend only records evictions without disrupting any pod.
+// When cache is not nil the eviction is replayed against it, so a cached
+// client used in dry run mode reflects the cumulative effect of all
+// evictions performed during a descheduling cycle.
+func NewRecordOnlyBackend(cache clientset.Interface, policyGroupVersion string) EvictionBackend {
+	return &recordOnlyBackend{
+		cache:              cache,
+		policyGroupVersion: policyGroupVersion,
+	}
+}
+
+func (b *recordOnlyBackend) Name() string {
+	return "RecordOnly"
+}
+
+func (b *recordOnlyBackend) Evict(ctx context.Context, pod *v1.Pod, _ EvictOptions) error {
+	if b.cache == nil {
+		return nil
+	}
+	return evictPod(ctx, b.cache, pod, b.policyGroupVersion)
+}
+
+type webhookBackend struct {
+	url    string
+	client *http.Client
+}
+
+var _ EvictionBackend = &webhookBackend{}
+
+// NewWebhookBackend delegates evictions to an external HTTP(S) endpoint.
+// Each eviction is sent as a JSON encoded EvictionRequest in a POST request,
+// any 2xx response means the endpoint evicted the pod.
+func NewWebhookBackend(url string, client *http.Client) EvictionBackend {
+	return &webhookBackend{
+		url:    url,
+		client: client,
+	}
+}
+
+func (b *webhookBackend) Name() string {
+	return "Webhook"
+}
+
+func (b *webhookBackend) Evict(ctx context.Context, pod *v1.Pod, opts EvictOptions) error {
+	body, err := json.Marshal(NewEvictionRequest(pod, opts))
+	if err != nil {
+		return fmt.Errorf("unable to encode eviction request: %v", err)
+	}
+	resp, err := PostJSON(ctx, b.client, b.url, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("eviction webhook returned %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// PostJSON sends body as a JSON encoded POST request to url.
+func PostJSON(ctx context.Context, client *http.Client, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("unable to build request for %q: %v", url, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %q failed: %v", url, err)
+	}
+	return resp, nil
+}
+
+// NewWebhookHTTPClient builds an HTTP client for calling webhooks. The server
+// certificate is verified against the PEM encoded CA bundle in caFile when set.
+func NewWebhookHTTPClient(caFile string, timeout time.Duration) (*http.Client, error) {
+	if timeout <= 0 {
+		timeout = DefaultWebhookTimeout
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caFile != "" {
+		caBundle, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file %q: %v", caFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no certificates found in CA file %q", caFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
+
+func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string) error {
+	deleteOptions := &metav1.DeleteOptions{}
+	// GracePeriodSeconds ?
+	eviction := &policy.Eviction{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyGroupVersion,
+			Kind:       eutils.EvictionKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+		DeleteOptions: deleteOptions,
+	}
+	err := client.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+
+	if apierrors.IsTooManyRequests(err) {
+		return fmt.Errorf("error when evicting pod (ignoring) %q: %v", pod.Name, err)
+	}
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("pod not found when evicting %q: %v", pod.Name, err)
+	}
+	return err
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package evictions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDeleteBackend(t *testing.T) {
+	ctx := context.Background()
+	pod := test.BuildTestPod("p1", 100, 0, "node1", nil)
+	client := fake.NewSimpleClientset(pod)
+
+	backend := NewDeleteBackend(client)
+	if err := backend.Evict(ctx, pod, EvictOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{}); err == nil {
+		t.Errorf("Expected pod %q to be deleted", pod.Name)
+	}
+	if err := backend.Evict(ctx, pod, EvictOptions{}); err == nil {
+		t.Errorf("Expected error when deleting a missing pod")
+	}
+}
+
+func TestRolloutRestartBackend(t *testing.T) {
+	ctx := context.Background()
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "default", UID: "deployment-uid", Generation: 1},
+		Spec:       appsv1.DeploymentSpec{Replicas: utilptr.To[int32](2)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			AvailableReplicas:  2,
+		},
+	}
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "rs",
+			Namespace: "default",
+			UID:       "rs-uid",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Deployment", Name: deployment.Name, UID: deployment.UID, Controller: utilptr.To(true)},
+			},
+		},
+	}
+	rsOwnerRefs := []metav1.OwnerReference{{Kind: "ReplicaSet", Name: rs.Name, UID: rs.UID, Controller: utilptr.To(true)}}
+	p1 := test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) { pod.OwnerReferences = rsOwnerRefs })
+	p2 := test.BuildTestPod("p2", 100, 0, "node1", func(pod *v1.Pod) { pod.OwnerReferences = rsOwnerRefs })
+	bare := test.BuildTestPod("bare", 100, 0, "node1", nil)
+
+	client := fake.NewSimpleClientset(deployment, rs, p1, p2, bare)
+	countPatches := func() int {
+		patches := 0
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "patch" && action.GetResource().Resource == "deployments" {
+				patches++
+			}
+		}
+		return patches
+	}
+
+	backend := NewRolloutRestartBackend(client)
+	if err := backend.Evict(ctx, p1, EvictOptions{}); err != nil {
+		t.Fatalf("Unexpected error evicting %q: %v", p1.Name, err)
+	}
+	if err := backend.Evict(ctx, p2, EvictOptions{}); !errors.Is(err, ErrOwnerAlreadyRestarted) {
+		t.Errorf("Expected %v evicting %q, got %v", ErrOwnerAlreadyRestarted, p2.Name, err)
+	}
+	if patches := countPatches(); patches != 1 {
+		t.Errorf("Expected the deployment to be restarted once, got %d patches", patches)
+	}
+
+	updated, err := client.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unable to get deployment: %v", err)
+	}
+	if _, ok := updated.Spec.Template.Annotations[restartedAtAnnotationKey]; !ok {
+		t.Errorf("Expected deployment pod template to have the %q annotation", restartedAtAnnotationKey)
+	}
+
+	// the next cycle builds a new backend while the restart is still rolling out
+	updated.Generation = 2
+	updated.Status.UpdatedReplicas = 1
+	if _, err := client.AppsV1().Deployments(deployment.Namespace).Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to update deployment: %v", err)
+	}
+	if err := NewRolloutRestartBackend(client).Evict(ctx, p2, EvictOptions{}); !errors.Is(err, ErrOwnerAlreadyRestarted) {
+		t.Errorf("Expected %v while the deployment is rolling out, got %v", ErrOwnerAlreadyRestarted, err)
+	}
+	if patches := countPatches(); patches != 1 {
+		t.Errorf("Expected the deployment not to be restarted while rolling out, got %d patches", patches)
+	}
+
+	if err := backend.Evict(ctx, bare, EvictOptions{}); err == nil {
+		t.Errorf("Expected error when restarting a pod without a controller")
+	}
+}
+
+func TestEvictPodOwnerAlreadyRestarted(t *testing.T) {
+	ctx := context.Background()
+	node := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "default", UID: "ds-uid"},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 2,
+			UpdatedNumberScheduled: 2,
+			NumberAvailable:        2,
+		},
+	}
+	ownerRefs := []metav1.OwnerReference{{Kind: "DaemonSet", Name: daemonSet.Name, UID: daemonSet.UID, Controller: utilptr.To(true)}}
+	p1 := test.BuildTestPod("p1", 100, 0, node.Name, func(pod *v1.Pod) { pod.OwnerReferences = ownerRefs })
+	p2 := test.BuildTestPod("p2", 100, 0, node.Name, func(pod *v1.Pod) { pod.OwnerReferences = ownerRefs })
+
+	client := fake.NewSimpleClientset(daemonSet, p1, p2)
+	podEvictor := NewPodEvictor(NewRolloutRestartBackend(client), nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+
+	if !podEvictor.EvictPod(ctx, p1, EvictOptions{}) {
+		t.Errorf("Expected %q to be evicted", p1.Name)
+	}
+	if podEvictor.EvictPod(ctx, p2, EvictOptions{}) {
+		t.Errorf("Expected %q not to be evicted once its owner is restarted", p2.Name)
+	}
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected 1 eviction, got %d", podEvictor.TotalEvicted())
+	}
+	if podEvictor.NodeEvicted(node) != 1 {
+		t.Errorf("Expected 1 eviction on %q, got %d", node.Name, podEvictor.NodeEvicted(node))
+	}
+	if evicted := podEvictor.EvictedOwnerPods(daemonSet.UID); evicted.Len() != 1 || !evicted.Has(p1.UID) {
+		t.Errorf("Expected only %q to be evicted for the owner, got %v", p1.Name, sets.List(evicted))
+	}
+}
+
+func TestRecordOnlyBackend(t *testing.T) {
+	ctx := context.Background()
+	pod := test.BuildTestPod("p1", 100, 0, "node1", nil)
+
+	if err := NewRecordOnlyBackend(nil, "v1").Evict(ctx, pod, EvictOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cache := fake.NewSimpleClientset(pod)
+	if err := NewRecordOnlyBackend(cache, "v1").Evict(ctx, pod, EvictOptions{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	evicted := false
+	for _, action := range cache.Actions() {
+		if action.GetVerb() == "create" && action.GetSubresource() == "eviction" {
+			evicted = true
+		}
+	}
+	if !evicted {
+		t.Errorf("Expected the eviction to be replayed against the cache client")
+	}
+}
+
+func TestWebhookBackend(t *testing.T) {
+	ctx := context.Background()
+	pod := test.BuildTestPod("p1", 100, 0, "node1", nil)
+
+	tests := []struct {
+		description string
+		statusCode  int
+		expectErr   bool
+	}{
+		{
+			description: "webhook evicts the pod",
+			statusCode:  http.StatusOK,
+		},
+		{
+			description: "webhook refuses to evict the pod",
+			statusCode:  http.StatusConflict,
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			var received EvictionRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Errorf("Unable to decode request: %v", err)
+				}
+				w.WriteHeader(tc.statusCode)
+			}))
+			defer server.Close()
+
+			backend := NewWebhookBackend(server.URL, server.Client())
+			err := backend.Evict(ctx, pod, EvictOptions{Reason: "testing", StrategyName: "plugin", ProfileName: "profile"})
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("Expected error: %v, got: %v", tc.expectErr, err)
+			}
+			expected := EvictionRequest{Pod: pod.Name, Namespace: pod.Namespace, UID: string(pod.UID), Node: "node1", Profile: "profile", Plugin: "plugin", Reason: "testing"}
+			if received != expected {
+				t.Errorf("Expected request %+v, got %+v", expected, received)
+			}
+		})
+	}
+}
+
+func TestEvictPodLimitsApplyToBackend(t *testing.T) {
+	ctx := context.Background()
+	node := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	p1 := test.BuildTestPod("p1", 100, 0, node.Name, nil)
+	p2 := test.BuildTestPod("p2", 100, 0, node.Name, nil)
+
+	podEvictor := NewPodEvictor(NewRecordOnlyBackend(nil, "v1"), utilptr.To[uint](1), nil, []*v1.Node{node}, false, &events.FakeRecorder{})
+
+	if !podEvictor.EvictPod(ctx, p1, EvictOptions{}) {
+		t.Errorf("Expected %q to be evicted", p1.Name)
+	}
+	if podEvictor.EvictPod(ctx, p2, EvictOptions{}) {
+		t.Errorf("Expected %q not to be evicted once the node limit is reached", p2.Name)
+	}
+	if podEvictor.TotalEvicted() != 1 {
+		t.Errorf("Expected 1 eviction, got %d", podEvictor.TotalEvicted())
+	}
+}
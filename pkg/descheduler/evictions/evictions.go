@@ -18,20 +18,20 @@ package evictions
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/core/v1"
-	policy "k8s.io/api/policy/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/metrics"
 
-	eutils "sigs.k8s.io/descheduler/pkg/descheduler/evictions/utils"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
+	"sigs.k8s.io/descheduler/pkg/descheduler/notifications"
 	"sigs.k8s.io/descheduler/pkg/tracing"
 )
 
@@ -39,30 +39,45 @@ import (
 type (
 	nodePodEvictedCount    map[string]uint
 	namespacePodEvictCount map[string]uint
+	// ownerEvictedPods keeps the pods evicted per owner UID
+	ownerEvictedPods map[types.UID]sets.Set[types.UID]
 )
 
 type PodEvictor struct {
-	client                     clientset.Interface
+	backend                    EvictionBackend
 	nodes                      []*v1.Node
-	policyGroupVersion         string
-	dryRun                     bool
 	maxPodsToEvictPerNode      *uint
 	maxPodsToEvictPerNamespace *uint
 	nodepodCount               nodePodEvictedCount
 	namespacePodCount          namespacePodEvictCount
+	ownerEvictedPods           ownerEvictedPods
 	metricsEnabled             bool
 	eventRecorder              events.EventRecorder
+	approver                   EvictionApprover
+	notifier                   notifications.Publisher
+	auditRecorder              audit.Recorder
 }
 
+// Option configures optional PodEvictor behavior
+type Option func(*PodEvictor)
+
+// WithApprover makes every eviction subject to the approver's decision
+func WithApprover(approver EvictionApprover) Option {
+	return func(pe *PodEvictor) {
+		pe.approver = approver
+	}
+}
+
+// NewPodEvictor creates a PodEvictor which removes pods through the given
+// backend while exercising the eviction limits.
 func NewPodEvictor(
-	client clientset.Interface,
-	policyGroupVersion string,
-	dryRun bool,
+	backend EvictionBackend,
 	maxPodsToEvictPerNode *uint,
 	maxPodsToEvictPerNamespace *uint,
 	nodes []*v1.Node,
 	metricsEnabled bool,
 	eventRecorder events.EventRecorder,
+	opts ...Option,
 ) *PodEvictor {
 	nodePodCount := make(nodePodEvictedCount)
 	namespacePodCount := make(namespacePodEvictCount)
@@ -71,18 +86,21 @@ func NewPodEvictor(
 		nodePodCount[node.Name] = 0
 	}
 
-	return &PodEvictor{
-		client:                     client,
+	pe := &PodEvictor{
+		backend:                    backend,
 		nodes:                      nodes,
-		policyGroupVersion:         policyGroupVersion,
-		dryRun:                     dryRun,
 		maxPodsToEvictPerNode:      maxPodsToEvictPerNode,
 		maxPodsToEvictPerNamespace: maxPodsToEvictPerNamespace,
 		nodepodCount:               nodePodCount,
 		namespacePodCount:          namespacePodCount,
+		ownerEvictedPods:           make(ownerEvictedPods),
 		metricsEnabled:             metricsEnabled,
 		eventRecorder:              eventRecorder,
 	}
+	for _, opt := range opts {
+		opt(pe)
+	}
+	return pe
 }
 
 // NodeEvicted gives a number of pods evicted for node
@@ -107,6 +125,44 @@ func (pe *PodEvictor) NodeLimitExceeded(node *v1.Node) bool {
 	return false
 }
 
+// EvictionsAllowed checks the per node and per namespace limits leave room to evict all the given pods
+func (pe *PodEvictor) EvictionsAllowed(pods []*v1.Pod) bool {
+	nodePodCount := map[string]uint{}
+	namespacePodCount := map[string]uint{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" {
+			nodePodCount[pod.Spec.NodeName]++
+			if pe.maxPodsToEvictPerNode != nil && pe.nodepodCount[pod.Spec.NodeName]+nodePodCount[pod.Spec.NodeName] > *pe.maxPodsToEvictPerNode {
+				return false
+			}
+		}
+		namespacePodCount[pod.Namespace]++
+		if pe.maxPodsToEvictPerNamespace != nil && pe.namespacePodCount[pod.Namespace]+namespacePodCount[pod.Namespace] > *pe.maxPodsToEvictPerNamespace {
+			return false
+		}
+	}
+	return true
+}
+
+// WithNotifier publishes a notification for every evicted pod
+func WithNotifier(notifier notifications.Publisher) Option {
+	return func(pe *PodEvictor) {
+		pe.notifier = notifier
+	}
+}
+
+// WithAuditRecorder records every eviction attempt in the audit log
+func WithAuditRecorder(recorder audit.Recorder) Option {
+	return func(pe *PodEvictor) {
+		pe.auditRecorder = recorder
+	}
+}
+
+// EvictedOwnerPods gives the UIDs of the owner's pods evicted so far
+func (pe *PodEvictor) EvictedOwnerPods(ownerUID types.UID) sets.Set[types.UID] {
+	return pe.ownerEvictedPods[ownerUID].Clone()
+}
+
 // EvictOptions provides a handle for passing additional info to EvictPod
 type EvictOptions struct {
 	// Reason allows for passing details about the specific eviction for logging.
@@ -131,6 +187,7 @@ func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, opts EvictOptio
 			}
 			span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", "Maximum number of evicted pods per node reached")))
 			klog.ErrorS(fmt.Errorf("maximum number of evicted pods per node reached"), "Error evicting pod", "limit", *pe.maxPodsToEvictPerNode, "node", pod.Spec.NodeName)
+			pe.audit(pod, opts, audit.OutcomeLimitReached, "maximum number of evicted pods per node reached")
 			return false
 		}
 	}
@@ -141,17 +198,41 @@ func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, opts EvictOptio
 		}
 		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", "Maximum number of evicted pods per namespace reached")))
 		klog.ErrorS(fmt.Errorf("maximum number of evicted pods per namespace reached"), "Error evicting pod", "limit", *pe.maxPodsToEvictPerNamespace, "namespace", pod.Namespace)
+		pe.audit(pod, opts, audit.OutcomeLimitReached, "maximum number of evicted pods per namespace reached")
 		return false
 	}
 
-	err := evictPod(ctx, pe.client, pod, pe.policyGroupVersion)
+	if pe.approver != nil {
+		response, err := pe.approver.Approve(ctx, pod, opts)
+		if err != nil {
+			klog.ErrorS(err, "Error requesting eviction approval", "pod", klog.KObj(pod), "allowed", response.Allowed)
+		}
+		if !response.Allowed {
+			if pe.metricsEnabled {
+				metrics.PodsEvicted.With(map[string]string{"result": "denied", "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}).Inc()
+			}
+			span.AddEvent("Eviction Denied", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("message", response.Message)))
+			klog.InfoS("Eviction denied", "pod", klog.KObj(pod), "message", response.Message, "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName)
+			pe.audit(pod, opts, audit.OutcomeDenied, response.Message)
+			return false
+		}
+	}
+
+	err := pe.backend.Evict(ctx, pod, opts)
+	if errors.Is(err, ErrOwnerAlreadyRestarted) {
+		// the pod stays until the rollout replaces it, it is not counted as evicted
+		span.AddEvent("Eviction Skipped", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("backend", pe.backend.Name()), attribute.String("message", err.Error())))
+		klog.V(3).InfoS("Owner already rolling out, pod not evicted", "pod", klog.KObj(pod), "reason", opts.Reason, "backend", pe.backend.Name())
+		return false
+	}
 	if err != nil {
 		// err is used only for logging purposes
-		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("err", err.Error())))
-		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", opts.Reason)
+		span.AddEvent("Eviction Failed", trace.WithAttributes(attribute.String("node", pod.Spec.NodeName), attribute.String("backend", pe.backend.Name()), attribute.String("err", err.Error())))
+		klog.ErrorS(err, "Error evicting pod", "pod", klog.KObj(pod), "reason", opts.Reason, "backend", pe.backend.Name())
 		if pe.metricsEnabled {
 			metrics.PodsEvicted.With(map[string]string{"result": "error", "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}).Inc()
 		}
+		pe.audit(pod, opts, audit.OutcomeFailed, err.Error())
 		return false
 	}
 
@@ -159,48 +240,50 @@ func (pe *PodEvictor) EvictPod(ctx context.Context, pod *v1.Pod, opts EvictOptio
 		pe.nodepodCount[pod.Spec.NodeName]++
 	}
 	pe.namespacePodCount[pod.Namespace]++
+	for _, ownerRef := range pod.OwnerReferences {
+		if pe.ownerEvictedPods[ownerRef.UID] == nil {
+			pe.ownerEvictedPods[ownerRef.UID] = sets.New[types.UID]()
+		}
+		pe.ownerEvictedPods[ownerRef.UID].Insert(pod.UID)
+	}
 
 	if pe.metricsEnabled {
 		metrics.PodsEvicted.With(map[string]string{"result": "success", "strategy": opts.StrategyName, "namespace": pod.Namespace, "node": pod.Spec.NodeName, "profile": opts.ProfileName}).Inc()
 	}
 
-	if pe.dryRun {
-		klog.V(1).InfoS("Evicted pod in dry run mode", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName)
-	} else {
-		klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName)
-		reason := opts.Reason
+	pe.audit(pod, opts, audit.OutcomeEvicted, "")
+	klog.V(1).InfoS("Evicted pod", "pod", klog.KObj(pod), "reason", opts.Reason, "strategy", opts.StrategyName, "node", pod.Spec.NodeName, "profile", opts.ProfileName, "backend", pe.backend.Name())
+	reason := opts.Reason
+	if len(reason) == 0 {
+		reason = opts.StrategyName
 		if len(reason) == 0 {
-			reason = opts.StrategyName
-			if len(reason) == 0 {
-				reason = "NotSet"
-			}
+			reason = "NotSet"
 		}
-		pe.eventRecorder.Eventf(pod, nil, v1.EventTypeNormal, reason, "Descheduled", "pod evicted from %v node by sigs.k8s.io/descheduler", pod.Spec.NodeName)
 	}
-	return true
-}
-
-func evictPod(ctx context.Context, client clientset.Interface, pod *v1.Pod, policyGroupVersion string) error {
-	deleteOptions := &metav1.DeleteOptions{}
-	// GracePeriodSeconds ?
-	eviction := &policy.Eviction{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: policyGroupVersion,
-			Kind:       eutils.EvictionKind,
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      pod.Name,
+	pe.eventRecorder.Eventf(pod, nil, v1.EventTypeNormal, reason, "Descheduled", "pod evicted from %v node by sigs.k8s.io/descheduler", pod.Spec.NodeName)
+	if pe.notifier != nil {
+		pe.notifier.Publish(notifications.NewEvent(notifications.PodEvictedEventType, notifications.PodEvictedData{
+			Pod:       pod.Name,
 			Namespace: pod.Namespace,
-		},
-		DeleteOptions: deleteOptions,
+			UID:       string(pod.UID),
+			Node:      pod.Spec.NodeName,
+			Profile:   opts.ProfileName,
+			Plugin:    opts.StrategyName,
+			Reason:    opts.Reason,
+			Backend:   pe.backend.Name(),
+		}))
 	}
-	err := client.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+	return true
+}
 
-	if apierrors.IsTooManyRequests(err) {
-		return fmt.Errorf("error when evicting pod (ignoring) %q: %v", pod.Name, err)
-	}
-	if apierrors.IsNotFound(err) {
-		return fmt.Errorf("pod not found when evicting %q: %v", pod.Name, err)
+func (pe *PodEvictor) audit(pod *v1.Pod, opts EvictOptions, outcome audit.Outcome, message string) {
+	if pe.auditRecorder == nil {
+		return
 	}
-	return err
+	record := audit.NewRecord(pod, outcome)
+	record.Profile = opts.ProfileName
+	record.Plugin = opts.StrategyName
+	record.Reason = opts.Reason
+	record.Message = message
+	pe.auditRecorder.Record(record)
 }
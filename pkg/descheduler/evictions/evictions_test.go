@@ -25,6 +25,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
+	"sigs.k8s.io/descheduler/pkg/descheduler/notifications"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
@@ -113,3 +117,90 @@ func TestPodTypes(t *testing.T) {
 		t.Errorf("Expected p1 to be a normal pod.")
 	}
 }
+
+type fakePublisher struct {
+	events []notifications.Event
+}
+
+func (p *fakePublisher) Publish(event notifications.Event) {
+	p.events = append(p.events, event)
+}
+
+func TestEvictPodPublishesNotification(t *testing.T) {
+	ctx := context.Background()
+	node := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	pod := test.BuildTestPod("p1", 400, 0, node.Name, nil)
+
+	publisher := &fakePublisher{}
+	podEvictor := NewPodEvictor(NewRecordOnlyBackend(nil, "v1"), nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{}, WithNotifier(publisher))
+	if !podEvictor.EvictPod(ctx, pod, EvictOptions{Reason: "testing", StrategyName: "plugin", ProfileName: "profile"}) {
+		t.Fatalf("Expected %q to be evicted", pod.Name)
+	}
+
+	if len(publisher.events) != 1 {
+		t.Fatalf("Expected 1 notification, got %d", len(publisher.events))
+	}
+	expected := notifications.PodEvictedData{Pod: pod.Name, Namespace: pod.Namespace, UID: string(pod.UID), Node: node.Name, Profile: "profile", Plugin: "plugin", Reason: "testing", Backend: "RecordOnly"}
+	if data, ok := publisher.events[0].Data.(notifications.PodEvictedData); !ok || data != expected {
+		t.Errorf("Expected notification data %+v, got %+v", expected, publisher.events[0].Data)
+	}
+}
+
+func TestEvictionsAllowed(t *testing.T) {
+	ctx := context.Background()
+	node := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	p1 := test.BuildTestPod("p1", 400, 0, node.Name, nil)
+	p2 := test.BuildTestPod("p2", 400, 0, node.Name, nil)
+	p3 := test.BuildTestPod("p3", 400, 0, "", nil)
+
+	podEvictor := NewPodEvictor(NewRecordOnlyBackend(nil, "v1"), utilptr.To[uint](2), utilptr.To[uint](3), []*v1.Node{node}, false, &events.FakeRecorder{})
+	if !podEvictor.EvictionsAllowed([]*v1.Pod{p1, p2}) {
+		t.Errorf("Expected the limits to allow evicting %q and %q", p1.Name, p2.Name)
+	}
+	if !podEvictor.EvictPod(ctx, p1, EvictOptions{}) {
+		t.Fatalf("Expected %q to be evicted", p1.Name)
+	}
+	if podEvictor.EvictionsAllowed([]*v1.Pod{p2, p3, p3}) {
+		t.Errorf("Expected the namespace limit to be exceeded")
+	}
+	if !podEvictor.EvictionsAllowed([]*v1.Pod{p2, p3}) {
+		t.Errorf("Expected the limits to allow evicting %q and %q", p2.Name, p3.Name)
+	}
+	if !podEvictor.EvictPod(ctx, p2, EvictOptions{}) {
+		t.Fatalf("Expected %q to be evicted", p2.Name)
+	}
+	if podEvictor.EvictionsAllowed([]*v1.Pod{p1}) {
+		t.Errorf("Expected the node limit to be exceeded")
+	}
+}
+
+type fakeAuditRecorder struct {
+	records []audit.Record
+}
+
+func (r *fakeAuditRecorder) Record(record audit.Record) {
+	r.records = append(r.records, record)
+}
+
+func TestEvictPodAuditsOutcome(t *testing.T) {
+	ctx := context.Background()
+	node := test.BuildTestNode("node1", 1000, 2000, 9, nil)
+	p1 := test.BuildTestPod("p1", 400, 0, node.Name, nil)
+	p2 := test.BuildTestPod("p2", 400, 0, node.Name, nil)
+
+	recorder := &fakeAuditRecorder{}
+	podEvictor := NewPodEvictor(NewRecordOnlyBackend(nil, "v1"), utilptr.To[uint](1), nil, []*v1.Node{node}, false, &events.FakeRecorder{}, WithAuditRecorder(recorder))
+	opts := EvictOptions{Reason: "testing", StrategyName: "plugin", ProfileName: "profile"}
+	podEvictor.EvictPod(ctx, p1, opts)
+	podEvictor.EvictPod(ctx, p2, opts)
+
+	if len(recorder.records) != 2 {
+		t.Fatalf("Expected 2 audit records, got %d", len(recorder.records))
+	}
+	for i, expected := range []audit.Outcome{audit.OutcomeEvicted, audit.OutcomeLimitReached} {
+		record := recorder.records[i]
+		if record.Outcome != expected || record.Profile != "profile" || record.Plugin != "plugin" || record.Reason != "testing" {
+			t.Errorf("Unexpected audit record %+v, expected outcome %v", record, expected)
+		}
+	}
+}
@@ -102,39 +102,79 @@ func IsReady(node *v1.Node) bool {
 
 // NodeFit returns true if the provided pod can be scheduled onto the provided node.
 // This function is used when the NodeFit pod filtering feature of the Descheduler is enabled.
-// This function currently considers a subset of the Kubernetes Scheduler's predicates when
-// deciding if a pod would fit on a node, but more predicates may be added in the future.
-func NodeFit(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node) []error {
+// This function considers a subset of the Kubernetes Scheduler's predicates when deciding if a pod
+// would fit on a node, DefaultNodeFitPredicates unless other predicates are set with WithPredicates.
+func NodeFit(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node, opts ...NodeFitOption) []error {
+	options := newNodeFitOptions(opts)
+	predicates := options.predicates
+	if options.snapshot != nil {
+		nodeIndexer = options.snapshot.GetPodsAssignedToNode
+	}
+	movingToNode := pod.Spec.NodeName == "" || pod.Spec.NodeName != node.Name
+
 	// Check node selector and required affinity
 	var errors []error
-	if ok, err := utils.PodMatchNodeSelector(pod, node); err != nil {
-		errors = append(errors, err)
-	} else if !ok {
-		errors = append(errors, fmt.Errorf("pod node selector does not match the node label"))
+	if predicates.Has(NodeAffinityPredicate) {
+		if ok, err := utils.PodMatchNodeSelector(pod, node); err != nil {
+			errors = append(errors, predicateError(NodeAffinityPredicate, "%v", err))
+		} else if !ok {
+			errors = append(errors, predicateError(NodeAffinityPredicate, "pod node selector does not match the node label"))
+		}
 	}
 	// Check taints (we only care about NoSchedule and NoExecute taints)
-	ok := utils.TolerationsTolerateTaintsWithFilter(pod.Spec.Tolerations, node.Spec.Taints, func(taint *v1.Taint) bool {
-		return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
-	})
-	if !ok {
-		errors = append(errors, fmt.Errorf("pod does not tolerate taints on the node"))
+	if predicates.Has(TaintTolerationPredicate) {
+		ok := utils.TolerationsTolerateTaintsWithFilter(pod.Spec.Tolerations, node.Spec.Taints, func(taint *v1.Taint) bool {
+			return taint.Effect == v1.TaintEffectNoSchedule || taint.Effect == v1.TaintEffectNoExecute
+		})
+		if !ok {
+			errors = append(errors, predicateError(TaintTolerationPredicate, "pod does not tolerate taints on the node"))
+		}
 	}
 	// Check if the pod can fit on a node based off it's requests
-	if pod.Spec.NodeName == "" || pod.Spec.NodeName != node.Name {
-		if ok, reqErrors := fitsRequest(nodeIndexer, pod, node); !ok {
+	if movingToNode && predicates.Has(NodeResourcesFitPredicate) {
+		if ok, reqErrors := fitsRequest(nodeIndexer, pod, node, options.snapshot); !ok {
 			errors = append(errors, reqErrors...)
 		}
 	}
+	// Check the node has room for one more pod
+	if movingToNode && predicates.Has(MaxPodsPredicate) {
+		if err := fitsMaxPods(nodeIndexer, pod, node); err != nil {
+			errors = append(errors, err)
+		}
+	}
 	// Check if node is schedulable
-	if IsNodeUnschedulable(node) {
-		errors = append(errors, fmt.Errorf("node is not schedulable"))
+	if predicates.Has(NodeUnschedulablePredicate) && IsNodeUnschedulable(node) {
+		errors = append(errors, predicateError(NodeUnschedulablePredicate, "node is not schedulable"))
 	}
 
 	// Check if pod matches inter-pod anti-affinity rule of pod on node
-	if match, err := podMatchesInterPodAntiAffinity(nodeIndexer, pod, node); err != nil {
-		errors = append(errors, err)
-	} else if match {
-		errors = append(errors, fmt.Errorf("pod matches inter-pod anti-affinity rule of other pod on node"))
+	if predicates.Has(InterPodAntiAffinityPredicate) {
+		if match, err := podMatchesInterPodAntiAffinity(nodeIndexer, pod, node); err != nil {
+			errors = append(errors, predicateError(InterPodAntiAffinityPredicate, "%v", err))
+		} else if match {
+			errors = append(errors, predicateError(InterPodAntiAffinityPredicate, "pod matches inter-pod anti-affinity rule of other pod on node"))
+		}
+	}
+
+	if predicates.Has(InterPodAffinityPredicate) {
+		if err := fitsInterPodAffinity(nodeIndexer, pod, node, options.nodes, options.snapshot); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if predicates.Has(PodTopologySpreadPredicate) {
+		if err := fitsPodTopologySpread(nodeIndexer, pod, node, options.nodes); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if movingToNode && predicates.Has(NodePortsPredicate) {
+		if err := fitsNodePorts(nodeIndexer, pod, node); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if predicates.Has(VolumeZonePredicate) && options.pvcLister != nil && options.pvLister != nil {
+		if err := fitsVolumeZone(pod, node, options.pvcLister, options.pvLister); err != nil {
+			errors = append(errors, err)
+		}
 	}
 
 	return errors
@@ -142,14 +182,15 @@ func NodeFit(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v
 
 // PodFitsAnyOtherNode checks if the given pod will fit any of the given nodes, besides the node
 // the pod is already running on. The predicates used to determine if the pod will fit can be found in the NodeFit function.
-func PodFitsAnyOtherNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, nodes []*v1.Node) bool {
+func PodFitsAnyOtherNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, nodes []*v1.Node, opts ...NodeFitOption) bool {
+	opts = append([]NodeFitOption{WithNodes(nodes)}, opts...)
 	for _, node := range nodes {
 		// Skip node pod is already on
 		if node.Name == pod.Spec.NodeName {
 			continue
 		}
 
-		errors := NodeFit(nodeIndexer, pod, node)
+		errors := NodeFit(nodeIndexer, pod, node, opts...)
 		if len(errors) == 0 {
 			klog.V(4).InfoS("Pod fits on node", "pod", klog.KObj(pod), "node", klog.KObj(node))
 			return true
@@ -163,9 +204,10 @@ func PodFitsAnyOtherNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.
 
 // PodFitsAnyNode checks if the given pod will fit any of the given nodes. The predicates used
 // to determine if the pod will fit can be found in the NodeFit function.
-func PodFitsAnyNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, nodes []*v1.Node) bool {
+func PodFitsAnyNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, nodes []*v1.Node, opts ...NodeFitOption) bool {
+	opts = append([]NodeFitOption{WithNodes(nodes)}, opts...)
 	for _, node := range nodes {
-		errors := NodeFit(nodeIndexer, pod, node)
+		errors := NodeFit(nodeIndexer, pod, node, opts...)
 		if len(errors) == 0 {
 			klog.V(4).InfoS("Pod fits on node", "pod", klog.KObj(pod), "node", klog.KObj(node))
 			return true
@@ -179,8 +221,8 @@ func PodFitsAnyNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod,
 
 // PodFitsCurrentNode checks if the given pod will fit onto the given node. The predicates used
 // to determine if the pod will fit can be found in the NodeFit function.
-func PodFitsCurrentNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node) bool {
-	errors := NodeFit(nodeIndexer, pod, node)
+func PodFitsCurrentNode(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node, opts ...NodeFitOption) bool {
+	errors := NodeFit(nodeIndexer, pod, node, opts...)
 	if len(errors) == 0 {
 		klog.V(4).InfoS("Pod fits on node", "pod", klog.KObj(pod), "node", klog.KObj(node))
 		return true
@@ -200,7 +242,7 @@ func IsNodeUnschedulable(node *v1.Node) bool {
 
 // fitsRequest determines if a pod can fit on a node based on its resource requests. It returns true if
 // the pod will fit.
-func fitsRequest(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node) (bool, []error) {
+func fitsRequest(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node, snapshot *Snapshot) (bool, []error) {
 	var insufficientResources []error
 
 	// Get pod requests
@@ -210,7 +252,7 @@ func fitsRequest(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, nod
 		resourceNames = append(resourceNames, name)
 	}
 
-	availableResources, err := nodeAvailableResources(nodeIndexer, node, resourceNames)
+	availableResources, err := nodeAvailableResources(nodeIndexer, node, resourceNames, snapshot)
 	if err != nil {
 		return false, []error{err}
 	}
@@ -220,26 +262,30 @@ func fitsRequest(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, nod
 		podResourceRequest := podRequests[resource]
 		availableResource, ok := availableResources[resource]
 		if !ok || podResourceRequest.MilliValue() > availableResource.MilliValue() {
-			insufficientResources = append(insufficientResources, fmt.Errorf("insufficient %v", resource))
+			insufficientResources = append(insufficientResources, predicateError(NodeResourcesFitPredicate, "insufficient %v", resource))
 			podFitsOnNode = false
 		}
 	}
-	// check pod num, at least one pod number is avaibalbe
-	if availableResources[v1.ResourcePods].MilliValue() <= 0 {
-		insufficientResources = append(insufficientResources, fmt.Errorf("insufficient %v", v1.ResourcePods))
-		podFitsOnNode = false
-	}
-
 	return podFitsOnNode, insufficientResources
 }
 
 // nodeAvailableResources returns resources mapped to the quanitity available on the node.
-func nodeAvailableResources(nodeIndexer podutil.GetPodsAssignedToNodeFunc, node *v1.Node, resourceNames []v1.ResourceName) (map[v1.ResourceName]*resource.Quantity, error) {
-	podsOnNode, err := podutil.ListPodsOnANode(node.Name, nodeIndexer, nil)
-	if err != nil {
-		return nil, err
+// The requests precomputed by the snapshot are used when one is given.
+func nodeAvailableResources(nodeIndexer podutil.GetPodsAssignedToNodeFunc, node *v1.Node, resourceNames []v1.ResourceName, snapshot *Snapshot) (map[v1.ResourceName]*resource.Quantity, error) {
+	var nodeUtilization map[v1.ResourceName]*resource.Quantity
+	if snapshot != nil {
+		nodeInfo, err := snapshot.NodeInfo(node.Name)
+		if err != nil {
+			return nil, err
+		}
+		nodeUtilization = nodeInfo.Usage(resourceNames)
+	} else {
+		podsOnNode, err := podutil.ListPodsOnANode(node.Name, nodeIndexer, nil)
+		if err != nil {
+			return nil, err
+		}
+		nodeUtilization = NodeUtilization(podsOnNode, resourceNames)
 	}
-	nodeUtilization := NodeUtilization(podsOnNode, resourceNames)
 	remainingResources := map[v1.ResourceName]*resource.Quantity{
 		v1.ResourceCPU:    resource.NewMilliQuantity(node.Status.Allocatable.Cpu().MilliValue()-nodeUtilization[v1.ResourceCPU].MilliValue(), resource.DecimalSI),
 		v1.ResourceMemory: resource.NewQuantity(node.Status.Allocatable.Memory().Value()-nodeUtilization[v1.ResourceMemory].Value(), resource.BinarySI),
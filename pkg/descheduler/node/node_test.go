@@ -773,7 +773,7 @@ func TestNodeFit(t *testing.T) {
 			podsOnNode: []*v1.Pod{
 				test.BuildTestPod("p2", 60000, 60*1000*1000*1000, "node", nil),
 			},
-			err: errors.New("insufficient cpu"),
+			err: errors.New("NodeResourcesFit: insufficient cpu"),
 		},
 		{
 			description: "insufficient pod num",
@@ -783,7 +783,7 @@ func TestNodeFit(t *testing.T) {
 				test.BuildTestPod("p2", 1000, 2*1000*1000*1000, "node", nil),
 				test.BuildTestPod("p3", 1000, 2*1000*1000*1000, "node", nil),
 			},
-			err: errors.New("insufficient pods"),
+			err: errors.New("MaxPods: insufficient pods"),
 		},
 		{
 			description: "matches inter-pod anti-affinity rule of pod on node",
@@ -792,7 +792,34 @@ func TestNodeFit(t *testing.T) {
 			podsOnNode: []*v1.Pod{
 				test.PodWithPodAntiAffinity(test.BuildTestPod("p2", 1000, 1000, node.Name, nil), "foo", "bar"),
 			},
-			err: errors.New("pod matches inter-pod anti-affinity rule of other pod on node"),
+			err: errors.New("InterPodAntiAffinity: pod matches inter-pod anti-affinity rule of other pod on node"),
+		},
+		{
+			description: "pod node selector does not match the node",
+			pod: test.BuildTestPod("p1", 1000, 1000, "", func(pod *v1.Pod) {
+				pod.Spec.NodeSelector = map[string]string{"region": "other-region"}
+			}),
+			node:       node,
+			podsOnNode: []*v1.Pod{},
+			err:        errors.New("NodeAffinity: pod node selector does not match the node label"),
+		},
+		{
+			description: "pod does not tolerate taints on the node",
+			pod:         test.BuildTestPod("p1", 1000, 1000, "", nil),
+			node: test.BuildTestNode("node", 64000, 128*1000*1000*1000, 2, func(node *v1.Node) {
+				node.Spec.Taints = []v1.Taint{{Key: "foo", Value: "bar", Effect: v1.TaintEffectNoSchedule}}
+			}),
+			podsOnNode: []*v1.Pod{},
+			err:        errors.New("TaintToleration: pod does not tolerate taints on the node"),
+		},
+		{
+			description: "node is unschedulable",
+			pod:         test.BuildTestPod("p1", 1000, 1000, "", nil),
+			node: test.BuildTestNode("node", 64000, 128*1000*1000*1000, 2, func(node *v1.Node) {
+				node.Spec.Unschedulable = true
+			}),
+			podsOnNode: []*v1.Pod{},
+			err:        errors.New("NodeUnschedulable: node is not schedulable"),
 		},
 		{
 			description: "pod fits on node",
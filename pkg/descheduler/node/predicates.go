@@ -0,0 +1,460 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	v1helper "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// NodeFitPredicate is a check performed by NodeFit, named after the scheduler plugin it mirrors.
+type NodeFitPredicate string
+
+const (
+	// NodeAffinityPredicate checks the node selector and the required node affinity of the pod.
+	NodeAffinityPredicate NodeFitPredicate = "NodeAffinity"
+	// TaintTolerationPredicate checks the pod tolerates the NoSchedule and NoExecute taints of the node.
+	TaintTolerationPredicate NodeFitPredicate = "TaintToleration"
+	// NodeResourcesFitPredicate checks the node has enough resources left for the requests of the pod.
+	NodeResourcesFitPredicate NodeFitPredicate = "NodeResourcesFit"
+	// NodeUnschedulablePredicate checks the node is not marked unschedulable.
+	NodeUnschedulablePredicate NodeFitPredicate = "NodeUnschedulable"
+	// InterPodAntiAffinityPredicate checks the required pod anti-affinity of the pod against the pods on the node.
+	InterPodAntiAffinityPredicate NodeFitPredicate = "InterPodAntiAffinity"
+	// MaxPodsPredicate checks the node has room for one more pod.
+	MaxPodsPredicate NodeFitPredicate = "MaxPods"
+	// InterPodAffinityPredicate checks the required pod affinity and anti-affinity of the pod, and the
+	// required anti-affinity of the existing pods, within the topology domains of the node.
+	InterPodAffinityPredicate NodeFitPredicate = "InterPodAffinity"
+	// PodTopologySpreadPredicate checks the DoNotSchedule topology spread constraints of the pod.
+	PodTopologySpreadPredicate NodeFitPredicate = "PodTopologySpread"
+	// NodePortsPredicate checks the host ports of the pod are free on the node.
+	NodePortsPredicate NodeFitPredicate = "NodePorts"
+	// VolumeZonePredicate checks the zone labels and node affinity of the persistent volumes of the pod.
+	VolumeZonePredicate NodeFitPredicate = "VolumeZone"
+)
+
+// DefaultNodeFitPredicates are the predicates checked by NodeFit unless set otherwise.
+var DefaultNodeFitPredicates = sets.New(
+	NodeAffinityPredicate,
+	TaintTolerationPredicate,
+	NodeResourcesFitPredicate,
+	NodeUnschedulablePredicate,
+	InterPodAntiAffinityPredicate,
+	MaxPodsPredicate,
+)
+
+// AllNodeFitPredicates are all the predicates known to NodeFit.
+var AllNodeFitPredicates = DefaultNodeFitPredicates.Clone().Insert(
+	InterPodAffinityPredicate,
+	PodTopologySpreadPredicate,
+	NodePortsPredicate,
+	VolumeZonePredicate,
+)
+
+// zoneLabels are the labels checked by the VolumeZone predicate
+var zoneLabels = []string{
+	v1.LabelFailureDomainBetaZone,
+	v1.LabelFailureDomainBetaRegion,
+	v1.LabelTopologyZone,
+	v1.LabelTopologyRegion,
+}
+
+type nodeFitOptions struct {
+	predicates sets.Set[NodeFitPredicate]
+	nodes      []*v1.Node
+	pvcLister  listersv1.PersistentVolumeClaimLister
+	pvLister   listersv1.PersistentVolumeLister
+	snapshot   *Snapshot
+}
+
+// NodeFitOption configures the predicates checked by NodeFit.
+type NodeFitOption func(*nodeFitOptions)
+
+// WithPredicates sets the predicates checked instead of DefaultNodeFitPredicates.
+func WithPredicates(predicates sets.Set[NodeFitPredicate]) NodeFitOption {
+	return func(o *nodeFitOptions) {
+		o.predicates = predicates
+	}
+}
+
+// WithNodes sets the nodes the pod could be scheduled to. The InterPodAffinity and
+// PodTopologySpread predicates use them to find the pods in a topology domain.
+func WithNodes(nodes []*v1.Node) NodeFitOption {
+	return func(o *nodeFitOptions) {
+		o.nodes = nodes
+	}
+}
+
+// WithVolumeListers sets the listers the VolumeZone predicate needs, the predicate
+// is skipped without them.
+func WithVolumeListers(pvcLister listersv1.PersistentVolumeClaimLister, pvLister listersv1.PersistentVolumeLister) NodeFitOption {
+	return func(o *nodeFitOptions) {
+		o.pvcLister = pvcLister
+		o.pvLister = pvLister
+	}
+}
+
+// WithSnapshot makes NodeFit list the pods from the snapshot instead of the given pod
+// indexer and use the requests and anti-affinity indexes precomputed by the snapshot.
+func WithSnapshot(snapshot *Snapshot) NodeFitOption {
+	return func(o *nodeFitOptions) {
+		o.snapshot = snapshot
+	}
+}
+
+func newNodeFitOptions(opts []NodeFitOption) *nodeFitOptions {
+	options := &nodeFitOptions{predicates: DefaultNodeFitPredicates}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// predicateError reports the predicate a pod fails
+func predicateError(predicate NodeFitPredicate, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %s", predicate, fmt.Sprintf(format, args...))
+}
+
+// podsOnNodes lists the pods occupying the given nodes, without the pod itself
+func podsOnNodes(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, nodes []*v1.Node) ([]*v1.Pod, error) {
+	return podutil.ListPodsOnNodes(nodes, nodeIndexer, func(p *v1.Pod) bool {
+		return (p.Namespace != pod.Namespace || p.Name != pod.Name) && p.Status.Phase != v1.PodSucceeded && p.Status.Phase != v1.PodFailed
+	})
+}
+
+// withNode adds the node to the nodes unless already present
+func withNode(nodes []*v1.Node, node *v1.Node) []*v1.Node {
+	for _, n := range nodes {
+		if n.Name == node.Name {
+			return nodes
+		}
+	}
+	return append(append(make([]*v1.Node, 0, len(nodes)+1), nodes...), node)
+}
+
+// fitsMaxPods checks the node has room for one more pod
+func fitsMaxPods(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node) error {
+	pods, err := podsOnNodes(nodeIndexer, pod, []*v1.Node{node})
+	if err != nil {
+		return err
+	}
+	if int64(len(pods)) >= node.Status.Allocatable.Pods().Value() {
+		return predicateError(MaxPodsPredicate, "insufficient %v", v1.ResourcePods)
+	}
+	return nil
+}
+
+// fitsNodePorts checks no pod on the node uses the host ports of the pod
+func fitsNodePorts(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node) error {
+	wantPorts := hostPorts(pod)
+	if len(wantPorts) == 0 {
+		return nil
+	}
+	pods, err := podsOnNodes(nodeIndexer, pod, []*v1.Node{node})
+	if err != nil {
+		return err
+	}
+	for _, existingPod := range pods {
+		for _, used := range hostPorts(existingPod) {
+			for _, want := range wantPorts {
+				if hostPortsConflict(want, used) {
+					return predicateError(NodePortsPredicate, "host port %s/%d is used by pod %s/%s", want.Protocol, want.HostPort, existingPod.Namespace, existingPod.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func hostPorts(pod *v1.Pod) []v1.ContainerPort {
+	var ports []v1.ContainerPort
+	for _, containers := range [][]v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			for _, port := range container.Ports {
+				if port.HostPort > 0 {
+					ports = append(ports, port)
+				}
+			}
+		}
+	}
+	return ports
+}
+
+func hostPortsConflict(a, b v1.ContainerPort) bool {
+	protocol := func(p v1.ContainerPort) v1.Protocol {
+		if p.Protocol == "" {
+			return v1.ProtocolTCP
+		}
+		return p.Protocol
+	}
+	wildcard := func(p v1.ContainerPort) bool {
+		return p.HostIP == "" || p.HostIP == "0.0.0.0" || p.HostIP == "::"
+	}
+	return a.HostPort == b.HostPort && protocol(a) == protocol(b) && (wildcard(a) || wildcard(b) || a.HostIP == b.HostIP)
+}
+
+// fitsInterPodAffinity checks the required pod affinity and anti-affinity terms of the pod and the
+// required anti-affinity terms of the existing pods within the topology domains of the node.
+func fitsInterPodAffinity(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node, nodes []*v1.Node, snapshot *Snapshot) error {
+	nodes = withNode(nodes, node)
+	pods, err := podsOnNodes(nodeIndexer, pod, nodes)
+	if err != nil {
+		return err
+	}
+	nodeMap := utils.CreateNodeMap(nodes)
+	sameDomain := func(existingPod *v1.Pod, topologyKey string) bool {
+		existingNode, ok := nodeMap[existingPod.Spec.NodeName]
+		if !ok {
+			return false
+		}
+		value, ok := node.Labels[topologyKey]
+		return ok && existingNode.Labels[topologyKey] == value
+	}
+
+	if pod.Spec.Affinity != nil && pod.Spec.Affinity.PodAffinity != nil {
+		for _, term := range pod.Spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if _, ok := node.Labels[term.TopologyKey]; !ok {
+				return predicateError(InterPodAffinityPredicate, "node does not have the %q pod affinity topology label", term.TopologyKey)
+			}
+			matchesAny, matchesInDomain := false, false
+			for _, existingPod := range pods {
+				match, err := utils.PodMatchesAffinityTerm(existingPod, pod, &term)
+				if err != nil {
+					return err
+				}
+				if match {
+					matchesAny = true
+					if sameDomain(existingPod, term.TopologyKey) {
+						matchesInDomain = true
+						break
+					}
+				}
+			}
+			if matchesInDomain {
+				continue
+			}
+			// like the scheduler, allow the first pod of a group of pods with affinity to each other
+			if selfMatch, _ := utils.PodMatchesAffinityTerm(pod, pod, &term); !matchesAny && selfMatch {
+				continue
+			}
+			return predicateError(InterPodAffinityPredicate, "no pod in the %q topology domain of the node matches the required pod affinity", term.TopologyKey)
+		}
+	}
+
+	if pod.Spec.Affinity != nil && pod.Spec.Affinity.PodAntiAffinity != nil {
+		for _, term := range pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			for _, existingPod := range pods {
+				if !sameDomain(existingPod, term.TopologyKey) {
+					continue
+				}
+				if match, err := utils.PodMatchesAffinityTerm(existingPod, pod, &term); err != nil {
+					return err
+				} else if match {
+					return predicateError(InterPodAffinityPredicate, "pod %s/%s in the %q topology domain of the node matches the required pod anti-affinity", existingPod.Namespace, existingPod.Name, term.TopologyKey)
+				}
+			}
+		}
+	}
+
+	antiAffinityPods, err := podsWithRequiredAntiAffinity(pods, pod, nodes, snapshot)
+	if err != nil {
+		return err
+	}
+	for _, existingPod := range antiAffinityPods {
+		for _, term := range existingPod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+			if !sameDomain(existingPod, term.TopologyKey) {
+				continue
+			}
+			if match, err := utils.PodMatchesAffinityTerm(pod, existingPod, &term); err != nil {
+				return err
+			} else if match {
+				return predicateError(InterPodAffinityPredicate, "pod matches the required pod anti-affinity of pod %s/%s in the %q topology domain of the node", existingPod.Namespace, existingPod.Name, term.TopologyKey)
+			}
+		}
+	}
+	return nil
+}
+
+// podsWithRequiredAntiAffinity filters the pods with required anti-affinity terms, from the
+// anti-affinity index of the snapshot when available
+func podsWithRequiredAntiAffinity(pods []*v1.Pod, pod *v1.Pod, nodes []*v1.Node, snapshot *Snapshot) ([]*v1.Pod, error) {
+	var result []*v1.Pod
+	if snapshot == nil {
+		for _, p := range pods {
+			if hasRequiredAntiAffinity(p) {
+				result = append(result, p)
+			}
+		}
+		return result, nil
+	}
+	for _, n := range nodes {
+		nodeInfo, err := snapshot.NodeInfo(n.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range nodeInfo.PodsWithRequiredAntiAffinity {
+			if p.Namespace != pod.Namespace || p.Name != pod.Name {
+				result = append(result, p)
+			}
+		}
+	}
+	return result, nil
+}
+
+// fitsPodTopologySpread checks placing the pod on the node keeps the skew of its DoNotSchedule
+// topology spread constraints within maxSkew. Only nodes matching the node affinity of the pod
+// count towards the domains, as with the default nodeAffinityPolicy of the scheduler.
+func fitsPodTopologySpread(nodeIndexer podutil.GetPodsAssignedToNodeFunc, pod *v1.Pod, node *v1.Node, nodes []*v1.Node) error {
+	var constraints []v1.TopologySpreadConstraint
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable == v1.DoNotSchedule {
+			constraints = append(constraints, constraint)
+		}
+	}
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	nodes = withNode(nodes, node)
+	requiredNodeAffinity := nodeaffinity.GetRequiredNodeAffinity(pod)
+	var eligibleNodes []*v1.Node
+	for _, n := range nodes {
+		if match, _ := requiredNodeAffinity.Match(n); match {
+			eligibleNodes = append(eligibleNodes, n)
+		}
+	}
+	pods, err := podsOnNodes(nodeIndexer, pod, nodes)
+	if err != nil {
+		return err
+	}
+	podsByNode := podutil.GroupByNodeName(pods)
+
+	for _, constraint := range constraints {
+		value, ok := node.Labels[constraint.TopologyKey]
+		if !ok {
+			return predicateError(PodTopologySpreadPredicate, "node does not have the %q topology spread label", constraint.TopologyKey)
+		}
+		selector, err := topologySpreadSelector(pod, constraint)
+		if err != nil {
+			return err
+		}
+
+		counts := map[string]int{}
+		countedNodes := sets.New[string]()
+		for _, n := range eligibleNodes {
+			domain, ok := n.Labels[constraint.TopologyKey]
+			if !ok || countedNodes.Has(n.Name) {
+				continue
+			}
+			countedNodes.Insert(n.Name)
+			if _, ok := counts[domain]; !ok {
+				counts[domain] = 0
+			}
+			for _, p := range podsByNode[n.Name] {
+				if p.Namespace == pod.Namespace && p.DeletionTimestamp == nil && selector.Matches(labels.Set(p.Labels)) {
+					counts[domain]++
+				}
+			}
+		}
+
+		minCount := math.MaxInt32
+		for _, count := range counts {
+			if count < minCount {
+				minCount = count
+			}
+		}
+		if constraint.MinDomains != nil && int32(len(counts)) < *constraint.MinDomains {
+			minCount = 0
+		}
+		selfMatch := 0
+		if selector.Matches(labels.Set(pod.Labels)) {
+			selfMatch = 1
+		}
+		if skew := counts[value] + selfMatch - minCount; skew > int(constraint.MaxSkew) {
+			return predicateError(PodTopologySpreadPredicate, "placing the pod in the %q topology domain %q would make the skew %d, exceeding maxSkew %d", constraint.TopologyKey, value, skew, constraint.MaxSkew)
+		}
+	}
+	return nil
+}
+
+// topologySpreadSelector merges the label selector and the matchLabelKeys of the constraint
+func topologySpreadSelector(pod *v1.Pod, constraint v1.TopologySpreadConstraint) (labels.Selector, error) {
+	selector, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range constraint.MatchLabelKeys {
+		if value, ok := pod.Labels[key]; ok {
+			requirement, err := labels.NewRequirement(key, "in", []string{value})
+			if err != nil {
+				return nil, err
+			}
+			selector = selector.Add(*requirement)
+		}
+	}
+	return selector, nil
+}
+
+// fitsVolumeZone checks the bound persistent volumes of the pod can be attached to the node
+func fitsVolumeZone(pod *v1.Pod, node *v1.Node, pvcLister listersv1.PersistentVolumeClaimLister, pvLister listersv1.PersistentVolumeLister) error {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc, err := pvcLister.PersistentVolumeClaims(pod.Namespace).Get(volume.PersistentVolumeClaim.ClaimName)
+		if err != nil {
+			return predicateError(VolumeZonePredicate, "unable to get persistent volume claim %q: %v", volume.PersistentVolumeClaim.ClaimName, err)
+		}
+		if pvc.Spec.VolumeName == "" {
+			// unbound claims are provisioned where the pod is scheduled
+			continue
+		}
+		pv, err := pvLister.Get(pvc.Spec.VolumeName)
+		if err != nil {
+			return predicateError(VolumeZonePredicate, "unable to get persistent volume %q: %v", pvc.Spec.VolumeName, err)
+		}
+		for _, key := range zoneLabels {
+			value, ok := pv.Labels[key]
+			if !ok {
+				continue
+			}
+			// the value of a volume spanning several zones is a "__" separated list
+			if !sets.New(strings.Split(value, "__")...).Has(node.Labels[key]) {
+				return predicateError(VolumeZonePredicate, "persistent volume %q is in %s %q", pv.Name, key, value)
+			}
+		}
+		if pv.Spec.NodeAffinity != nil && pv.Spec.NodeAffinity.Required != nil {
+			if match, err := v1helper.MatchNodeSelectorTerms(node, pv.Spec.NodeAffinity.Required); err != nil || !match {
+				return predicateError(VolumeZonePredicate, "node does not match the node affinity of persistent volume %q", pv.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestNodeFitPredicates(t *testing.T) {
+	buildNode := func(name, zone string) *v1.Node {
+		return test.BuildTestNode(name, 64000, 128*1000*1000*1000, 10, func(node *v1.Node) {
+			node.Labels = map[string]string{v1.LabelTopologyZone: zone, v1.LabelHostname: name}
+		})
+	}
+	nodes := []*v1.Node{buildNode("node1", "zone-a"), buildNode("node2", "zone-a"), buildNode("node3", "zone-b")}
+	withLabels := func(labels map[string]string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Labels = labels
+		}
+	}
+	affinityTerm := v1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		TopologyKey:   v1.LabelTopologyZone,
+	}
+	withPodAffinity := func(pod *v1.Pod) {
+		pod.Spec.Affinity = &v1.Affinity{PodAffinity: &v1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{affinityTerm},
+		}}
+	}
+	withPodAntiAffinity := func(pod *v1.Pod) {
+		pod.Spec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{affinityTerm},
+		}}
+	}
+	withSpread := func(pod *v1.Pod) {
+		pod.Labels = map[string]string{"app": "web"}
+		pod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       v1.LabelTopologyZone,
+			WhenUnsatisfiable: v1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		}}
+	}
+	withHostPort := func(pod *v1.Pod) {
+		pod.Spec.Containers[0].Ports = []v1.ContainerPort{{ContainerPort: 80, HostPort: 8080}}
+	}
+	withVolume := func(pod *v1.Pod) {
+		pod.Spec.Volumes = []v1.Volume{{
+			Name:         "data",
+			VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}},
+		}}
+	}
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec:       v1.PersistentVolumeClaimSpec{VolumeName: "pv-data"},
+	}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pv-data", Labels: map[string]string{v1.LabelTopologyZone: "zone-b"}},
+	}
+
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		podsOnNodes []*v1.Pod
+		node        *v1.Node
+		predicate   NodeFitPredicate
+		err         string
+	}{
+		{
+			description: "required pod affinity satisfied in the zone",
+			pod:         test.BuildTestPod("p1", 100, 0, "node3", withPodAffinity),
+			podsOnNodes: []*v1.Pod{test.BuildTestPod("db", 100, 0, "node2", withLabels(map[string]string{"app": "db"}))},
+			node:        nodes[0],
+			predicate:   InterPodAffinityPredicate,
+		},
+		{
+			description: "required pod affinity not satisfied in the zone",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withPodAffinity),
+			podsOnNodes: []*v1.Pod{test.BuildTestPod("db", 100, 0, "node1", withLabels(map[string]string{"app": "db"}))},
+			node:        nodes[2],
+			predicate:   InterPodAffinityPredicate,
+			err:         "InterPodAffinity: no pod",
+		},
+		{
+			description: "required pod anti-affinity violated in the zone",
+			pod:         test.BuildTestPod("p1", 100, 0, "node3", withPodAntiAffinity),
+			podsOnNodes: []*v1.Pod{test.BuildTestPod("db", 100, 0, "node2", withLabels(map[string]string{"app": "db"}))},
+			node:        nodes[0],
+			predicate:   InterPodAffinityPredicate,
+			err:         "InterPodAffinity: pod default/db",
+		},
+		{
+			description: "pod violates the anti-affinity of an existing pod",
+			pod:         test.BuildTestPod("p1", 100, 0, "node3", withLabels(map[string]string{"app": "db"})),
+			podsOnNodes: []*v1.Pod{test.BuildTestPod("other", 100, 0, "node2", withPodAntiAffinity)},
+			node:        nodes[0],
+			predicate:   InterPodAffinityPredicate,
+			err:         "InterPodAffinity: pod matches",
+		},
+		{
+			description: "topology spread skew within maxSkew",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withSpread),
+			podsOnNodes: []*v1.Pod{
+				test.BuildTestPod("web1", 100, 0, "node1", withLabels(map[string]string{"app": "web"})),
+				test.BuildTestPod("web2", 100, 0, "node2", withLabels(map[string]string{"app": "web"})),
+			},
+			node:      nodes[2],
+			predicate: PodTopologySpreadPredicate,
+		},
+		{
+			description: "topology spread skew exceeding maxSkew",
+			pod:         test.BuildTestPod("p1", 100, 0, "node3", withSpread),
+			podsOnNodes: []*v1.Pod{
+				test.BuildTestPod("web1", 100, 0, "node1", withLabels(map[string]string{"app": "web"})),
+			},
+			node:      nodes[1],
+			predicate: PodTopologySpreadPredicate,
+			err:       "PodTopologySpread:",
+		},
+		{
+			description: "host port in use",
+			pod:         test.BuildTestPod("p1", 100, 0, "node3", withHostPort),
+			podsOnNodes: []*v1.Pod{test.BuildTestPod("other", 100, 0, "node1", withHostPort)},
+			node:        nodes[0],
+			predicate:   NodePortsPredicate,
+			err:         "NodePorts:",
+		},
+		{
+			description: "host port free",
+			pod:         test.BuildTestPod("p1", 100, 0, "node3", withHostPort),
+			podsOnNodes: []*v1.Pod{test.BuildTestPod("other", 100, 0, "node2", withHostPort)},
+			node:        nodes[0],
+			predicate:   NodePortsPredicate,
+		},
+		{
+			description: "volume in another zone",
+			pod:         test.BuildTestPod("p1", 100, 0, "node3", withVolume),
+			node:        nodes[0],
+			predicate:   VolumeZonePredicate,
+			err:         "VolumeZone:",
+		},
+		{
+			description: "volume in the zone",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withVolume),
+			node:        nodes[2],
+			predicate:   VolumeZonePredicate,
+		},
+		{
+			description: "max pods reached",
+			pod:         test.BuildTestPod("p1", 100, 0, "node3", nil),
+			podsOnNodes: func() []*v1.Pod {
+				var pods []*v1.Pod
+				for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+					pods = append(pods, test.BuildTestPod(name, 100, 0, "node1", nil))
+				}
+				return pods
+			}(),
+			node:      nodes[0],
+			predicate: MaxPodsPredicate,
+			err:       "MaxPods: insufficient pods",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			objs := []runtime.Object{tc.pod, pvc, pv}
+			for _, node := range nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.podsOnNodes {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(sharedInformerFactory.Core().V1().Pods().Informer())
+			if err != nil {
+				t.Fatalf("Build get pods assigned to node function error: %v", err)
+			}
+			pvcLister := sharedInformerFactory.Core().V1().PersistentVolumeClaims().Lister()
+			pvLister := sharedInformerFactory.Core().V1().PersistentVolumes().Lister()
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			errs := NodeFit(getPodsAssignedToNode, tc.pod, tc.node,
+				WithPredicates(sets.New(tc.predicate)), WithNodes(nodes), WithVolumeListers(pvcLister, pvLister))
+			if tc.err == "" && len(errs) > 0 {
+				t.Errorf("Expected the pod to fit, got %v", errs)
+			}
+			if tc.err != "" && (len(errs) == 0 || !strings.HasPrefix(errs[0].Error(), tc.err)) {
+				t.Errorf("Expected error starting with %q, got %v", tc.err, errs)
+			}
+
+			if tc.predicate != MaxPodsPredicate && len(NodeFit(getPodsAssignedToNode, tc.pod, tc.node, WithNodes(nodes))) > 0 {
+				t.Errorf("Expected the %v predicate to be disabled by default", tc.predicate)
+			}
+		})
+	}
+}
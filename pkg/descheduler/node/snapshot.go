@@ -0,0 +1,243 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+// NodeInfo holds the pods occupying a node together with their precomputed requests.
+type NodeInfo struct {
+	// Pods are the pods assigned to the node, as listed by podutil.ListPodsOnANode.
+	Pods []*v1.Pod
+	// Requested sums the requests of Pods for every resource requested by any of them,
+	// the number of pods included.
+	Requested map[v1.ResourceName]*resource.Quantity
+	// PodsWithRequiredAntiAffinity are the Pods with required pod anti-affinity terms,
+	// succeeded and failed pods are left out.
+	PodsWithRequiredAntiAffinity []*v1.Pod
+}
+
+// Usage returns a copy of the requests of the given resources, as NodeUtilization does.
+// Resources no pod requests are zero.
+func (n *NodeInfo) Usage(resourceNames []v1.ResourceName) map[v1.ResourceName]*resource.Quantity {
+	usage := map[v1.ResourceName]*resource.Quantity{
+		v1.ResourceCPU:    copyQuantity(n.Requested[v1.ResourceCPU]),
+		v1.ResourceMemory: copyQuantity(n.Requested[v1.ResourceMemory]),
+		v1.ResourcePods:   copyQuantity(n.Requested[v1.ResourcePods]),
+	}
+	for _, name := range resourceNames {
+		if IsBasicResource(name) {
+			continue
+		}
+		if quantity, ok := n.Requested[name]; ok {
+			usage[name] = copyQuantity(quantity)
+		} else {
+			usage[name] = resource.NewQuantity(0, resource.DecimalSI)
+		}
+	}
+	return usage
+}
+
+func newNodeInfo(pods []*v1.Pod) *NodeInfo {
+	nodeInfo := &NodeInfo{Pods: pods}
+	for _, pod := range pods {
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		if hasRequiredAntiAffinity(pod) {
+			nodeInfo.PodsWithRequiredAntiAffinity = append(nodeInfo.PodsWithRequiredAntiAffinity, pod)
+		}
+	}
+	resourceNames := []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods}
+	extendedResources := sets.New[v1.ResourceName]()
+	for _, pod := range nodeInfo.Pods {
+		req, _ := utils.PodRequestsAndLimits(pod)
+		for name := range req {
+			if !IsBasicResource(name) && !extendedResources.Has(name) {
+				extendedResources.Insert(name)
+				resourceNames = append(resourceNames, name)
+			}
+		}
+	}
+	nodeInfo.Requested = NodeUtilization(nodeInfo.Pods, resourceNames)
+	return nodeInfo
+}
+
+// withoutPod returns a new node info with the requests of the pod subtracted,
+// node infos handed out are never modified.
+func (n *NodeInfo) withoutPod(pod *v1.Pod) *NodeInfo {
+	pods := withoutPod(n.Pods, pod)
+	if len(pods) == len(n.Pods) {
+		return n
+	}
+	nodeInfo := &NodeInfo{
+		Pods:                         pods,
+		PodsWithRequiredAntiAffinity: withoutPod(n.PodsWithRequiredAntiAffinity, pod),
+		Requested:                    make(map[v1.ResourceName]*resource.Quantity, len(n.Requested)),
+	}
+	req, _ := utils.PodRequestsAndLimits(pod)
+	for name, quantity := range n.Requested {
+		remaining := copyQuantity(quantity)
+		if name == v1.ResourcePods {
+			remaining.Sub(*resource.NewQuantity(1, resource.DecimalSI))
+		} else if podQuantity, ok := req[name]; ok {
+			remaining.Sub(podQuantity)
+		}
+		nodeInfo.Requested[name] = remaining
+	}
+	return nodeInfo
+}
+
+func copyQuantity(quantity *resource.Quantity) *resource.Quantity {
+	result := quantity.DeepCopy()
+	return &result
+}
+
+func hasRequiredAntiAffinity(pod *v1.Pod) bool {
+	return pod.Spec.Affinity != nil && pod.Spec.Affinity.PodAntiAffinity != nil &&
+		len(pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) > 0
+}
+
+func withoutPod(pods []*v1.Pod, pod *v1.Pod) []*v1.Pod {
+	result := make([]*v1.Pod, 0, len(pods))
+	for _, p := range pods {
+		if p.Namespace != pod.Namespace || p.Name != pod.Name {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// Snapshot caches the ready nodes and the pods assigned to them for a single
+// descheduling cycle, so NodeFit and the balance plugins do not list and sum up
+// the pods of every node over and over. Node infos are computed on first use and
+// updated as pods are evicted, the snapshot is not refreshed from the cluster.
+type Snapshot struct {
+	client                clientset.Interface
+	nodeLister            listersv1.NodeLister
+	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc
+
+	lock       sync.Mutex
+	readyNodes map[string][]*v1.Node
+	pods       map[string][]*v1.Pod
+	nodeInfos  map[string]*NodeInfo
+	removed    sets.Set[string]
+}
+
+// NewSnapshot creates an empty snapshot filled lazily from the given lister and pod indexer.
+func NewSnapshot(client clientset.Interface, nodeLister listersv1.NodeLister, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) *Snapshot {
+	return &Snapshot{
+		client:                client,
+		nodeLister:            nodeLister,
+		getPodsAssignedToNode: getPodsAssignedToNode,
+		readyNodes:            map[string][]*v1.Node{},
+		pods:                  map[string][]*v1.Pod{},
+		nodeInfos:             map[string]*NodeInfo{},
+		removed:               sets.New[string](),
+	}
+}
+
+// ReadyNodes returns the ready nodes matching the node selector, see ReadyNodes.
+func (s *Snapshot) ReadyNodes(ctx context.Context, nodeSelector string) ([]*v1.Node, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if nodes, ok := s.readyNodes[nodeSelector]; ok {
+		return nodes, nil
+	}
+	nodes, err := ReadyNodes(ctx, s.client, s.nodeLister, nodeSelector)
+	if err != nil {
+		return nil, err
+	}
+	s.readyNodes[nodeSelector] = nodes
+	return nodes, nil
+}
+
+// GetPodsAssignedToNode lists the pods of the node, evicted pods excluded. It can
+// be used as a podutil.GetPodsAssignedToNodeFunc.
+func (s *Snapshot) GetPodsAssignedToNode(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pods, err := s.podsLocked(nodeName)
+	if err != nil {
+		return nil, err
+	}
+	if filter == nil {
+		return append([]*v1.Pod(nil), pods...), nil
+	}
+	var result []*v1.Pod
+	for _, pod := range pods {
+		if filter(pod) {
+			result = append(result, pod)
+		}
+	}
+	return result, nil
+}
+
+// NodeInfo returns the pods occupying the node and their requests. The returned
+// node info must not be modified, evictions replace it with an updated one.
+func (s *Snapshot) NodeInfo(nodeName string) (*NodeInfo, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if nodeInfo, ok := s.nodeInfos[nodeName]; ok {
+		return nodeInfo, nil
+	}
+	pods, err := s.podsLocked(nodeName)
+	if err != nil {
+		return nil, err
+	}
+	nodeInfo := newNodeInfo(pods)
+	s.nodeInfos[nodeName] = nodeInfo
+	return nodeInfo, nil
+}
+
+// RemovePod removes an evicted pod from the snapshot.
+func (s *Snapshot) RemovePod(pod *v1.Pod) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.removed.Insert(pod.Namespace + "/" + pod.Name)
+	if pods, ok := s.pods[pod.Spec.NodeName]; ok {
+		s.pods[pod.Spec.NodeName] = withoutPod(pods, pod)
+	}
+	if nodeInfo, ok := s.nodeInfos[pod.Spec.NodeName]; ok {
+		s.nodeInfos[pod.Spec.NodeName] = nodeInfo.withoutPod(pod)
+	}
+}
+
+func (s *Snapshot) podsLocked(nodeName string) ([]*v1.Pod, error) {
+	if pods, ok := s.pods[nodeName]; ok {
+		return pods, nil
+	}
+	pods, err := s.getPodsAssignedToNode(nodeName, func(pod *v1.Pod) bool {
+		return !s.removed.Has(pod.Namespace + "/" + pod.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.pods[nodeName] = pods
+	return pods, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node1 := test.BuildTestNode("n1", 1000, 2000, 10, nil)
+	node2 := test.BuildTestNode("n2", 1000, 2000, 10, nil)
+	p1 := test.BuildTestPod("p1", 400, 0, node1.Name, nil)
+	p2 := test.BuildTestPod("p2", 400, 0, node1.Name, func(pod *v1.Pod) {
+		test.SetPodAntiAffinity(pod, "foo", "bar")
+	})
+	p3 := test.BuildTestPod("p3", 400, 0, node1.Name, func(pod *v1.Pod) {
+		pod.Status.Phase = v1.PodFailed
+	})
+	p4 := test.BuildTestPod("p4", 100, 0, node2.Name, nil)
+
+	fakeClient := fake.NewSimpleClientset(node1, node2, p1, p2, p3, p4)
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(sharedInformerFactory.Core().V1().Pods().Informer())
+	if err != nil {
+		t.Fatalf("Build get pods assigned to node function error: %v", err)
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	listed := map[string]int{}
+	snapshot := NewSnapshot(fakeClient, nodeLister, func(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+		listed[nodeName]++
+		return getPodsAssignedToNode(nodeName, filter)
+	})
+
+	nodes, err := snapshot.ReadyNodes(ctx, "")
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("Expected 2 ready nodes, got %v (%v)", len(nodes), err)
+	}
+
+	nodeInfo, err := snapshot.NodeInfo(node1.Name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// the same pods as podutil.ListPodsOnANode, failed pods included
+	if len(nodeInfo.Pods) != 3 {
+		t.Errorf("Expected 3 pods, got %d pods", len(nodeInfo.Pods))
+	}
+	if cpu := nodeInfo.Requested[v1.ResourceCPU].MilliValue(); cpu != 1200 {
+		t.Errorf("Expected 1200m cpu requested, got %dm", cpu)
+	}
+	if pods := nodeInfo.Requested[v1.ResourcePods].Value(); pods != 3 {
+		t.Errorf("Expected 3 pods requested, got %d", pods)
+	}
+	if len(nodeInfo.PodsWithRequiredAntiAffinity) != 1 || nodeInfo.PodsWithRequiredAntiAffinity[0].Name != p2.Name {
+		t.Errorf("Expected p2 to be indexed with required anti-affinity, got %v", nodeInfo.PodsWithRequiredAntiAffinity)
+	}
+
+	pod := test.BuildTestPod("p5", 100, 0, node2.Name, nil)
+	if errs := NodeFit(nil, pod, node1, WithSnapshot(snapshot)); len(errs) == 0 {
+		t.Errorf("Expected the pod not to fit before evicting p1")
+	}
+
+	snapshot.RemovePod(p1)
+	if errs := NodeFit(nil, pod, node1, WithSnapshot(snapshot)); len(errs) != 0 {
+		t.Errorf("Expected the pod to fit after evicting p1, got %v", errs)
+	}
+	updated, _ := snapshot.NodeInfo(node1.Name)
+	if cpu := updated.Requested[v1.ResourceCPU].MilliValue(); cpu != 800 {
+		t.Errorf("Expected 800m cpu requested after evicting p1, got %dm", cpu)
+	}
+	if pods := updated.Requested[v1.ResourcePods].Value(); pods != 2 {
+		t.Errorf("Expected 2 pods requested after evicting p1, got %d", pods)
+	}
+	if cpu := nodeInfo.Requested[v1.ResourceCPU].MilliValue(); cpu != 1200 {
+		t.Errorf("Expected the node info handed out before the eviction to be left unchanged, got %dm", cpu)
+	}
+
+	// evicting a pod of a node not loaded yet
+	snapshot.RemovePod(p4)
+	pods, err := snapshot.GetPodsAssignedToNode(node2.Name, nil)
+	if err != nil || len(pods) != 0 {
+		t.Errorf("Expected no pods on %v after evicting p4, got %v (%v)", node2.Name, len(pods), err)
+	}
+
+	if listed[node1.Name] != 1 || listed[node2.Name] != 1 {
+		t.Errorf("Expected the pods of every node to be listed once, got %v", listed)
+	}
+}
+
+func TestSnapshotExtendedResources(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const extendedResource = v1.ResourceName("example.com/foo")
+	node := test.BuildTestNode("n1", 1000, 2000, 10, func(node *v1.Node) {
+		test.SetNodeExtendedResource(node, extendedResource, 8)
+	})
+	var objs []runtime.Object
+	var pods []*v1.Pod
+	for _, name := range []string{"p1", "p2", "p3"} {
+		pod := test.BuildTestPod(name, 100, 0, node.Name, func(pod *v1.Pod) {
+			test.SetPodExtendedResourceRequest(pod, extendedResource, 2)
+		})
+		pods = append(pods, pod)
+		objs = append(objs, pod)
+	}
+
+	fakeClient := fake.NewSimpleClientset(append(objs, node)...)
+	sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+	nodeLister := sharedInformerFactory.Core().V1().Nodes().Lister()
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(sharedInformerFactory.Core().V1().Pods().Informer())
+	if err != nil {
+		t.Fatalf("Build get pods assigned to node function error: %v", err)
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	nodeInfo, err := NewSnapshot(fakeClient, nodeLister, getPodsAssignedToNode).NodeInfo(node.Name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resourceNames := []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods, extendedResource}
+	expected := NodeUtilization(pods, resourceNames)
+	usage := nodeInfo.Usage(resourceNames)
+	for _, name := range resourceNames {
+		if usage[name].Cmp(*expected[name]) != 0 {
+			t.Errorf("Expected %v %v requested, got %v", expected[name], name, usage[name])
+		}
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/metrics"
+)
+
+const (
+	// EventSource is the CloudEvents source of all published events.
+	EventSource = "sigs.k8s.io/descheduler"
+	// PodEvictedEventType is published for every evicted pod.
+	PodEvictedEventType = "io.k8s.descheduler.pod.evicted"
+	// CycleCompletedEventType is published once a descheduling cycle finishes.
+	CycleCompletedEventType = "io.k8s.descheduler.cycle.completed"
+
+	cloudEventsSpecVersion = "1.0"
+)
+
+// Event is a CloudEvent in its structured JSON encoding.
+type Event struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Time            time.Time   `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+// PodEvictedData is the payload of a PodEvictedEventType event.
+type PodEvictedData struct {
+	Pod       string `json:"pod"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+	Node      string `json:"node"`
+	Profile   string `json:"profile"`
+	Plugin    string `json:"plugin"`
+	Reason    string `json:"reason"`
+	Backend   string `json:"backend"`
+}
+
+// CycleSummaryData is the payload of a CycleCompletedEventType event.
+type CycleSummaryData struct {
+	StartTime    time.Time       `json:"startTime"`
+	Duration     string          `json:"duration"`
+	TotalEvicted uint            `json:"totalEvicted"`
+	NodeEvicted  map[string]uint `json:"nodeEvicted,omitempty"`
+}
+
+// NewEvent wraps data into a CloudEvent of the given type.
+func NewEvent(eventType string, data interface{}) Event {
+	return Event{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          EventSource,
+		Type:            eventType,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// Publisher accepts events for delivery. Publish must never block.
+type Publisher interface {
+	Publish(event Event)
+}
+
+// Sink delivers a batch of events to its destination.
+type Sink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+	// Send delivers the batch, a non-nil error means the batch is retried.
+	Send(ctx context.Context, events []Event) error
+}
+
+// DispatcherOptions bounds and batches the delivery to every sink.
+type DispatcherOptions struct {
+	// QueueSize is the number of events buffered per sink, events are dropped once the queue is full.
+	QueueSize int
+	// BatchSize is the maximum number of events sent to a sink at once.
+	BatchSize int
+	// FlushInterval is the longest time an event waits for a batch to fill up.
+	FlushInterval time.Duration
+	// MaxRetries is the number of times a failed batch is resent before it is dropped.
+	MaxRetries int
+	// RetryBackoff is the initial wait between retries, doubled on every retry.
+	RetryBackoff time.Duration
+}
+
+// DefaultDispatcherOptions returns the options used by the descheduler.
+func DefaultDispatcherOptions() DispatcherOptions {
+	return DispatcherOptions{
+		QueueSize:     1000,
+		BatchSize:     50,
+		FlushInterval: 5 * time.Second,
+		MaxRetries:    3,
+		RetryBackoff:  time.Second,
+	}
+}
+
+// Dispatcher publishes events asynchronously to a set of sinks. Every sink
+// gets its own bounded queue and worker so a slow sink neither blocks the
+// publisher nor the other sinks.
+type Dispatcher struct {
+	workers []*sinkWorker
+	wg      sync.WaitGroup
+	once    sync.Once
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+var _ Publisher = &Dispatcher{}
+
+type sinkWorker struct {
+	sink  Sink
+	queue chan Event
+	opts  DispatcherOptions
+}
+
+// NewDispatcher creates a dispatcher delivering to sinks. Start must be
+// called before events are delivered.
+func NewDispatcher(sinks []Sink, opts DispatcherOptions) *Dispatcher {
+	d := &Dispatcher{}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+	for _, sink := range sinks {
+		d.workers = append(d.workers, &sinkWorker{
+			sink:  sink,
+			queue: make(chan Event, opts.QueueSize),
+			opts:  opts,
+		})
+	}
+	return d
+}
+
+// Start runs one worker per sink until Stop is called.
+func (d *Dispatcher) Start() {
+	for _, w := range d.workers {
+		d.wg.Add(1)
+		go func(w *sinkWorker) {
+			defer d.wg.Done()
+			w.run(d.ctx)
+		}(w)
+	}
+}
+
+// Publish enqueues the event for every sink, the event is dropped for sinks
+// whose queue is full. Publish must not be called once Stop was called.
+func (d *Dispatcher) Publish(event Event) {
+	for _, w := range d.workers {
+		select {
+		case w.queue <- event:
+		default:
+			metrics.NotificationsDropped.With(map[string]string{"sink": w.sink.Name(), "reason": "queue full"}).Inc()
+			klog.V(2).InfoS("Notification queue is full, dropping event", "sink", w.sink.Name(), "type", event.Type)
+		}
+	}
+}
+
+// Stop flushes the queued events and waits for the workers to finish,
+// pending deliveries are aborted once the timeout expires.
+func (d *Dispatcher) Stop(timeout time.Duration) {
+	defer d.cancel()
+	d.once.Do(func() {
+		for _, w := range d.workers {
+			close(w.queue)
+		}
+	})
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		klog.InfoS("Timed out waiting for notifications to be delivered", "timeout", timeout)
+	}
+}
+
+func (w *sinkWorker) run(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, w.opts.BatchSize)
+	for {
+		select {
+		case event, ok := <-w.queue:
+			if !ok {
+				w.send(ctx, batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) < w.opts.BatchSize {
+				continue
+			}
+		case <-ticker.C:
+		}
+		w.send(ctx, batch)
+		batch = batch[:0]
+	}
+}
+
+func (w *sinkWorker) send(ctx context.Context, batch []Event) {
+	if len(batch) == 0 {
+		return
+	}
+	backoff := wait.Backoff{
+		Duration: w.opts.RetryBackoff,
+		Factor:   2,
+		Steps:    w.opts.MaxRetries + 1,
+	}
+	var lastErr error
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		lastErr = w.sink.Send(ctx, batch)
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			err = lastErr
+		}
+		metrics.NotificationsDropped.With(map[string]string{"sink": w.sink.Name(), "reason": "delivery failed"}).Add(float64(len(batch)))
+		klog.ErrorS(err, "Unable to deliver notifications", "sink", w.sink.Name(), "events", len(batch))
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+type fakeSink struct {
+	lock    sync.Mutex
+	batches [][]Event
+	fail    int
+	block   chan struct{}
+}
+
+func (s *fakeSink) Name() string {
+	return "Fake"
+}
+
+func (s *fakeSink) Send(ctx context.Context, events []Event) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.fail > 0 {
+		s.fail--
+		return fmt.Errorf("sink unavailable")
+	}
+	s.batches = append(s.batches, append([]Event(nil), events...))
+	return nil
+}
+
+func (s *fakeSink) delivered() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	total := 0
+	for _, batch := range s.batches {
+		total += len(batch)
+	}
+	return total
+}
+
+func testOptions() DispatcherOptions {
+	return DispatcherOptions{
+		QueueSize:     10,
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+		MaxRetries:    2,
+		RetryBackoff:  time.Millisecond,
+	}
+}
+
+func TestDispatcherBatchesEvents(t *testing.T) {
+	sink := &fakeSink{}
+	dispatcher := NewDispatcher([]Sink{sink}, testOptions())
+	dispatcher.Start()
+
+	for i := 0; i < 7; i++ {
+		dispatcher.Publish(NewEvent(PodEvictedEventType, PodEvictedData{Pod: fmt.Sprintf("p%d", i)}))
+	}
+	dispatcher.Stop(time.Minute)
+
+	if sink.delivered() != 7 {
+		t.Errorf("Expected 7 delivered events, got %d", sink.delivered())
+	}
+	for _, batch := range sink.batches {
+		if len(batch) > 3 {
+			t.Errorf("Expected batches of at most 3 events, got %d", len(batch))
+		}
+	}
+}
+
+func TestDispatcherRetriesFailedBatches(t *testing.T) {
+	sink := &fakeSink{fail: 2}
+	dispatcher := NewDispatcher([]Sink{sink}, testOptions())
+	dispatcher.Start()
+
+	dispatcher.Publish(NewEvent(CycleCompletedEventType, CycleSummaryData{TotalEvicted: 1}))
+	dispatcher.Stop(time.Minute)
+
+	if sink.delivered() != 1 {
+		t.Errorf("Expected the event to be delivered after retrying, got %d delivered events", sink.delivered())
+	}
+}
+
+func TestDispatcherNeverBlocks(t *testing.T) {
+	slow := &fakeSink{block: make(chan struct{})}
+	fast := &fakeSink{}
+	opts := testOptions()
+	opts.BatchSize = 1
+	dispatcher := NewDispatcher([]Sink{slow, fast}, opts)
+	dispatcher.Start()
+
+	for i := 0; i < 5; i++ {
+		dispatcher.Publish(NewEvent(PodEvictedEventType, PodEvictedData{Pod: fmt.Sprintf("p%d", i)}))
+	}
+	if err := wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, 10*time.Second, true, func(context.Context) (bool, error) {
+		return fast.delivered() == 5, nil
+	}); err != nil {
+		t.Fatalf("Expected the fast sink to receive all events while the slow sink is blocked, got %d", fast.delivered())
+	}
+
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			dispatcher.Publish(NewEvent(PodEvictedEventType, PodEvictedData{Pod: fmt.Sprintf("p%d", i)}))
+		}
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Publishing blocked on a slow sink")
+	}
+
+	close(slow.block)
+	dispatcher.Stop(time.Minute)
+
+	// the slow sink holds one event in flight and a full queue
+	if slow.delivered() > opts.QueueSize+1 {
+		t.Errorf("Expected the slow sink queue to be bounded, got %d delivered events", slow.delivered())
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received []Event
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Unable to decode request: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	event := NewEvent(PodEvictedEventType, PodEvictedData{Pod: "p1", Namespace: "default"})
+	if err := NewWebhookSink(server.URL, server.Client()).Send(context.Background(), []Event{event}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if contentType != cloudEventsBatchContentType {
+		t.Errorf("Expected content type %q, got %q", cloudEventsBatchContentType, contentType)
+	}
+	if len(received) != 1 || received[0].ID != event.ID || received[0].Type != PodEvictedEventType || received[0].SpecVersion != "1.0" {
+		t.Errorf("Unexpected events received: %+v", received)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	if err := NewWebhookSink(failing.URL, failing.Client()).Send(context.Background(), []Event{event}); err == nil {
+		t.Errorf("Expected error when the webhook fails")
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "evictions.jsonl")
+	sink := NewFileSink(path)
+
+	for i := 0; i < 2; i++ {
+		events := []Event{
+			NewEvent(PodEvictedEventType, PodEvictedData{Pod: fmt.Sprintf("p%d", i)}),
+			NewEvent(CycleCompletedEventType, CycleSummaryData{TotalEvicted: 1}),
+		}
+		if err := sink.Send(context.Background(), events); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Unable to open %q: %v", path, err)
+	}
+	defer f.Close()
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		event := Event{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Errorf("Unable to decode line %d: %v", lines, err)
+		}
+		lines++
+	}
+	if lines != 4 {
+		t.Errorf("Expected 4 lines, got %d", lines)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// cloudEventsBatchContentType is the content type of the CloudEvents batched JSON encoding.
+const cloudEventsBatchContentType = "application/cloudevents-batch+json"
+
+type webhookSink struct {
+	url    string
+	client *http.Client
+}
+
+var _ Sink = &webhookSink{}
+
+// NewWebhookSink posts batches of events to an HTTP(S) endpoint using the
+// CloudEvents batched JSON encoding. Any 2xx response acknowledges the batch.
+func NewWebhookSink(url string, client *http.Client) Sink {
+	return &webhookSink{
+		url:    url,
+		client: client,
+	}
+}
+
+func (s *webhookSink) Name() string {
+	return "Webhook"
+}
+
+func (s *webhookSink) Send(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("unable to encode events: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to build request for %q: %v", s.url, err)
+	}
+	req.Header.Set("Content-Type", cloudEventsBatchContentType)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %q failed: %v", s.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("notification webhook returned %d: %s", resp.StatusCode, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+type fileSink struct {
+	path string
+	lock sync.Mutex
+}
+
+var _ Sink = &fileSink{}
+
+// NewFileSink appends events to a local file, one JSON encoded CloudEvent per line.
+func NewFileSink(path string) Sink {
+	return &fileSink{
+		path: path,
+	}
+}
+
+func (s *fileSink) Name() string {
+	return "File"
+}
+
+func (s *fileSink) Send(_ context.Context, events []Event) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, event := range events {
+		if err := encoder.Encode(event); err != nil {
+			return fmt.Errorf("unable to encode event: %v", err)
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to open %q: %v", s.path, err)
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return fmt.Errorf("unable to write to %q: %v", s.path, err)
+	}
+	return f.Close()
+}
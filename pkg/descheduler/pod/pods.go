@@ -17,14 +17,20 @@ limitations under the License.
 package pod
 
 import (
+	"fmt"
+	"path"
 	"sort"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	listersv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/utils"
 )
 
@@ -52,10 +58,13 @@ func WrapFilterFuncs(filters ...FilterFunc) FilterFunc {
 }
 
 type Options struct {
-	filter             FilterFunc
-	includedNamespaces sets.Set[string]
-	excludedNamespaces sets.Set[string]
-	labelSelector      *metav1.LabelSelector
+	filter                   FilterFunc
+	includedNamespaces       sets.Set[string]
+	excludedNamespaces       sets.Set[string]
+	includeNamespaceSelector *metav1.LabelSelector
+	excludeNamespaceSelector *metav1.LabelSelector
+	namespaceLister          listersv1.NamespaceLister
+	labelSelector            *metav1.LabelSelector
 }
 
 // NewOptions returns an empty Options.
@@ -70,18 +79,48 @@ func (o *Options) WithFilter(filter FilterFunc) *Options {
 	return o
 }
 
-// WithNamespaces sets included namespaces
+// WithNamespaces sets included namespaces, names or glob patterns
 func (o *Options) WithNamespaces(namespaces sets.Set[string]) *Options {
 	o.includedNamespaces = namespaces
 	return o
 }
 
-// WithoutNamespaces sets excluded namespaces
+// WithoutNamespaces sets excluded namespaces, names or glob patterns
 func (o *Options) WithoutNamespaces(namespaces sets.Set[string]) *Options {
 	o.excludedNamespaces = namespaces
 	return o
 }
 
+// WithNamespaceSelectors sets label selectors the namespace of a pod has to match to be
+// included or excluded. The namespaces are looked up with the namespace lister.
+func (o *Options) WithNamespaceSelectors(includeSelector, excludeSelector *metav1.LabelSelector) *Options {
+	o.includeNamespaceSelector = includeSelector
+	o.excludeNamespaceSelector = excludeSelector
+	return o
+}
+
+// WithNamespaceLister sets the lister resolving the namespace selectors
+func (o *Options) WithNamespaceLister(namespaceLister listersv1.NamespaceLister) *Options {
+	o.namespaceLister = namespaceLister
+	return o
+}
+
+// WithNamespaceFilter sets the included and excluded namespaces and namespace selectors
+// of the plugin arguments. The namespace lister resolving the selectors is only requested
+// from the informer factory when selectors are set.
+func (o *Options) WithNamespaceFilter(namespaces *api.Namespaces, sharedInformerFactory informers.SharedInformerFactory) *Options {
+	if namespaces == nil {
+		return o
+	}
+	o.WithNamespaces(sets.New(namespaces.Include...)).
+		WithoutNamespaces(sets.New(namespaces.Exclude...)).
+		WithNamespaceSelectors(namespaces.IncludeSelector, namespaces.ExcludeSelector)
+	if (namespaces.IncludeSelector != nil || namespaces.ExcludeSelector != nil) && sharedInformerFactory != nil {
+		o.WithNamespaceLister(sharedInformerFactory.Core().V1().Namespaces().Lister())
+	}
+	return o
+}
+
 // WithLabelSelector sets a pod label selector
 func (o *Options) WithLabelSelector(labelSelector *metav1.LabelSelector) *Options {
 	o.labelSelector = labelSelector
@@ -98,14 +137,15 @@ func (o *Options) BuildFilterFunc() (FilterFunc, error) {
 			return nil, err
 		}
 	}
+	namespaceMatcher, err := o.buildNamespaceMatcher()
+	if err != nil {
+		return nil, err
+	}
 	return func(pod *v1.Pod) bool {
 		if o.filter != nil && !o.filter(pod) {
 			return false
 		}
-		if len(o.includedNamespaces) > 0 && !o.includedNamespaces.Has(pod.Namespace) {
-			return false
-		}
-		if len(o.excludedNamespaces) > 0 && o.excludedNamespaces.Has(pod.Namespace) {
+		if namespaceMatcher != nil && !namespaceMatcher(pod.Namespace) {
 			return false
 		}
 		if s != nil && !s.Matches(labels.Set(pod.GetLabels())) {
@@ -115,6 +155,99 @@ func (o *Options) BuildFilterFunc() (FilterFunc, error) {
 	}, nil
 }
 
+// namespaceNames matches namespaces against a list of names and glob patterns
+type namespaceNames struct {
+	names    sets.Set[string]
+	patterns []string
+}
+
+func newNamespaceNames(namespaces sets.Set[string]) namespaceNames {
+	n := namespaceNames{names: sets.New[string]()}
+	for namespace := range namespaces {
+		if api.IsNamespacePattern(namespace) {
+			n.patterns = append(n.patterns, namespace)
+		} else {
+			n.names.Insert(namespace)
+		}
+	}
+	return n
+}
+
+func (n namespaceNames) empty() bool {
+	return len(n.names) == 0 && len(n.patterns) == 0
+}
+
+func (n namespaceNames) matches(namespace string) bool {
+	if n.names.Has(namespace) {
+		return true
+	}
+	for _, pattern := range n.patterns {
+		if match, _ := path.Match(pattern, namespace); match {
+			return true
+		}
+	}
+	return false
+}
+
+// buildNamespaceMatcher returns a function telling whether pods of a namespace pass the
+// included and excluded namespaces and selectors, nil when none are set. A namespace is
+// included when it is listed or matches the include selector, and excluded when it is
+// listed or matches the exclude selector. Pods of namespaces which cannot be looked up
+// are left out when selectors are set.
+func (o *Options) buildNamespaceMatcher() (func(namespace string) bool, error) {
+	included := newNamespaceNames(o.includedNamespaces)
+	excluded := newNamespaceNames(o.excludedNamespaces)
+	var includeSelector, excludeSelector labels.Selector
+	var err error
+	if o.includeNamespaceSelector != nil {
+		if includeSelector, err = metav1.LabelSelectorAsSelector(o.includeNamespaceSelector); err != nil {
+			return nil, err
+		}
+	}
+	if o.excludeNamespaceSelector != nil {
+		if excludeSelector, err = metav1.LabelSelectorAsSelector(o.excludeNamespaceSelector); err != nil {
+			return nil, err
+		}
+	}
+	if included.empty() && excluded.empty() && includeSelector == nil && excludeSelector == nil {
+		return nil, nil
+	}
+	if (includeSelector != nil || excludeSelector != nil) && o.namespaceLister == nil {
+		return nil, fmt.Errorf("namespace selectors require a namespace lister")
+	}
+
+	namespaceLabels := func(namespace string) (labels.Set, bool) {
+		ns, err := o.namespaceLister.Get(namespace)
+		if err != nil {
+			klog.V(4).InfoS("Unable to get namespace", "namespace", namespace, "err", err)
+			return nil, false
+		}
+		return labels.Set(ns.Labels), true
+	}
+	return func(namespace string) bool {
+		if excluded.matches(namespace) {
+			return false
+		}
+		if excludeSelector != nil {
+			nsLabels, ok := namespaceLabels(namespace)
+			if !ok || excludeSelector.Matches(nsLabels) {
+				return false
+			}
+		}
+		if included.empty() && includeSelector == nil {
+			return true
+		}
+		if included.matches(namespace) {
+			return true
+		}
+		if includeSelector != nil {
+			nsLabels, ok := namespaceLabels(namespace)
+			return ok && includeSelector.Matches(nsLabels)
+		}
+		return false
+	}, nil
+}
+
 // BuildGetPodsAssignedToNodeFunc establishes an indexer to map the pods and their assigned nodes.
 // It returns a function to help us get all the pods that assigned to a node based on the indexer.
 func BuildGetPodsAssignedToNodeFunc(podInformer cache.SharedIndexInformer) (GetPodsAssignedToNodeFunc, error) {
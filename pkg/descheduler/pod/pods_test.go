@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/test"
 )
 
@@ -240,3 +241,91 @@ func TestGroupByNodeName(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildFilterFuncNamespaces(t *testing.T) {
+	namespaces := []*v1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"team": "a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"team": "b", "frozen": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "pr-123", Labels: map[string]string{"preview": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	}
+	testCases := []struct {
+		name       string
+		namespaces *api.Namespaces
+		expected   []string
+	}{
+		{
+			name:     "no namespaces",
+			expected: []string{"team-a", "team-b", "pr-123", "kube-system", "unknown"},
+		},
+		{
+			name:       "include names and patterns",
+			namespaces: &api.Namespaces{Include: []string{"kube-system", "team-*"}},
+			expected:   []string{"team-a", "team-b", "kube-system"},
+		},
+		{
+			name:       "exclude patterns",
+			namespaces: &api.Namespaces{Exclude: []string{"kube-*", "pr-[0-9]*"}},
+			expected:   []string{"team-a", "team-b", "unknown"},
+		},
+		{
+			name:       "include selector",
+			namespaces: &api.Namespaces{IncludeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: metav1.LabelSelectorOpExists}}}},
+			expected:   []string{"team-a", "team-b"},
+		},
+		{
+			name: "include names or selector",
+			namespaces: &api.Namespaces{
+				Include:         []string{"kube-system"},
+				IncludeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"preview": "true"}},
+			},
+			expected: []string{"pr-123", "kube-system"},
+		},
+		{
+			name: "exclude selector, unknown namespaces left out",
+			namespaces: &api.Namespaces{
+				ExcludeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"frozen": "true"}},
+			},
+			expected: []string{"team-a", "pr-123", "kube-system"},
+		},
+		{
+			name: "include selector and exclude selector",
+			namespaces: &api.Namespaces{
+				IncludeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: metav1.LabelSelectorOpExists}}},
+				ExcludeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"frozen": "true"}},
+			},
+			expected: []string{"team-a"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, namespace := range namespaces {
+				objs = append(objs, namespace)
+			}
+			sharedInformerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(objs...), 0)
+			filter, err := NewOptions().WithNamespaceFilter(tc.namespaces, sharedInformerFactory).BuildFilterFunc()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			var actual []string
+			for _, namespace := range []string{"team-a", "team-b", "pr-123", "kube-system", "unknown"} {
+				pod := test.BuildTestPod("pod", 100, 0, "n1", func(pod *v1.Pod) {
+					pod.Namespace = namespace
+				})
+				if filter(pod) {
+					actual = append(actual, namespace)
+				}
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("Expected pods of namespaces %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package descheduler
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -152,5 +153,107 @@ func validateDeschedulerConfiguration(in api.DeschedulerPolicy, registry pluginr
 			}
 		}
 	}
+	if err := validateEvictionBackend(in.EvictionBackend); err != nil {
+		errorsInProfiles = append(errorsInProfiles, err)
+	}
+	if err := validateEvictionApproval(in.EvictionApproval); err != nil {
+		errorsInProfiles = append(errorsInProfiles, err)
+	}
+	if err := validateNotifications(in.Notifications); err != nil {
+		errorsInProfiles = append(errorsInProfiles, err)
+	}
+	if err := validateAuditLog(in.Audit); err != nil {
+		errorsInProfiles = append(errorsInProfiles, err)
+	}
 	return utilerrors.NewAggregate(errorsInProfiles)
 }
+
+func validateEvictionBackend(backend *api.EvictionBackend) error {
+	if backend == nil {
+		return nil
+	}
+	switch backend.Type {
+	case "", api.EvictionBackendEvictionAPI, api.EvictionBackendDelete, api.EvictionBackendRolloutRestart, api.EvictionBackendRecordOnly:
+		if backend.Webhook != nil {
+			return fmt.Errorf("evictionBackend: webhook can only be set for the %s backend", api.EvictionBackendWebhook)
+		}
+	case api.EvictionBackendWebhook:
+		if backend.Webhook == nil {
+			return fmt.Errorf("evictionBackend: webhook must be set for the %s backend", api.EvictionBackendWebhook)
+		}
+		if err := validateWebhookClientConfig(backend.Webhook); err != nil {
+			return fmt.Errorf("evictionBackend: %v", err)
+		}
+	default:
+		return fmt.Errorf("evictionBackend: unknown type %q", backend.Type)
+	}
+	return nil
+}
+
+func validateEvictionApproval(approval *api.EvictionApproval) error {
+	if approval == nil {
+		return nil
+	}
+	switch approval.FailurePolicy {
+	case "", api.FailurePolicyFail, api.FailurePolicyIgnore:
+	default:
+		return fmt.Errorf("evictionApproval: failurePolicy must be one of %s or %s, got %q", api.FailurePolicyFail, api.FailurePolicyIgnore, approval.FailurePolicy)
+	}
+	if err := validateWebhookClientConfig(&approval.Webhook); err != nil {
+		return fmt.Errorf("evictionApproval: %v", err)
+	}
+	return nil
+}
+
+func validateNotifications(notifications *api.Notifications) error {
+	if notifications == nil {
+		return nil
+	}
+	for idx, sink := range notifications.Sinks {
+		switch {
+		case sink.Webhook != nil && sink.File != nil:
+			return fmt.Errorf("notifications: sink %d: only one of webhook or file can be set", idx)
+		case sink.Webhook != nil:
+			if err := validateWebhookClientConfig(sink.Webhook); err != nil {
+				return fmt.Errorf("notifications: sink %d: %v", idx, err)
+			}
+		case sink.File != nil:
+			if sink.File.Path == "" {
+				return fmt.Errorf("notifications: sink %d: file path must be set", idx)
+			}
+		default:
+			return fmt.Errorf("notifications: sink %d: one of webhook or file must be set", idx)
+		}
+	}
+	return nil
+}
+
+func validateAuditLog(auditLog *api.AuditLog) error {
+	if auditLog == nil {
+		return nil
+	}
+	if auditLog.Path == "" {
+		return fmt.Errorf("audit: path must be set")
+	}
+	if auditLog.MaxSize != nil && auditLog.MaxSize.Sign() < 0 {
+		return fmt.Errorf("audit: maxSize must not be negative")
+	}
+	if auditLog.MaxAge != nil && auditLog.MaxAge.Duration < 0 {
+		return fmt.Errorf("audit: maxAge must not be negative")
+	}
+	return nil
+}
+
+func validateWebhookClientConfig(config *api.WebhookClientConfig) error {
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url %q: %v", config.URL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("webhook url %q must use http or https scheme", config.URL)
+	}
+	if config.Timeout != nil && config.Timeout.Duration < 0 {
+		return fmt.Errorf("webhook timeout must not be negative")
+	}
+	return nil
+}
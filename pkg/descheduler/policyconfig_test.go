@@ -19,9 +19,11 @@ package descheduler
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	utilpointer "k8s.io/utils/pointer"
 	"sigs.k8s.io/descheduler/pkg/api"
@@ -995,6 +997,59 @@ profiles:
 				},
 			},
 		},
+		{
+			description: "v1alpha2 to internal with a webhook eviction backend",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+evictionBackend:
+  type: Webhook
+  webhook:
+    url: "https://evictor.example.com/evict"
+    timeout: 5s
+profiles:
+  - name: ProfileName
+    plugins:
+      deschedule:
+        enabled:
+          - "RemovePodsHavingTooManyRestarts"
+`),
+			result: &api.DeschedulerPolicy{
+				EvictionBackend: &api.EvictionBackend{
+					Type: api.EvictionBackendWebhook,
+					Webhook: &api.WebhookClientConfig{
+						URL:     "https://evictor.example.com/evict",
+						Timeout: &metav1.Duration{Duration: 5 * time.Second},
+					},
+				},
+				Profiles: []api.DeschedulerProfile{
+					{
+						Name:          "ProfileName",
+						PluginConfigs: []api.PluginConfig{defaultEvictorPluginConfig},
+						Plugins: api.Plugins{
+							Filter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							PreEvictionFilter: api.PluginSet{
+								Enabled: []string{defaultevictor.PluginName},
+							},
+							Deschedule: api.PluginSet{
+								Enabled: []string{removepodshavingtoomanyrestarts.PluginName},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "v1alpha2 with an unknown eviction backend",
+			policy: []byte(`apiVersion: "descheduler/v1alpha2"
+kind: "DeschedulerPolicy"
+evictionBackend:
+  type: Unknown
+`),
+			result: nil,
+			err:    fmt.Errorf("evictionBackend: unknown type \"Unknown\""),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1065,6 +1120,83 @@ func TestValidateDeschedulerConfiguration(t *testing.T) {
 			},
 			result: fmt.Errorf("[in profile RemoveFailedPods: only one of Include/Exclude namespaces can be set, in profile RemovePodsViolatingTopologySpreadConstraint: only one of Include/Exclude namespaces can be set]"),
 		},
+		{
+			description: "webhook eviction backend without webhook",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionBackend: &api.EvictionBackend{Type: api.EvictionBackendWebhook},
+			},
+			result: fmt.Errorf("evictionBackend: webhook must be set for the Webhook backend"),
+		},
+		{
+			description: "webhook eviction backend with invalid url scheme",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionBackend: &api.EvictionBackend{
+					Type:    api.EvictionBackendWebhook,
+					Webhook: &api.WebhookClientConfig{URL: "ftp://evictor.example.com"},
+				},
+			},
+			result: fmt.Errorf("evictionBackend: webhook url \"ftp://evictor.example.com\" must use http or https scheme"),
+		},
+		{
+			description: "webhook configured for a non webhook eviction backend",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionBackend: &api.EvictionBackend{
+					Type:    api.EvictionBackendDelete,
+					Webhook: &api.WebhookClientConfig{URL: "https://evictor.example.com"},
+				},
+			},
+			result: fmt.Errorf("evictionBackend: webhook can only be set for the Webhook backend"),
+		},
+		{
+			description: "eviction approval with unknown failure policy",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionApproval: &api.EvictionApproval{
+					Webhook:       api.WebhookClientConfig{URL: "https://approver.example.com"},
+					FailurePolicy: "Maybe",
+				},
+			},
+			result: fmt.Errorf("evictionApproval: failurePolicy must be one of Fail or Ignore, got \"Maybe\""),
+		},
+		{
+			description: "eviction approval without url",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				EvictionApproval: &api.EvictionApproval{FailurePolicy: api.FailurePolicyIgnore},
+			},
+			result: fmt.Errorf("evictionApproval: webhook url \"\" must use http or https scheme"),
+		},
+		{
+			description: "notification sink without destination",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Notifications: &api.Notifications{
+					Sinks: []api.NotificationSink{
+						{File: &api.FileSink{Path: "/var/log/descheduler/evictions.jsonl"}},
+						{},
+					},
+				},
+			},
+			result: fmt.Errorf("notifications: sink 1: one of webhook or file must be set"),
+		},
+		{
+			description: "notification sink with both webhook and file",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Notifications: &api.Notifications{
+					Sinks: []api.NotificationSink{
+						{
+							Webhook: &api.WebhookClientConfig{URL: "https://events.example.com"},
+							File:    &api.FileSink{Path: "/var/log/descheduler/evictions.jsonl"},
+						},
+					},
+				},
+			},
+			result: fmt.Errorf("notifications: sink 0: only one of webhook or file can be set"),
+		},
+		{
+			description: "audit log without path",
+			deschedulerPolicy: api.DeschedulerPolicy{
+				Audit: &api.AuditLog{MaxAge: &metav1.Duration{Duration: 24 * time.Hour}},
+			},
+			result: fmt.Errorf("audit: path must be set"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -25,16 +25,25 @@ import (
 	"sigs.k8s.io/descheduler/pkg/api/v1alpha2"
 	"sigs.k8s.io/descheduler/pkg/apis/componentconfig"
 	componentconfigv1alpha1 "sigs.k8s.io/descheduler/pkg/apis/componentconfig/v1alpha1"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/celevictor"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/extendedresourcedefragmentation"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/inflightworkevictor"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsblockingpendingpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsfrommaintenancenodes"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsfromnodeswithbadconditions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingstalerevision"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinginterpodaffinity"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinginterpodantiaffinity"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodeaffinity"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingtopologyspreadconstraint"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/rotatelonglivedpods"
 )
 
 var (
@@ -44,16 +53,25 @@ var (
 
 func init() {
 	utilruntime.Must(api.AddToScheme(Scheme))
+	utilruntime.Must(celevictor.AddToScheme(Scheme))
+	utilruntime.Must(inflightworkevictor.AddToScheme(Scheme))
 	utilruntime.Must(defaultevictor.AddToScheme(Scheme))
+	utilruntime.Must(extendedresourcedefragmentation.AddToScheme(Scheme))
 	utilruntime.Must(nodeutilization.AddToScheme(Scheme))
 	utilruntime.Must(podlifetime.AddToScheme(Scheme))
 	utilruntime.Must(removeduplicates.AddToScheme(Scheme))
 	utilruntime.Must(removefailedpods.AddToScheme(Scheme))
+	utilruntime.Must(removepodsblockingpendingpods.AddToScheme(Scheme))
+	utilruntime.Must(removepodsfrommaintenancenodes.AddToScheme(Scheme))
+	utilruntime.Must(removepodsfromnodeswithbadconditions.AddToScheme(Scheme))
+	utilruntime.Must(removepodshavingstalerevision.AddToScheme(Scheme))
 	utilruntime.Must(removepodshavingtoomanyrestarts.AddToScheme(Scheme))
+	utilruntime.Must(removepodsviolatinginterpodaffinity.AddToScheme(Scheme))
 	utilruntime.Must(removepodsviolatinginterpodantiaffinity.AddToScheme(Scheme))
 	utilruntime.Must(removepodsviolatingnodeaffinity.AddToScheme(Scheme))
 	utilruntime.Must(removepodsviolatingnodetaints.AddToScheme(Scheme))
 	utilruntime.Must(removepodsviolatingtopologyspreadconstraint.AddToScheme(Scheme))
+	utilruntime.Must(rotatelonglivedpods.AddToScheme(Scheme))
 
 	utilruntime.Must(componentconfig.AddToScheme(Scheme))
 	utilruntime.Must(componentconfigv1alpha1.AddToScheme(Scheme))
@@ -18,16 +18,25 @@ package descheduler
 
 import (
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/celevictor"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/extendedresourcedefragmentation"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/inflightworkevictor"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/nodeutilization"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/podlifetime"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removeduplicates"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removefailedpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsblockingpendingpods"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsfrommaintenancenodes"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsfromnodeswithbadconditions"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingstalerevision"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodshavingtoomanyrestarts"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinginterpodaffinity"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatinginterpodantiaffinity"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodeaffinity"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingnodetaints"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/removepodsviolatingtopologyspreadconstraint"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/rotatelonglivedpods"
 )
 
 func SetupPlugins() {
@@ -37,14 +46,24 @@ func SetupPlugins() {
 
 func RegisterDefaultPlugins(registry pluginregistry.Registry) {
 	pluginregistry.Register(defaultevictor.PluginName, defaultevictor.New, &defaultevictor.DefaultEvictor{}, &defaultevictor.DefaultEvictorArgs{}, defaultevictor.ValidateDefaultEvictorArgs, defaultevictor.SetDefaults_DefaultEvictorArgs, registry)
+	pluginregistry.Register(extendedresourcedefragmentation.PluginName, extendedresourcedefragmentation.New, &extendedresourcedefragmentation.ExtendedResourceDefragmentation{}, &extendedresourcedefragmentation.ExtendedResourceDefragmentationArgs{}, extendedresourcedefragmentation.ValidateExtendedResourceDefragmentationArgs, extendedresourcedefragmentation.SetDefaults_ExtendedResourceDefragmentationArgs, registry)
+	pluginregistry.Register(celevictor.PluginName, celevictor.New, &celevictor.CELEvictor{}, &celevictor.CELEvictorArgs{}, celevictor.ValidateCELEvictorArgs, celevictor.SetDefaults_CELEvictorArgs, registry)
+	pluginregistry.Register(inflightworkevictor.PluginName, inflightworkevictor.New, &inflightworkevictor.InFlightWorkEvictor{}, &inflightworkevictor.InFlightWorkEvictorArgs{}, inflightworkevictor.ValidateInFlightWorkEvictorArgs, inflightworkevictor.SetDefaults_InFlightWorkEvictorArgs, registry)
 	pluginregistry.Register(nodeutilization.LowNodeUtilizationPluginName, nodeutilization.NewLowNodeUtilization, &nodeutilization.LowNodeUtilization{}, &nodeutilization.LowNodeUtilizationArgs{}, nodeutilization.ValidateLowNodeUtilizationArgs, nodeutilization.SetDefaults_LowNodeUtilizationArgs, registry)
 	pluginregistry.Register(nodeutilization.HighNodeUtilizationPluginName, nodeutilization.NewHighNodeUtilization, &nodeutilization.HighNodeUtilization{}, &nodeutilization.HighNodeUtilizationArgs{}, nodeutilization.ValidateHighNodeUtilizationArgs, nodeutilization.SetDefaults_HighNodeUtilizationArgs, registry)
+	pluginregistry.Register(nodeutilization.RebalanceOntoNewNodesPluginName, nodeutilization.NewRebalanceOntoNewNodes, &nodeutilization.RebalanceOntoNewNodes{}, &nodeutilization.RebalanceOntoNewNodesArgs{}, nodeutilization.ValidateRebalanceOntoNewNodesArgs, nodeutilization.SetDefaults_RebalanceOntoNewNodesArgs, registry)
 	pluginregistry.Register(podlifetime.PluginName, podlifetime.New, &podlifetime.PodLifeTime{}, &podlifetime.PodLifeTimeArgs{}, podlifetime.ValidatePodLifeTimeArgs, podlifetime.SetDefaults_PodLifeTimeArgs, registry)
 	pluginregistry.Register(removeduplicates.PluginName, removeduplicates.New, &removeduplicates.RemoveDuplicates{}, &removeduplicates.RemoveDuplicatesArgs{}, removeduplicates.ValidateRemoveDuplicatesArgs, removeduplicates.SetDefaults_RemoveDuplicatesArgs, registry)
 	pluginregistry.Register(removefailedpods.PluginName, removefailedpods.New, &removefailedpods.RemoveFailedPods{}, &removefailedpods.RemoveFailedPodsArgs{}, removefailedpods.ValidateRemoveFailedPodsArgs, removefailedpods.SetDefaults_RemoveFailedPodsArgs, registry)
+	pluginregistry.Register(removepodsblockingpendingpods.PluginName, removepodsblockingpendingpods.New, &removepodsblockingpendingpods.RemovePodsBlockingPendingPods{}, &removepodsblockingpendingpods.RemovePodsBlockingPendingPodsArgs{}, removepodsblockingpendingpods.ValidateRemovePodsBlockingPendingPodsArgs, removepodsblockingpendingpods.SetDefaults_RemovePodsBlockingPendingPodsArgs, registry)
+	pluginregistry.Register(removepodsfrommaintenancenodes.PluginName, removepodsfrommaintenancenodes.New, &removepodsfrommaintenancenodes.RemovePodsFromMaintenanceNodes{}, &removepodsfrommaintenancenodes.RemovePodsFromMaintenanceNodesArgs{}, removepodsfrommaintenancenodes.ValidateRemovePodsFromMaintenanceNodesArgs, removepodsfrommaintenancenodes.SetDefaults_RemovePodsFromMaintenanceNodesArgs, registry)
+	pluginregistry.Register(removepodsfromnodeswithbadconditions.PluginName, removepodsfromnodeswithbadconditions.New, &removepodsfromnodeswithbadconditions.RemovePodsFromNodesWithBadConditions{}, &removepodsfromnodeswithbadconditions.RemovePodsFromNodesWithBadConditionsArgs{}, removepodsfromnodeswithbadconditions.ValidateRemovePodsFromNodesWithBadConditionsArgs, removepodsfromnodeswithbadconditions.SetDefaults_RemovePodsFromNodesWithBadConditionsArgs, registry)
+	pluginregistry.Register(removepodshavingstalerevision.PluginName, removepodshavingstalerevision.New, &removepodshavingstalerevision.RemovePodsHavingStaleRevision{}, &removepodshavingstalerevision.RemovePodsHavingStaleRevisionArgs{}, removepodshavingstalerevision.ValidateRemovePodsHavingStaleRevisionArgs, removepodshavingstalerevision.SetDefaults_RemovePodsHavingStaleRevisionArgs, registry)
 	pluginregistry.Register(removepodshavingtoomanyrestarts.PluginName, removepodshavingtoomanyrestarts.New, &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestarts{}, &removepodshavingtoomanyrestarts.RemovePodsHavingTooManyRestartsArgs{}, removepodshavingtoomanyrestarts.ValidateRemovePodsHavingTooManyRestartsArgs, removepodshavingtoomanyrestarts.SetDefaults_RemovePodsHavingTooManyRestartsArgs, registry)
+	pluginregistry.Register(removepodsviolatinginterpodaffinity.PluginName, removepodsviolatinginterpodaffinity.New, &removepodsviolatinginterpodaffinity.RemovePodsViolatingInterPodAffinity{}, &removepodsviolatinginterpodaffinity.RemovePodsViolatingInterPodAffinityArgs{}, removepodsviolatinginterpodaffinity.ValidateRemovePodsViolatingInterPodAffinityArgs, removepodsviolatinginterpodaffinity.SetDefaults_RemovePodsViolatingInterPodAffinityArgs, registry)
 	pluginregistry.Register(removepodsviolatinginterpodantiaffinity.PluginName, removepodsviolatinginterpodantiaffinity.New, &removepodsviolatinginterpodantiaffinity.RemovePodsViolatingInterPodAntiAffinity{}, &removepodsviolatinginterpodantiaffinity.RemovePodsViolatingInterPodAntiAffinityArgs{}, removepodsviolatinginterpodantiaffinity.ValidateRemovePodsViolatingInterPodAntiAffinityArgs, removepodsviolatinginterpodantiaffinity.SetDefaults_RemovePodsViolatingInterPodAntiAffinityArgs, registry)
 	pluginregistry.Register(removepodsviolatingnodeaffinity.PluginName, removepodsviolatingnodeaffinity.New, &removepodsviolatingnodeaffinity.RemovePodsViolatingNodeAffinity{}, &removepodsviolatingnodeaffinity.RemovePodsViolatingNodeAffinityArgs{}, removepodsviolatingnodeaffinity.ValidateRemovePodsViolatingNodeAffinityArgs, removepodsviolatingnodeaffinity.SetDefaults_RemovePodsViolatingNodeAffinityArgs, registry)
 	pluginregistry.Register(removepodsviolatingnodetaints.PluginName, removepodsviolatingnodetaints.New, &removepodsviolatingnodetaints.RemovePodsViolatingNodeTaints{}, &removepodsviolatingnodetaints.RemovePodsViolatingNodeTaintsArgs{}, removepodsviolatingnodetaints.ValidateRemovePodsViolatingNodeTaintsArgs, removepodsviolatingnodetaints.SetDefaults_RemovePodsViolatingNodeTaintsArgs, registry)
 	pluginregistry.Register(removepodsviolatingtopologyspreadconstraint.PluginName, removepodsviolatingtopologyspreadconstraint.New, &removepodsviolatingtopologyspreadconstraint.RemovePodsViolatingTopologySpreadConstraint{}, &removepodsviolatingtopologyspreadconstraint.RemovePodsViolatingTopologySpreadConstraintArgs{}, removepodsviolatingtopologyspreadconstraint.ValidateRemovePodsViolatingTopologySpreadConstraintArgs, removepodsviolatingtopologyspreadconstraint.SetDefaults_RemovePodsViolatingTopologySpreadConstraintArgs, registry)
+	pluginregistry.Register(rotatelonglivedpods.PluginName, rotatelonglivedpods.New, &rotatelonglivedpods.RotateLongLivedPods{}, &rotatelonglivedpods.RotateLongLivedPodsArgs{}, rotatelonglivedpods.ValidateRotateLongLivedPodsArgs, rotatelonglivedpods.SetDefaults_RotateLongLivedPodsArgs, registry)
 }
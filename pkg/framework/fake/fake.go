@@ -4,10 +4,13 @@ import (
 	"context"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
@@ -18,6 +21,7 @@ type HandleImpl struct {
 	SharedInformerFactoryImpl     informers.SharedInformerFactory
 	EvictorFilterImpl             frameworktypes.EvictorPlugin
 	PodEvictorImpl                *evictions.PodEvictor
+	NodeSnapshotImpl              *nodeutil.Snapshot
 }
 
 var _ frameworktypes.Handle = &HandleImpl{}
@@ -38,6 +42,10 @@ func (hi *HandleImpl) Evictor() frameworktypes.Evictor {
 	return hi
 }
 
+func (hi *HandleImpl) NodeSnapshot() *nodeutil.Snapshot {
+	return hi.NodeSnapshotImpl
+}
+
 func (hi *HandleImpl) Filter(pod *v1.Pod) bool {
 	return hi.EvictorFilterImpl.Filter(pod)
 }
@@ -47,9 +55,21 @@ func (hi *HandleImpl) PreEvictionFilter(pod *v1.Pod) bool {
 }
 
 func (hi *HandleImpl) Evict(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions) bool {
-	return hi.PodEvictorImpl.EvictPod(ctx, pod, opts)
+	evicted := hi.PodEvictorImpl.EvictPod(ctx, pod, opts)
+	if evicted && hi.NodeSnapshotImpl != nil {
+		hi.NodeSnapshotImpl.RemovePod(pod)
+	}
+	return evicted
 }
 
 func (hi *HandleImpl) NodeLimitExceeded(node *v1.Node) bool {
 	return hi.PodEvictorImpl.NodeLimitExceeded(node)
 }
+
+func (hi *HandleImpl) EvictionsAllowed(pods []*v1.Pod) bool {
+	return hi.PodEvictorImpl.EvictionsAllowed(pods)
+}
+
+func (hi *HandleImpl) EvictedOwnerPods(ownerUID types.UID) sets.Set[types.UID] {
+	return hi.PodEvictorImpl.EvictedOwnerPods(ownerUID)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celevictor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "CELEvictor"
+
+const (
+	podVarName       = "pod"
+	nodeVarName      = "node"
+	namespaceVarName = "namespaceObject"
+	nowVarName       = "now"
+
+	// expressionCostLimit bounds the evaluation of a single expression
+	expressionCostLimit = 1000000
+)
+
+var _ frameworktypes.EvictorPlugin = &CELEvictor{}
+
+// CELEvictor is an EvictorPlugin filtering pods with CEL expressions,
+// a pod is evicted only when all the expressions evaluate to true.
+type CELEvictor struct {
+	handle          frameworktypes.Handle
+	args            *CELEvictorArgs
+	expressions     []compiledExpression
+	nodeLister      corev1listers.NodeLister
+	namespaceLister corev1listers.NamespaceLister
+}
+
+type compiledExpression struct {
+	Expression
+	program cel.Program
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	celArgs, ok := args.(*CELEvictorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type CELEvictorArgs, got %T", args)
+	}
+
+	expressions, err := compile(celArgs.Expressions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CELEvictor{
+		handle:          handle,
+		args:            celArgs,
+		expressions:     expressions,
+		nodeLister:      handle.SharedInformerFactory().Core().V1().Nodes().Lister(),
+		namespaceLister: handle.SharedInformerFactory().Core().V1().Namespaces().Lister(),
+	}, nil
+}
+
+// compile type checks the expressions and plans their programs
+func compile(expressions []Expression) ([]compiledExpression, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(podVarName, cel.DynType),
+		cel.Variable(nodeVarName, cel.DynType),
+		cel.Variable(namespaceVarName, cel.DynType),
+		cel.Variable(nowVarName, cel.TimestampType),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the CEL environment: %v", err)
+	}
+
+	var compiled []compiledExpression
+	var errs []error
+	for i, expression := range expressions {
+		ast, issues := env.Compile(expression.Expression)
+		if issues != nil && issues.Err() != nil {
+			errs = append(errs, fmt.Errorf("expressions[%d]: unable to compile %q: %v", i, expression.Expression, issues.Err()))
+			continue
+		}
+		if !ast.OutputType().IsExactType(cel.BoolType) {
+			errs = append(errs, fmt.Errorf("expressions[%d]: %q must evaluate to bool, got %v", i, expression.Expression, ast.OutputType()))
+			continue
+		}
+		program, err := env.Program(ast, cel.EvalOptions(cel.OptOptimize), cel.CostLimit(expressionCostLimit))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("expressions[%d]: unable to plan %q: %v", i, expression.Expression, err))
+			continue
+		}
+		compiled = append(compiled, compiledExpression{Expression: expression, program: program})
+	}
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return compiled, nil
+}
+
+// Name retrieves the plugin name
+func (c *CELEvictor) Name() string {
+	return PluginName
+}
+
+func (c *CELEvictor) Filter(pod *v1.Pod) bool {
+	return c.evaluate(pod)
+}
+
+func (c *CELEvictor) PreEvictionFilter(pod *v1.Pod) bool {
+	return c.evaluate(pod)
+}
+
+// evaluate tells whether all the expressions evaluate to true for the pod.
+// Failing evaluations keep the pod from being evicted.
+func (c *CELEvictor) evaluate(pod *v1.Pod) bool {
+	activation, err := c.activation(pod)
+	if err != nil {
+		klog.ErrorS(err, "Unable to evaluate the CEL expressions", "pod", klog.KObj(pod))
+		return false
+	}
+	for _, expression := range c.expressions {
+		out, _, err := expression.program.Eval(activation)
+		if err != nil {
+			klog.ErrorS(err, "Unable to evaluate the CEL expression", "pod", klog.KObj(pod), "expression", expression.Expression.Expression)
+			return false
+		}
+		if evictable, ok := out.Value().(bool); !ok || !evictable {
+			message := expression.Message
+			if message == "" {
+				message = fmt.Sprintf("expression %q evaluated to false", expression.Expression.Expression)
+			}
+			klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod), "checks", message)
+			return false
+		}
+	}
+	return true
+}
+
+func (c *CELEvictor) activation(pod *v1.Pod) (map[string]interface{}, error) {
+	podObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert the pod: %v", err)
+	}
+	activation := map[string]interface{}{
+		podVarName:       podObj,
+		nodeVarName:      nil,
+		namespaceVarName: nil,
+		nowVarName:       time.Now(),
+	}
+	if pod.Spec.NodeName != "" {
+		if node, err := c.nodeLister.Get(pod.Spec.NodeName); err == nil {
+			if activation[nodeVarName], err = runtime.DefaultUnstructuredConverter.ToUnstructured(node); err != nil {
+				return nil, fmt.Errorf("unable to convert the node: %v", err)
+			}
+		}
+	}
+	if namespace, err := c.namespaceLister.Get(pod.Namespace); err == nil {
+		if activation[namespaceVarName], err = runtime.DefaultUnstructuredConverter.ToUnstructured(namespace); err != nil {
+			return nil, fmt.Errorf("unable to convert the namespace: %v", err)
+		}
+	}
+	return activation, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celevictor
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestCELEvictor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node := test.BuildTestNode("node1", 1000, 2000, 9, func(node *v1.Node) {
+		node.Labels = map[string]string{"pool": "spot"}
+	})
+	namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"team": "a"}}}
+	pod := test.BuildTestPod("p1", 100, 0, node.Name, func(pod *v1.Pod) {
+		pod.CreationTimestamp = metav1.Now()
+		pod.Labels = map[string]string{"track": "canary"}
+		pod.Spec.Containers[0].Env = []v1.EnvVar{{Name: "NO_EVICT", Value: "1"}}
+	})
+	unscheduled := test.BuildTestPod("p2", 100, 0, "", nil)
+
+	tests := []struct {
+		description string
+		expressions []string
+		pod         *v1.Pod
+		result      bool
+	}{
+		{
+			description: "pod labels",
+			expressions: []string{`!("track" in pod.metadata.labels) || pod.metadata.labels.track != "canary"`},
+			pod:         pod,
+			result:      false,
+		},
+		{
+			description: "container env",
+			expressions: []string{`!pod.spec.containers.exists(c, has(c.env) && c.env.exists(e, e.name == "NO_EVICT"))`},
+			pod:         pod,
+			result:      false,
+		},
+		{
+			description: "node labels",
+			expressions: []string{`node.metadata.labels.pool == "spot"`},
+			pod:         pod,
+			result:      true,
+		},
+		{
+			description: "namespace labels",
+			expressions: []string{`namespaceObject.metadata.labels.team == "a"`},
+			pod:         pod,
+			result:      true,
+		},
+		{
+			description: "pod age",
+			expressions: []string{`now - timestamp(pod.metadata.creationTimestamp) > duration("1h")`},
+			pod:         pod,
+			result:      false,
+		},
+		{
+			description: "all expressions must be true",
+			expressions: []string{`true`, `pod.metadata.name.startsWith("p2")`},
+			pod:         pod,
+			result:      false,
+		},
+		{
+			description: "node is null for pods not assigned to a node",
+			expressions: []string{`node == null`},
+			pod:         unscheduled,
+			result:      true,
+		},
+		{
+			description: "evaluation errors do not evict",
+			expressions: []string{`pod.metadata.annotations.missing == "x"`},
+			pod:         pod,
+			result:      false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(node, namespace, tc.pod)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+
+			args := &CELEvictorArgs{}
+			for _, expression := range tc.expressions {
+				args.Expressions = append(args.Expressions, Expression{Expression: expression})
+			}
+			plugin, err := New(args, &frameworkfake.HandleImpl{
+				ClientsetImpl:             fakeClient,
+				SharedInformerFactoryImpl: sharedInformerFactory,
+			})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			evictorPlugin := plugin.(frameworktypes.EvictorPlugin)
+			if result := evictorPlugin.Filter(tc.pod); result != tc.result {
+				t.Errorf("Expected Filter to return %v, got %v", tc.result, result)
+			}
+			if result := evictorPlugin.PreEvictionFilter(tc.pod); result != tc.result {
+				t.Errorf("Expected PreEvictionFilter to return %v, got %v", tc.result, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celevictor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CELEvictorArgs holds arguments used to configure CELEvictor plugin.
+type CELEvictorArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Expressions which all have to evaluate to true for a pod to be evicted.
+	Expressions []Expression `json:"expressions"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Expression is a CEL expression evaluated against the pod, its node and its namespace.
+type Expression struct {
+	// Expression must evaluate to a bool. The pod, node and namespaceObject variables hold
+	// the objects, node and namespaceObject are null when they can not be found.
+	// The now variable holds the current time.
+	Expression string `json:"expression"`
+	// Message explains why a pod is not evicted when the expression evaluates to false.
+	Message string `json:"message,omitempty"`
+}
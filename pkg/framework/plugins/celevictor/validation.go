@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celevictor
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateCELEvictorArgs validates CELEvictor arguments
+func ValidateCELEvictorArgs(obj runtime.Object) error {
+	args := obj.(*CELEvictorArgs)
+	if len(args.Expressions) == 0 {
+		return fmt.Errorf("at least one expression must be set")
+	}
+	_, err := compile(args.Expressions)
+	return err
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package celevictor
+
+import (
+	"testing"
+)
+
+func TestValidateCELEvictorArgs(t *testing.T) {
+	tests := []struct {
+		description string
+		expressions []string
+		expectErr   bool
+	}{
+		{
+			description: "valid expressions",
+			expressions: []string{`pod.metadata.name != "p1"`, `node == null || !has(node.spec.unschedulable)`},
+		},
+		{
+			description: "no expressions",
+			expectErr:   true,
+		},
+		{
+			description: "syntax error",
+			expressions: []string{`pod.metadata.name ==`},
+			expectErr:   true,
+		},
+		{
+			description: "undeclared variable",
+			expressions: []string{`deployment.metadata.name == "d"`},
+			expectErr:   true,
+		},
+		{
+			description: "not a bool",
+			expressions: []string{`"pod"`},
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			args := &CELEvictorArgs{}
+			for _, expression := range tc.expressions {
+				args.Expressions = append(args.Expressions, Expression{Expression: expression})
+			}
+			err := ValidateCELEvictorArgs(args)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("Expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
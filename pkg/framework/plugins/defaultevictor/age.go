@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const (
+	// PodAgeFromStartTime computes the age of pods from the time they were started
+	PodAgeFromStartTime = "StartTime"
+	// PodAgeFromReady computes the age of pods from the time they became Ready
+	PodAgeFromReady = "Ready"
+
+	deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+)
+
+// podAgeStart returns the time the age of the pod is computed from. Pods which are
+// not Ready fall back to their start time, and pods not started yet to their creation.
+func podAgeStart(pod *v1.Pod, from string) time.Time {
+	if from == PodAgeFromReady {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodReady && condition.Status == v1.ConditionTrue && !condition.LastTransitionTime.IsZero() {
+				return condition.LastTransitionTime.Time
+			}
+		}
+	}
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// revisionAgeChecker looks up when the current revision of the workloads owning pods,
+// that is the newest ReplicaSet of a Deployment or the update revision of a StatefulSet,
+// became current.
+type revisionAgeChecker struct {
+	minAge                   time.Duration
+	replicaSetLister         appsv1listers.ReplicaSetLister
+	deploymentLister         appsv1listers.DeploymentLister
+	statefulSetLister        appsv1listers.StatefulSetLister
+	controllerRevisionLister appsv1listers.ControllerRevisionLister
+	podLister                corev1listers.PodLister
+	now                      func() time.Time
+}
+
+func newRevisionAgeChecker(handle frameworktypes.Handle, minAge time.Duration) *revisionAgeChecker {
+	informerFactory := handle.SharedInformerFactory()
+	return &revisionAgeChecker{
+		minAge:                   minAge,
+		replicaSetLister:         informerFactory.Apps().V1().ReplicaSets().Lister(),
+		deploymentLister:         informerFactory.Apps().V1().Deployments().Lister(),
+		statefulSetLister:        informerFactory.Apps().V1().StatefulSets().Lister(),
+		controllerRevisionLister: informerFactory.Apps().V1().ControllerRevisions().Lister(),
+		podLister:                informerFactory.Core().V1().Pods().Lister(),
+		now:                      time.Now,
+	}
+}
+
+// check returns an error when the current revision of the owner of the pod is younger
+// than the minimum age. Owners missing from the informer caches are not checked.
+func (c *revisionAgeChecker) check(pod *v1.Pod) error {
+	ownerRef := metav1.GetControllerOfNoCopy(pod)
+	if ownerRef == nil {
+		return nil
+	}
+	var current time.Time
+	switch ownerRef.Kind {
+	case "ReplicaSet":
+		current = c.replicaSetRevision(pod.Namespace, ownerRef.Name)
+	case "StatefulSet":
+		current = c.statefulSetRevision(pod.Namespace, ownerRef.Name)
+	}
+	if current.IsZero() {
+		return nil
+	}
+	if age := c.now().Sub(current); age < c.minAge {
+		return fmt.Errorf("current revision of owner %s/%s is %v old, less than minOwnerRevisionAgeSeconds", ownerRef.Kind, ownerRef.Name, age.Round(time.Second))
+	}
+	return nil
+}
+
+// replicaSetRevision returns the time the newest ReplicaSet of the Deployment owning the
+// ReplicaSet became current, or the creation time of the ReplicaSet itself when it is not
+// owned by a Deployment.
+func (c *revisionAgeChecker) replicaSetRevision(namespace, name string) time.Time {
+	rs, err := c.replicaSetLister.ReplicaSets(namespace).Get(name)
+	if err != nil {
+		return time.Time{}
+	}
+	deploymentRef := metav1.GetControllerOfNoCopy(rs)
+	if deploymentRef == nil || deploymentRef.Kind != "Deployment" {
+		return rs.CreationTimestamp.Time
+	}
+	replicaSets, err := c.replicaSetLister.ReplicaSets(namespace).List(labels.Everything())
+	if err != nil {
+		return time.Time{}
+	}
+	newest, newestRevision := rs, revisionOf(rs.Annotations)
+	for _, other := range replicaSets {
+		if ref := metav1.GetControllerOfNoCopy(other); ref == nil || ref.UID != deploymentRef.UID {
+			continue
+		}
+		if revision := revisionOf(other.Annotations); revision > newestRevision {
+			newest, newestRevision = other, revision
+		}
+	}
+	current := newest.CreationTimestamp.Time
+
+	// A rollback makes an existing ReplicaSet the newest one again without changing its
+	// creation time. The Progressing condition is updated by every rollout instead.
+	deployment, err := c.deploymentLister.Deployments(namespace).Get(deploymentRef.Name)
+	if err != nil || deployment.UID != deploymentRef.UID {
+		return current
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.LastUpdateTime.Time.After(current) {
+			current = condition.LastUpdateTime.Time
+		}
+	}
+	return current
+}
+
+// statefulSetRevision returns the time the update revision of the StatefulSet became
+// current. A rollback makes an existing ControllerRevision the update revision again
+// without changing its creation time, so the pods the rollout recreated with the update
+// revision are taken into account as well.
+func (c *revisionAgeChecker) statefulSetRevision(namespace, name string) time.Time {
+	ss, err := c.statefulSetLister.StatefulSets(namespace).Get(name)
+	if err != nil || ss.Status.UpdateRevision == "" {
+		return time.Time{}
+	}
+	var current time.Time
+	if revision, err := c.controllerRevisionLister.ControllerRevisions(namespace).Get(ss.Status.UpdateRevision); err == nil {
+		current = revision.CreationTimestamp.Time
+	}
+	selector, err := metav1.LabelSelectorAsSelector(ss.Spec.Selector)
+	if err != nil {
+		return current
+	}
+	pods, err := c.podLister.Pods(namespace).List(selector)
+	if err != nil {
+		return current
+	}
+	for _, pod := range pods {
+		if ref := metav1.GetControllerOfNoCopy(pod); ref == nil || ref.UID != ss.UID {
+			continue
+		}
+		if pod.Labels[appsv1.ControllerRevisionHashLabelKey] == ss.Status.UpdateRevision && pod.CreationTimestamp.Time.After(current) {
+			current = pod.CreationTimestamp.Time
+		}
+	}
+	return current
+}
+
+func revisionOf(annotations map[string]string) int64 {
+	revision, err := strconv.ParseInt(annotations[deploymentRevisionAnnotation], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return revision
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDefaultEvictorMinPodAge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ago := func(d time.Duration) metav1.Time {
+		return metav1.NewTime(time.Now().Add(-d))
+	}
+	buildPod := func(started, ready *metav1.Time) *v1.Pod {
+		return test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+			pod.OwnerReferences = test.GetNormalPodOwnerRefList()
+			pod.CreationTimestamp = ago(2 * time.Hour)
+			pod.Status.StartTime = started
+			if ready != nil {
+				pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue, LastTransitionTime: *ready}}
+			}
+		})
+	}
+
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		from        string
+		result      bool
+	}{
+		{
+			description: "pod started long ago",
+			pod:         buildPod(utilptr.To(ago(time.Hour)), nil),
+			result:      true,
+		},
+		{
+			description: "pod started recently",
+			pod:         buildPod(utilptr.To(ago(time.Minute)), nil),
+			result:      false,
+		},
+		{
+			description: "pod not started yet falls back to the creation time",
+			pod:         buildPod(nil, nil),
+			result:      true,
+		},
+		{
+			description: "pod started long ago but became Ready recently",
+			pod:         buildPod(utilptr.To(ago(time.Hour)), utilptr.To(ago(time.Minute))),
+			from:        PodAgeFromReady,
+			result:      false,
+		},
+		{
+			description: "pod Ready long ago",
+			pod:         buildPod(utilptr.To(ago(time.Hour)), utilptr.To(ago(time.Hour))),
+			from:        PodAgeFromReady,
+			result:      true,
+		},
+		{
+			description: "pod not Ready falls back to the start time",
+			pod:         buildPod(utilptr.To(ago(time.Minute)), nil),
+			from:        PodAgeFromReady,
+			result:      false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(tc.pod)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+
+			evictorPlugin, err := New(&DefaultEvictorArgs{MinPodAgeSeconds: utilptr.To[uint](600), MinPodAgeFrom: tc.from}, &frameworkfake.HandleImpl{
+				ClientsetImpl:             fakeClient,
+				SharedInformerFactoryImpl: sharedInformerFactory,
+			})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			if result := evictorPlugin.(frameworktypes.EvictorPlugin).Filter(tc.pod); result != tc.result {
+				t.Errorf("Expected pod %s to be evictable: %t, got %t", tc.pod.Name, tc.result, result)
+			}
+		})
+	}
+}
+
+func TestDefaultEvictorMinOwnerRevisionAge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ago := func(d time.Duration) metav1.Time {
+		return metav1.NewTime(time.Now().Add(-d))
+	}
+	withOwner := func(kind, name, uid string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: name, UID: types.UID(uid), Controller: utilptr.To(true)}}
+		}
+	}
+	buildReplicaSet := func(name, revision string, created metav1.Time) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: created,
+			Annotations:       map[string]string{deploymentRevisionAnnotation: revision},
+			OwnerReferences:   []metav1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "web-uid", Controller: utilptr.To(true)}},
+		}}
+	}
+	buildRevision := func(name string, revision int64, created metav1.Time, ownerKind, ownerUID string) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: created,
+				OwnerReferences:   []metav1.OwnerReference{{Kind: ownerKind, Name: "owner", UID: types.UID(ownerUID), Controller: utilptr.To(true)}},
+			},
+			Revision: revision,
+		}
+	}
+
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		objects     []runtime.Object
+		result      bool
+	}{
+		{
+			description: "deployment rolled out long ago",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "web-1", "")),
+			objects:     []runtime.Object{buildReplicaSet("web-1", "1", ago(time.Hour))},
+			result:      true,
+		},
+		{
+			description: "pod of the old revision of a deployment in rollout",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "web-1", "")),
+			objects: []runtime.Object{
+				buildReplicaSet("web-1", "1", ago(time.Hour)),
+				buildReplicaSet("web-2", "2", ago(time.Minute)),
+			},
+			result: false,
+		},
+		{
+			description: "statefulset update revision created recently",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withOwner("StatefulSet", "db", "")),
+			objects: []runtime.Object{
+				&appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+					Status:     appsv1.StatefulSetStatus{CurrentRevision: "db-1", UpdateRevision: "db-2"},
+				},
+				buildRevision("db-1", 1, ago(time.Hour), "StatefulSet", "db-uid"),
+				buildRevision("db-2", 2, ago(time.Minute), "StatefulSet", "db-uid"),
+			},
+			result: false,
+		},
+		{
+			description: "deployment rolled back to an old replicaset recently",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "web-2", "")),
+			objects: []runtime.Object{
+				buildReplicaSet("web-1", "3", ago(2*time.Hour)),
+				buildReplicaSet("web-2", "2", ago(time.Hour)),
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "web-uid"},
+					Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+						{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, LastUpdateTime: ago(time.Minute), LastTransitionTime: ago(2 * time.Hour)},
+					}},
+				},
+			},
+			result: false,
+		},
+		{
+			description: "deployment rolled back long ago",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "web-1", "")),
+			objects: []runtime.Object{
+				buildReplicaSet("web-1", "3", ago(2*time.Hour)),
+				buildReplicaSet("web-2", "2", ago(time.Hour)),
+				&appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "web-uid"},
+					Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+						{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, LastUpdateTime: ago(30 * time.Minute), LastTransitionTime: ago(2 * time.Hour)},
+					}},
+				},
+			},
+			result: true,
+		},
+		{
+			description: "statefulset rolled back to an old revision recently",
+			pod: test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+				withOwner("StatefulSet", "db", "db-uid")(pod)
+				pod.Labels = map[string]string{"app": "db", appsv1.ControllerRevisionHashLabelKey: "db-2"}
+			}),
+			objects: []runtime.Object{
+				&appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: "db-uid"},
+					Spec:       appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}},
+					Status:     appsv1.StatefulSetStatus{CurrentRevision: "db-2", UpdateRevision: "db-1"},
+				},
+				buildRevision("db-1", 3, ago(2*time.Hour), "StatefulSet", "db-uid"),
+				buildRevision("db-2", 2, ago(time.Hour), "StatefulSet", "db-uid"),
+				test.BuildTestPod("p2", 100, 0, "node1", func(pod *v1.Pod) {
+					withOwner("StatefulSet", "db", "db-uid")(pod)
+					pod.Labels = map[string]string{"app": "db", appsv1.ControllerRevisionHashLabelKey: "db-1"}
+					pod.CreationTimestamp = ago(time.Minute)
+				}),
+			},
+			result: false,
+		},
+		{
+			description: "replicaset without deployment created recently",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "standalone", "")),
+			objects: []runtime.Object{
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default", CreationTimestamp: ago(time.Minute)}},
+			},
+			result: false,
+		},
+		{
+			description: "owner missing from the cache",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "web-1", "")),
+			result:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(append(tc.objects, tc.pod)...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+
+			evictorPlugin, err := New(&DefaultEvictorArgs{MinOwnerRevisionAgeSeconds: utilptr.To[uint](600)}, &frameworkfake.HandleImpl{
+				ClientsetImpl:             fakeClient,
+				SharedInformerFactoryImpl: sharedInformerFactory,
+			})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			if result := evictorPlugin.(frameworktypes.EvictorPlugin).Filter(tc.pod); result != tc.result {
+				t.Errorf("Expected pod %s to be evictable: %t, got %t", tc.pod.Name, tc.result, result)
+			}
+		})
+	}
+}
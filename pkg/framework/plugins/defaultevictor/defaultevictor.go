@@ -18,12 +18,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
@@ -35,6 +38,7 @@ import (
 const (
 	PluginName            = "DefaultEvictor"
 	evictPodAnnotationKey = "descheduler.alpha.kubernetes.io/evict"
+	ownerRefIndexName     = "metadata.ownerReferences"
 )
 
 var _ frameworktypes.EvictorPlugin = &DefaultEvictor{}
@@ -47,9 +51,12 @@ type constraint func(pod *v1.Pod) error
 // This plugin is only meant to customize other actions (extension points) of the evictor,
 // like filtering, sorting, and other ones that might be relevant in the future
 type DefaultEvictor struct {
-	args        runtime.Object
-	constraints []constraint
-	handle      frameworktypes.Handle
+	args            runtime.Object
+	constraints     []constraint
+	handle          frameworktypes.Handle
+	ownerRefIndexer cache.Indexer
+	protection      *protectionChecker
+	nodeFitOptions  []nodeutil.NodeFitOption
 }
 
 // IsPodEvictableBasedOnPriority checks if the given pod is evictable based on priority resolved from pod Spec.
@@ -73,6 +80,7 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 	ev := &DefaultEvictor{}
 	ev.handle = handle
 	ev.args = defaultEvictorArgs
+	ev.protection = newProtectionChecker(handle, defaultEvictorArgs.OwnerPreventEviction)
 
 	if defaultEvictorArgs.EvictFailedBarePods {
 		klog.V(1).InfoS("Warning: EvictFailedBarePods is set to True. This could cause eviction of pods without ownerReferences.")
@@ -118,8 +126,8 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 	}
 	if !defaultEvictorArgs.EvictLocalStoragePods {
 		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
-			if utils.IsPodWithLocalStorage(pod) {
-				return fmt.Errorf("pod has local storage and descheduler is not configured with evictLocalStoragePods")
+			if volume, ok := protectedLocalStorage(pod, defaultEvictorArgs.EvictMemoryBackedEmptyDirs, defaultEvictorArgs.EvictEmptyDirSizeLimit); ok {
+				return fmt.Errorf("pod has local storage in %s and descheduler is not configured with evictLocalStoragePods", volume)
 			}
 			return nil
 		})
@@ -145,12 +153,49 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		})
 	}
 
-	if defaultEvictorArgs.MinReplicas > 1 {
-		indexName := "metadata.ownerReferences"
-		indexer, err := getPodIndexerByOwnerRefs(indexName, handle)
+	if ownerFilter := newOwnerFilter(defaultEvictorArgs, handle); ownerFilter != nil {
+		ev.constraints = append(ev.constraints, ownerFilter.check)
+	}
+
+	if defaultEvictorArgs.MinPodAgeSeconds != nil {
+		minAge := time.Duration(*defaultEvictorArgs.MinPodAgeSeconds) * time.Second
+		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
+			if age := time.Since(podAgeStart(pod, defaultEvictorArgs.MinPodAgeFrom)); age < minAge {
+				return fmt.Errorf("pod is %v old, less than minPodAgeSeconds of %d", age.Round(time.Second), *defaultEvictorArgs.MinPodAgeSeconds)
+			}
+			return nil
+		})
+	}
+
+	if defaultEvictorArgs.MinOwnerRevisionAgeSeconds != nil {
+		checker := newRevisionAgeChecker(handle, time.Duration(*defaultEvictorArgs.MinOwnerRevisionAgeSeconds)*time.Second)
+		ev.constraints = append(ev.constraints, checker.check)
+	}
+
+	if defaultEvictorArgs.IgnoreUnstableOwners {
+		ev.constraints = append(ev.constraints, newStabilityChecker(handle).check)
+	}
+
+	if defaultEvictorArgs.NodeFit {
+		predicates := nodeFitPredicates(defaultEvictorArgs.NodeFitPredicates)
+		ev.nodeFitOptions = append(ev.nodeFitOptions, nodeutil.WithPredicates(predicates))
+		if predicates.Has(nodeutil.VolumeZonePredicate) {
+			ev.nodeFitOptions = append(ev.nodeFitOptions, nodeutil.WithVolumeListers(
+				handle.SharedInformerFactory().Core().V1().PersistentVolumeClaims().Lister(),
+				handle.SharedInformerFactory().Core().V1().PersistentVolumes().Lister(),
+			))
+		}
+	}
+
+	if defaultEvictorArgs.MinReplicas > 1 || defaultEvictorArgs.MaxUnavailable != nil {
+		indexer, err := getPodIndexerByOwnerRefs(ownerRefIndexName, handle)
 		if err != nil {
 			return nil, err
 		}
+		ev.ownerRefIndexer = indexer
+	}
+
+	if defaultEvictorArgs.MinReplicas > 1 {
 		ev.constraints = append(ev.constraints, func(pod *v1.Pod) error {
 			if len(pod.OwnerReferences) == 0 {
 				return nil
@@ -162,7 +207,7 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 			}
 
 			ownerRef := pod.OwnerReferences[0]
-			objs, err := indexer.ByIndex(indexName, string(ownerRef.UID))
+			objs, err := ev.ownerRefIndexer.ByIndex(ownerRefIndexName, string(ownerRef.UID))
 			if err != nil {
 				return fmt.Errorf("unable to list pods for minReplicas filter in the policy parameter")
 			}
@@ -175,6 +220,10 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		})
 	}
 
+	if defaultEvictorArgs.MaxUnavailable != nil {
+		ev.constraints = append(ev.constraints, ev.checkMaxUnavailable)
+	}
+
 	return ev, nil
 }
 
@@ -185,13 +234,28 @@ func (d *DefaultEvictor) Name() string {
 
 func (d *DefaultEvictor) PreEvictionFilter(pod *v1.Pod) bool {
 	defaultEvictorArgs := d.args.(*DefaultEvictorArgs)
+	if defaultEvictorArgs.MaxUnavailable != nil && !HaveEvictAnnotation(pod) {
+		// evictions performed since the pod was filtered count against the budget
+		if err := d.checkMaxUnavailable(pod); err != nil {
+			klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod), "checks", err.Error())
+			return false
+		}
+	}
 	if defaultEvictorArgs.NodeFit {
-		nodes, err := nodeutil.ReadyNodes(context.TODO(), d.handle.ClientSet(), d.handle.SharedInformerFactory().Core().V1().Nodes().Lister(), defaultEvictorArgs.NodeSelector)
+		var nodes []*v1.Node
+		var err error
+		nodeFitOptions := d.nodeFitOptions
+		if snapshot := d.handle.NodeSnapshot(); snapshot != nil {
+			nodes, err = snapshot.ReadyNodes(context.TODO(), defaultEvictorArgs.NodeSelector)
+			nodeFitOptions = append(append([]nodeutil.NodeFitOption{}, d.nodeFitOptions...), nodeutil.WithSnapshot(snapshot))
+		} else {
+			nodes, err = nodeutil.ReadyNodes(context.TODO(), d.handle.ClientSet(), d.handle.SharedInformerFactory().Core().V1().Nodes().Lister(), defaultEvictorArgs.NodeSelector)
+		}
 		if err != nil {
 			klog.ErrorS(err, "unable to list ready nodes", "pod", klog.KObj(pod))
 			return false
 		}
-		if !nodeutil.PodFitsAnyOtherNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes) {
+		if !nodeutil.PodFitsAnyOtherNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes, nodeFitOptions...) {
 			klog.InfoS("pod does not fit on any other node because of nodeSelector(s), Taint(s), or nodes marked as unschedulable", "pod", klog.KObj(pod))
 			return false
 		}
@@ -203,6 +267,12 @@ func (d *DefaultEvictor) PreEvictionFilter(pod *v1.Pod) bool {
 func (d *DefaultEvictor) Filter(pod *v1.Pod) bool {
 	checkErrs := []error{}
 
+	// protection takes precedence over the evict annotation
+	if err := d.protection.check(pod); err != nil {
+		klog.V(3).InfoS("Pod is not evictable", "pod", klog.KObj(pod), "reason", err.Error())
+		return false
+	}
+
 	if HaveEvictAnnotation(pod) {
 		return true
 	}
@@ -238,6 +308,62 @@ func (d *DefaultEvictor) Filter(pod *v1.Pod) bool {
 	return true
 }
 
+// nodeFitPredicates enables or disables the given predicates on top of the default ones
+func nodeFitPredicates(overrides map[string]bool) sets.Set[nodeutil.NodeFitPredicate] {
+	predicates := nodeutil.DefaultNodeFitPredicates.Clone()
+	for name, enabled := range overrides {
+		if enabled {
+			predicates.Insert(nodeutil.NodeFitPredicate(name))
+		} else {
+			predicates.Delete(nodeutil.NodeFitPredicate(name))
+		}
+	}
+	return predicates
+}
+
+// checkMaxUnavailable makes sure evicting the pod does not make more pods of its
+// owner unavailable than maxUnavailable. Pods which are not Ready, are terminating
+// or were already evicted in the current descheduling cycle count as unavailable.
+func (d *DefaultEvictor) checkMaxUnavailable(pod *v1.Pod) error {
+	ownerRef := metav1.GetControllerOfNoCopy(pod)
+	if ownerRef == nil {
+		return nil
+	}
+	if !utils.IsPodReady(pod) || utils.IsPodTerminating(pod) {
+		// evicting an unavailable pod does not reduce the availability of its owner
+		return nil
+	}
+
+	objs, err := d.ownerRefIndexer.ByIndex(ownerRefIndexName, string(ownerRef.UID))
+	if err != nil {
+		return fmt.Errorf("unable to list pods for maxUnavailable filter in the policy parameter")
+	}
+	evicted := d.handle.Evictor().EvictedOwnerPods(ownerRef.UID)
+	if evicted.Has(pod.UID) {
+		return fmt.Errorf("pod was already evicted")
+	}
+	replicas, unavailable := evicted.Len(), evicted.Len()
+	for _, obj := range objs {
+		ownerPod, ok := obj.(*v1.Pod)
+		if !ok || evicted.Has(ownerPod.UID) || ownerPod.Status.Phase == v1.PodSucceeded || ownerPod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		replicas++
+		if !utils.IsPodReady(ownerPod) || utils.IsPodTerminating(ownerPod) {
+			unavailable++
+		}
+	}
+
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(d.args.(*DefaultEvictorArgs).MaxUnavailable, replicas, true)
+	if err != nil {
+		return fmt.Errorf("invalid maxUnavailable: %v", err)
+	}
+	if unavailable+1 > maxUnavailable {
+		return fmt.Errorf("owner %s/%s has %d of %d replicas unavailable, evicting the pod would exceed maxUnavailable of %d", ownerRef.Kind, ownerRef.Name, unavailable, replicas, maxUnavailable)
+	}
+	return nil
+}
+
 func getPodIndexerByOwnerRefs(indexName string, handle frameworktypes.Handle) (cache.Indexer, error) {
 	podInformer := handle.SharedInformerFactory().Core().V1().Pods().Informer()
 	if err := podInformer.AddIndexers(cache.Indexers{
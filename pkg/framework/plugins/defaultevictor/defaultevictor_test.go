@@ -19,11 +19,16 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
@@ -48,10 +53,37 @@ func TestDefaultEvictorPreEvictionFilter(t *testing.T) {
 		evictSystemCriticalPods bool
 		priorityThreshold       *int32
 		nodeFit                 bool
+		nodeFitPredicates       map[string]bool
 		result                  bool
 	}
 
+	withHostPort := func(pod *v1.Pod) {
+		pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+		pod.Spec.Containers[0].Ports = []v1.ContainerPort{{ContainerPort: 80, HostPort: 8080}}
+	}
+
 	testCases := []testCase{
+		{
+			description: "Pod with a host port used on the other node fits when the NodePorts predicate is disabled",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, withHostPort),
+				test.BuildTestPod("p2", 1, 1, "node2", withHostPort),
+			},
+			nodes:   []*v1.Node{n1, test.BuildTestNode("node2", 1000, 2000, 13, nil)},
+			nodeFit: true,
+			result:  true,
+		},
+		{
+			description: "Pod with a host port used on the other node does not fit when the NodePorts predicate is enabled",
+			pods: []*v1.Pod{
+				test.BuildTestPod("p1", 1, 1, n1.Name, withHostPort),
+				test.BuildTestPod("p2", 1, 1, "node2", withHostPort),
+			},
+			nodes:             []*v1.Node{n1, test.BuildTestNode("node2", 1000, 2000, 13, nil)},
+			nodeFit:           true,
+			nodeFitPredicates: map[string]bool{"NodePorts": true},
+			result:            false,
+		},
 		{
 			description: "Pod with no tolerations running on normal node, all other nodes tainted",
 			pods: []*v1.Pod{
@@ -334,7 +366,8 @@ func TestDefaultEvictorPreEvictionFilter(t *testing.T) {
 				PriorityThreshold: &api.PriorityThreshold{
 					Value: test.priorityThreshold,
 				},
-				NodeFit: test.nodeFit,
+				NodeFit:           test.nodeFit,
+				NodeFitPredicates: test.nodeFitPredicates,
 			}
 
 			evictorPlugin, err := New(
@@ -808,3 +841,113 @@ func TestDefaultEvictorFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultEvictorMaxUnavailable(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	node := test.BuildTestNode("node1", 1000, 2000, 13, nil)
+	ownerRefs := []metav1.OwnerReference{
+		{Kind: "ReplicaSet", APIVersion: "apps/v1", Name: "rs", UID: "rs-uid", Controller: utilptr.To(true)},
+	}
+	buildPod := func(name string, ready bool) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node.Name, func(pod *v1.Pod) {
+			pod.UID = uuid.NewUUID()
+			pod.OwnerReferences = ownerRefs
+			status := v1.ConditionFalse
+			if ready {
+				status = v1.ConditionTrue
+			}
+			pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodReady, Status: status}}
+		})
+	}
+
+	tests := []struct {
+		description    string
+		pods           []*v1.Pod
+		maxUnavailable intstr.IntOrString
+		evicted        int
+		result         bool
+	}{
+		{
+			description:    "all replicas available",
+			pods:           []*v1.Pod{buildPod("p1", true), buildPod("p2", true), buildPod("p3", true)},
+			maxUnavailable: intstr.FromInt32(1),
+			result:         true,
+		},
+		{
+			description:    "not ready replica counts as unavailable",
+			pods:           []*v1.Pod{buildPod("p1", true), buildPod("p2", false), buildPod("p3", true)},
+			maxUnavailable: intstr.FromInt32(1),
+			result:         false,
+		},
+		{
+			description:    "evicting a not ready replica is always allowed",
+			pods:           []*v1.Pod{buildPod("p1", false), buildPod("p2", false), buildPod("p3", true)},
+			maxUnavailable: intstr.FromInt32(1),
+			result:         true,
+		},
+		{
+			description:    "percentage rounds up",
+			pods:           []*v1.Pod{buildPod("p1", true), buildPod("p2", true), buildPod("p3", true)},
+			maxUnavailable: intstr.FromString("10%"),
+			result:         true,
+		},
+		{
+			description:    "replicas evicted in the cycle count as unavailable",
+			pods:           []*v1.Pod{buildPod("p1", true), buildPod("p2", true), buildPod("p3", true), buildPod("p4", true)},
+			maxUnavailable: intstr.FromString("50%"),
+			evicted:        1,
+			result:         true,
+		},
+		{
+			description:    "budget exhausted by evictions in the cycle",
+			pods:           []*v1.Pod{buildPod("p1", true), buildPod("p2", true), buildPod("p3", true), buildPod("p4", true)},
+			maxUnavailable: intstr.FromString("50%"),
+			evicted:        2,
+			result:         false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			objs := []runtime.Object{node}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				PodEvictorImpl:                evictions.NewPodEvictor(evictions.NewRecordOnlyBackend(nil, "v1"), nil, nil, []*v1.Node{node}, false, &events.FakeRecorder{}),
+			}
+			evictorPlugin, err := New(&DefaultEvictorArgs{MaxUnavailable: &tc.maxUnavailable}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			for i := 0; i < tc.evicted; i++ {
+				if !handle.Evict(ctx, tc.pods[len(tc.pods)-1-i], evictions.EvictOptions{}) {
+					t.Fatalf("Unable to evict %v", tc.pods[len(tc.pods)-1-i].Name)
+				}
+			}
+
+			result := evictorPlugin.(frameworktypes.EvictorPlugin).Filter(tc.pods[0]) &&
+				evictorPlugin.(frameworktypes.EvictorPlugin).PreEvictionFilter(tc.pods[0])
+			if result != tc.result {
+				t.Errorf("Expected pod %s to be evictable: %t, got %t", tc.pods[0].Name, tc.result, result)
+			}
+		})
+	}
+}
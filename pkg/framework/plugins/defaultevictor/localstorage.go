@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// evictableVolumesAnnotationKey lists the names of the local storage volumes of a pod
+// which are safe to lose, separated by commas.
+const evictableVolumesAnnotationKey = "descheduler.alpha.kubernetes.io/evictable-volumes"
+
+// protectedLocalStorage returns the first volume of the pod holding local data which is
+// not safe to lose. hostPath volumes are always protected, emptyDir volumes unless listed
+// in the evictable-volumes annotation, memory-backed or limited to less than maxSizeLimit,
+// as configured.
+func protectedLocalStorage(pod *v1.Pod, evictMemoryBacked bool, maxSizeLimit *resource.Quantity) (string, bool) {
+	evictable := sets.New[string]()
+	if value, ok := pod.Annotations[evictableVolumesAnnotationKey]; ok {
+		for _, name := range strings.Split(value, ",") {
+			evictable.Insert(strings.TrimSpace(name))
+		}
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil {
+			return fmt.Sprintf("hostPath volume %s", volume.Name), true
+		}
+		if volume.EmptyDir == nil || evictable.Has(volume.Name) {
+			continue
+		}
+		if volume.EmptyDir.Medium == v1.StorageMediumMemory && evictMemoryBacked {
+			continue
+		}
+		if maxSizeLimit != nil && volume.EmptyDir.SizeLimit != nil && volume.EmptyDir.SizeLimit.Cmp(*maxSizeLimit) < 0 {
+			continue
+		}
+		return fmt.Sprintf("emptyDir volume %s", volume.Name), true
+	}
+	return "", false
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestProtectedLocalStorage(t *testing.T) {
+	emptyDir := func(name string, medium v1.StorageMedium, sizeLimit string) v1.Volume {
+		source := &v1.EmptyDirVolumeSource{Medium: medium}
+		if sizeLimit != "" {
+			source.SizeLimit = utilptr.To(resource.MustParse(sizeLimit))
+		}
+		return v1.Volume{Name: name, VolumeSource: v1.VolumeSource{EmptyDir: source}}
+	}
+	hostPath := v1.Volume{Name: "host", VolumeSource: v1.VolumeSource{HostPath: &v1.HostPathVolumeSource{Path: "/var/lib/data"}}}
+	configMap := v1.Volume{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}}
+
+	tests := []struct {
+		description       string
+		volumes           []v1.Volume
+		annotation        string
+		evictMemoryBacked bool
+		maxSizeLimit      string
+		protected         bool
+	}{
+		{
+			description: "no local storage",
+			volumes:     []v1.Volume{configMap},
+			protected:   false,
+		},
+		{
+			description: "emptyDir protected by default",
+			volumes:     []v1.Volume{emptyDir("scratch", "", "64Mi")},
+			protected:   true,
+		},
+		{
+			description:       "memory-backed emptyDir",
+			volumes:           []v1.Volume{emptyDir("scratch", v1.StorageMediumMemory, "")},
+			evictMemoryBacked: true,
+			protected:         false,
+		},
+		{
+			description:  "memory-backed emptyDir protected unless enabled",
+			volumes:      []v1.Volume{emptyDir("scratch", v1.StorageMediumMemory, "")},
+			maxSizeLimit: "1Gi",
+			protected:    true,
+		},
+		{
+			description:  "emptyDir with a small sizeLimit",
+			volumes:      []v1.Volume{emptyDir("scratch", "", "64Mi"), configMap},
+			maxSizeLimit: "128Mi",
+			protected:    false,
+		},
+		{
+			description:  "emptyDir with a sizeLimit at the threshold",
+			volumes:      []v1.Volume{emptyDir("scratch", "", "128Mi")},
+			maxSizeLimit: "128Mi",
+			protected:    true,
+		},
+		{
+			description:  "emptyDir with a large sizeLimit",
+			volumes:      []v1.Volume{emptyDir("scratch", "", "64Mi"), emptyDir("data", "", "10Gi")},
+			maxSizeLimit: "128Mi",
+			protected:    true,
+		},
+		{
+			description:  "emptyDir without sizeLimit",
+			volumes:      []v1.Volume{emptyDir("data", "", "")},
+			maxSizeLimit: "128Mi",
+			protected:    true,
+		},
+		{
+			description:       "hostPath stays protected",
+			volumes:           []v1.Volume{emptyDir("scratch", v1.StorageMediumMemory, ""), hostPath},
+			evictMemoryBacked: true,
+			maxSizeLimit:      "128Mi",
+			protected:         true,
+		},
+		{
+			description: "emptyDir volumes listed in the annotation",
+			volumes:     []v1.Volume{emptyDir("data", "", ""), emptyDir("cache", "", "")},
+			annotation:  "data, cache",
+			protected:   false,
+		},
+		{
+			description: "hostPath listed in the annotation stays protected",
+			volumes:     []v1.Volume{emptyDir("data", "", ""), hostPath},
+			annotation:  "data, host",
+			protected:   true,
+		},
+		{
+			description: "volumes not listed in the annotation",
+			volumes:     []v1.Volume{emptyDir("cache", "", ""), emptyDir("data", "", "")},
+			annotation:  "cache",
+			protected:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+				pod.Spec.Volumes = tc.volumes
+				if tc.annotation != "" {
+					pod.Annotations = map[string]string{evictableVolumesAnnotationKey: tc.annotation}
+				}
+			})
+			var maxSizeLimit *resource.Quantity
+			if tc.maxSizeLimit != "" {
+				maxSizeLimit = utilptr.To(resource.MustParse(tc.maxSizeLimit))
+			}
+			if volume, protected := protectedLocalStorage(pod, tc.evictMemoryBacked, maxSizeLimit); protected != tc.protected {
+				t.Errorf("Expected protected: %t, got %t (%s)", tc.protected, protected, volume)
+			}
+		})
+	}
+}
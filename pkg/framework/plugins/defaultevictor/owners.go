@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+// owner is a workload in the ownership chain of a pod
+type owner struct {
+	kind string
+	name string
+}
+
+func (o owner) String() string {
+	return o.kind + "/" + o.name
+}
+
+// ownerFilter matches the owners of pods against the owner kind and name lists.
+// ReplicaSets are resolved to the Deployment owning them and Jobs to their CronJob,
+// so the lists can target both the direct owner and the top-level controller.
+type ownerFilter struct {
+	includeKinds     sets.Set[string]
+	excludeKinds     sets.Set[string]
+	includeNames     []string
+	excludeNames     []string
+	replicaSetLister appsv1listers.ReplicaSetLister
+	jobLister        batchv1listers.JobLister
+}
+
+// newOwnerFilter returns nil when none of the owner lists is set
+func newOwnerFilter(args *DefaultEvictorArgs, handle frameworktypes.Handle) *ownerFilter {
+	if len(args.IncludeOwnerKinds) == 0 && len(args.ExcludeOwnerKinds) == 0 &&
+		len(args.IncludeOwnerNames) == 0 && len(args.ExcludeOwnerNames) == 0 {
+		return nil
+	}
+	filter := &ownerFilter{
+		includeKinds: sets.New(args.IncludeOwnerKinds...),
+		excludeKinds: sets.New(args.ExcludeOwnerKinds...),
+		includeNames: args.IncludeOwnerNames,
+		excludeNames: args.ExcludeOwnerNames,
+	}
+	if informerFactory := handle.SharedInformerFactory(); informerFactory != nil {
+		filter.replicaSetLister = informerFactory.Apps().V1().ReplicaSets().Lister()
+		filter.jobLister = informerFactory.Batch().V1().Jobs().Lister()
+	}
+	return filter
+}
+
+// check returns an error describing why the owners of the pod do not pass the filter
+func (f *ownerFilter) check(pod *v1.Pod) error {
+	owners := f.owners(pod)
+	if f.includeKinds.Len() > 0 && !anyOwner(owners, func(o owner) bool { return f.includeKinds.Has(o.kind) }) {
+		return fmt.Errorf("pod is not owned by any of the includeOwnerKinds %v", sets.List(f.includeKinds))
+	}
+	for _, o := range owners {
+		if f.excludeKinds.Has(o.kind) {
+			return fmt.Errorf("pod owner %v is of an excluded kind", o)
+		}
+	}
+	if len(f.includeNames) > 0 && !anyOwner(owners, func(o owner) bool { return matchesOwnerName(f.includeNames, o) }) {
+		return fmt.Errorf("pod is not owned by any of the includeOwnerNames %v", f.includeNames)
+	}
+	for _, o := range owners {
+		if matchesOwnerName(f.excludeNames, o) {
+			return fmt.Errorf("pod owner %v is excluded by name", o)
+		}
+	}
+	return nil
+}
+
+// owners returns the owners of the pod followed by the Deployment or CronJob
+// controlling them. Owners missing from the informer caches are not resolved further.
+func (f *ownerFilter) owners(pod *v1.Pod) []owner {
+	var owners []owner
+	for _, ownerRef := range pod.OwnerReferences {
+		owners = append(owners, owner{kind: ownerRef.Kind, name: ownerRef.Name})
+		if ownerRef.Controller == nil || !*ownerRef.Controller {
+			continue
+		}
+		var controller *metav1.OwnerReference
+		switch {
+		case ownerRef.Kind == "ReplicaSet" && f.replicaSetLister != nil:
+			if rs, err := f.replicaSetLister.ReplicaSets(pod.Namespace).Get(ownerRef.Name); err == nil {
+				controller = metav1.GetControllerOfNoCopy(rs)
+			}
+		case ownerRef.Kind == "Job" && f.jobLister != nil:
+			if job, err := f.jobLister.Jobs(pod.Namespace).Get(ownerRef.Name); err == nil {
+				controller = metav1.GetControllerOfNoCopy(job)
+			}
+		}
+		if controller != nil && (controller.Kind == "Deployment" || controller.Kind == "CronJob") {
+			owners = append(owners, owner{kind: controller.Kind, name: controller.Name})
+		}
+	}
+	return owners
+}
+
+func anyOwner(owners []owner, match func(owner) bool) bool {
+	for _, o := range owners {
+		if match(o) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesOwnerName matches the owner against entries of the form "name" or "Kind/name",
+// names can be glob patterns.
+func matchesOwnerName(entries []string, o owner) bool {
+	for _, entry := range entries {
+		kind, name, found := strings.Cut(entry, "/")
+		if !found {
+			kind, name = "", entry
+		}
+		if kind != "" && kind != o.kind {
+			continue
+		}
+		if matched, _ := path.Match(name, o.name); matched {
+			return true
+		}
+	}
+	return false
+}
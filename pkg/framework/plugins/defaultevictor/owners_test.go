@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDefaultEvictorOwnerFilter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	withOwner := func(kind, name string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: name, Controller: utilptr.To(true)}}
+		}
+	}
+	deploymentPod := test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "web-5d8f"))
+	cronJobPod := test.BuildTestPod("p2", 100, 0, "node1", withOwner("Job", "backup-28405"))
+	statefulSetPod := test.BuildTestPod("p3", 100, 0, "node1", withOwner("StatefulSet", "db"))
+	replicaSetPod := test.BuildTestPod("p4", 100, 0, "node1", withOwner("ReplicaSet", "standalone"))
+	barePod := test.BuildTestPod("p5", 100, 0, "node1", nil)
+
+	replicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "web-5d8f",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: utilptr.To(true)}},
+	}}
+	standalone := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default"}}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name:            "backup-28405",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "backup", Controller: utilptr.To(true)}},
+	}}
+
+	tests := []struct {
+		description string
+		args        *DefaultEvictorArgs
+		evictable   []*v1.Pod
+	}{
+		{
+			description: "no owner filter",
+			args:        &DefaultEvictorArgs{EvictFailedBarePods: true},
+			evictable:   []*v1.Pod{deploymentPod, cronJobPod, statefulSetPod, replicaSetPod},
+		},
+		{
+			description: "include Deployment resolves ReplicaSets",
+			args:        &DefaultEvictorArgs{IncludeOwnerKinds: []string{"Deployment"}},
+			evictable:   []*v1.Pod{deploymentPod},
+		},
+		{
+			description: "include ReplicaSet matches the direct owner",
+			args:        &DefaultEvictorArgs{IncludeOwnerKinds: []string{"ReplicaSet"}},
+			evictable:   []*v1.Pod{deploymentPod, replicaSetPod},
+		},
+		{
+			description: "exclude StatefulSet and CronJob",
+			args:        &DefaultEvictorArgs{ExcludeOwnerKinds: []string{"StatefulSet", "CronJob"}},
+			evictable:   []*v1.Pod{deploymentPod, replicaSetPod},
+		},
+		{
+			description: "include owner names",
+			args:        &DefaultEvictorArgs{IncludeOwnerNames: []string{"Deployment/web", "backup"}},
+			evictable:   []*v1.Pod{deploymentPod, cronJobPod},
+		},
+		{
+			description: "exclude owner name patterns",
+			args:        &DefaultEvictorArgs{ExcludeOwnerNames: []string{"CronJob/back*", "d?"}},
+			evictable:   []*v1.Pod{deploymentPod, replicaSetPod},
+		},
+		{
+			description: "kind and name lists combined",
+			args:        &DefaultEvictorArgs{IncludeOwnerKinds: []string{"Deployment", "StatefulSet"}, ExcludeOwnerNames: []string{"web"}},
+			evictable:   []*v1.Pod{statefulSetPod},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(replicaSet, standalone, job)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+
+			evictorPlugin, err := New(tc.args, &frameworkfake.HandleImpl{
+				ClientsetImpl:             fakeClient,
+				SharedInformerFactoryImpl: sharedInformerFactory,
+			})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			evictable := map[string]bool{}
+			for _, pod := range tc.evictable {
+				evictable[pod.Name] = true
+			}
+			for _, pod := range []*v1.Pod{deploymentPod, cronJobPod, statefulSetPod, replicaSetPod, barePod} {
+				if result := evictorPlugin.(frameworktypes.EvictorPlugin).Filter(pod); result != evictable[pod.Name] {
+					t.Errorf("Expected pod %s to be evictable: %t, got %t", pod.Name, evictable[pod.Name], result)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"sigs.k8s.io/descheduler/metrics"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+// preventEvictionKey is the annotation (or namespace label) protecting pods from eviction.
+// The value is either "true", or a RFC3339 timestamp until which the pods are protected.
+// Label values can not hold a timestamp, so the namespace label is only "true" or "false".
+const preventEvictionKey = "descheduler.alpha.kubernetes.io/prevent-eviction"
+
+// Reasons for protecting a pod, reported in the pods_protected metric.
+const (
+	protectedByPodAnnotation       = "PodAnnotation"
+	protectedByOwnerAnnotation     = "OwnerAnnotation"
+	protectedByNamespaceLabel      = "NamespaceLabel"
+	protectedByNamespaceAnnotation = "NamespaceAnnotation"
+)
+
+// preventsEviction tells whether the value of the prevent-eviction annotation or label
+// protects from eviction at the given time. Values which can not be parsed protect the
+// pods so a mistyped timestamp does not silently allow evictions.
+func preventsEviction(value string, now time.Time) (bool, string) {
+	if value == "" {
+		return true, ""
+	}
+	if prevent, err := strconv.ParseBool(value); err == nil {
+		return prevent, ""
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return true, fmt.Sprintf(" (invalid value %q)", value)
+	}
+	return now.Before(until), fmt.Sprintf(" until %s", until.Format(time.RFC3339))
+}
+
+// protectionChecker looks up the prevent-eviction annotations and labels of pods,
+// their namespaces and optionally the workloads owning the pods. The evictor is
+// rebuilt every descheduling cycle, so each protected pod is counted once per cycle.
+type protectionChecker struct {
+	namespaceLister   corev1listers.NamespaceLister
+	replicaSetLister  appsv1listers.ReplicaSetLister
+	deploymentLister  appsv1listers.DeploymentLister
+	statefulSetLister appsv1listers.StatefulSetLister
+	daemonSetLister   appsv1listers.DaemonSetLister
+	jobLister         batchv1listers.JobLister
+	now               func() time.Time
+	// counted are the protected pods already reported in the pods_protected metric
+	counted sets.Set[types.UID]
+}
+
+func newProtectionChecker(handle frameworktypes.Handle, checkOwners bool) *protectionChecker {
+	informerFactory := handle.SharedInformerFactory()
+	checker := &protectionChecker{
+		namespaceLister: informerFactory.Core().V1().Namespaces().Lister(),
+		now:             time.Now,
+		counted:         sets.New[types.UID](),
+	}
+	if checkOwners {
+		checker.replicaSetLister = informerFactory.Apps().V1().ReplicaSets().Lister()
+		checker.deploymentLister = informerFactory.Apps().V1().Deployments().Lister()
+		checker.statefulSetLister = informerFactory.Apps().V1().StatefulSets().Lister()
+		checker.daemonSetLister = informerFactory.Apps().V1().DaemonSets().Lister()
+		checker.jobLister = informerFactory.Batch().V1().Jobs().Lister()
+	}
+	return checker
+}
+
+// check returns an error describing why the pod is protected from eviction
+func (c *protectionChecker) check(pod *v1.Pod) error {
+	now := c.now()
+	if value, ok := pod.Annotations[preventEvictionKey]; ok {
+		if prevent, until := preventsEviction(value, now); prevent {
+			return c.protected(pod, protectedByPodAnnotation, fmt.Sprintf("pod has the %s annotation%s", preventEvictionKey, until))
+		}
+	}
+
+	namespace, err := c.namespaceLister.Get(pod.Namespace)
+	if err == nil {
+		if value, ok := namespace.Labels[preventEvictionKey]; ok {
+			if prevent, until := preventsEviction(value, now); prevent {
+				return c.protected(pod, protectedByNamespaceLabel, fmt.Sprintf("namespace has the %s label%s", preventEvictionKey, until))
+			}
+		}
+		if value, ok := namespace.Annotations[preventEvictionKey]; ok {
+			if prevent, until := preventsEviction(value, now); prevent {
+				return c.protected(pod, protectedByNamespaceAnnotation, fmt.Sprintf("namespace has the %s annotation%s", preventEvictionKey, until))
+			}
+		}
+	}
+
+	if c.replicaSetLister == nil {
+		return nil
+	}
+	for _, owner := range c.owners(pod) {
+		if value, ok := owner.GetAnnotations()[preventEvictionKey]; ok {
+			if prevent, until := preventsEviction(value, now); prevent {
+				return c.protected(pod, protectedByOwnerAnnotation, fmt.Sprintf("owner %s has the %s annotation%s", owner.GetName(), preventEvictionKey, until))
+			}
+		}
+	}
+	return nil
+}
+
+// owners returns the workloads owning the pod, including the Deployment owning a ReplicaSet.
+// Owners missing from the informer caches are ignored.
+func (c *protectionChecker) owners(pod *v1.Pod) []metav1.Object {
+	ownerRef := metav1.GetControllerOfNoCopy(pod)
+	if ownerRef == nil {
+		return nil
+	}
+	var owners []metav1.Object
+	switch ownerRef.Kind {
+	case "ReplicaSet":
+		rs, err := c.replicaSetLister.ReplicaSets(pod.Namespace).Get(ownerRef.Name)
+		if err != nil {
+			return nil
+		}
+		owners = append(owners, rs)
+		if rsOwnerRef := metav1.GetControllerOfNoCopy(rs); rsOwnerRef != nil && rsOwnerRef.Kind == "Deployment" {
+			if deployment, err := c.deploymentLister.Deployments(pod.Namespace).Get(rsOwnerRef.Name); err == nil {
+				owners = append(owners, deployment)
+			}
+		}
+	case "StatefulSet":
+		if ss, err := c.statefulSetLister.StatefulSets(pod.Namespace).Get(ownerRef.Name); err == nil {
+			owners = append(owners, ss)
+		}
+	case "DaemonSet":
+		if ds, err := c.daemonSetLister.DaemonSets(pod.Namespace).Get(ownerRef.Name); err == nil {
+			owners = append(owners, ds)
+		}
+	case "Job":
+		if job, err := c.jobLister.Jobs(pod.Namespace).Get(ownerRef.Name); err == nil {
+			owners = append(owners, job)
+		}
+	}
+	return owners
+}
+
+func (c *protectionChecker) protected(pod *v1.Pod, reason, message string) error {
+	if !c.counted.Has(pod.UID) {
+		c.counted.Insert(pod.UID)
+		metrics.PodsProtected.With(map[string]string{"reason": reason, "namespace": pod.Namespace}).Inc()
+	}
+	return fmt.Errorf("pod is protected from eviction: %s", message)
+}
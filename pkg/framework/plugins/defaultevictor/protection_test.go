@@ -0,0 +1,259 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/component-base/metrics/testutil"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/metrics"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestPreventsEviction(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		prevent bool
+	}{
+		{value: "", prevent: true},
+		{value: "true", prevent: true},
+		{value: "false", prevent: false},
+		{value: "2024-01-02T00:00:00Z", prevent: true},
+		{value: "2023-12-31T00:00:00Z", prevent: false},
+		{value: "next week", prevent: true},
+	}
+	for _, tc := range tests {
+		if prevent, _ := preventsEviction(tc.value, now); prevent != tc.prevent {
+			t.Errorf("Expected %q to prevent eviction: %v, got %v", tc.value, tc.prevent, prevent)
+		}
+	}
+}
+
+func TestDefaultEvictorProtection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	expired := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	withNamespace := func(namespace string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Namespace = namespace
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "rs", Controller: utilptr.To(true)}}
+		}
+	}
+	withAnnotation := func(value string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			withNamespace("default")(pod)
+			pod.Annotations = map[string]string{preventEvictionKey: value}
+		}
+	}
+	buildNamespace := func(name string, labels, annotations map[string]string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations}}
+	}
+	buildReplicaSet := func(namespace string, annotations map[string]string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "rs",
+			Namespace:       namespace,
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "deployment", Controller: utilptr.To(true)}},
+		}}
+	}
+
+	tests := []struct {
+		description          string
+		pod                  *v1.Pod
+		objects              []runtime.Object
+		ownerPreventEviction bool
+		result               bool
+	}{
+		{
+			description: "pod without protection",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withNamespace("default")),
+			objects:     []runtime.Object{buildNamespace("default", nil, nil)},
+			result:      true,
+		},
+		{
+			description: "pod annotation",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withAnnotation("true")),
+			result:      false,
+		},
+		{
+			description: "pod annotation protected until the future",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withAnnotation(future)),
+			result:      false,
+		},
+		{
+			description: "pod annotation protection expired",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withAnnotation(expired)),
+			result:      true,
+		},
+		{
+			description: "pod annotation takes precedence over the evict annotation",
+			pod: test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+				withAnnotation("true")(pod)
+				pod.Annotations[evictPodAnnotationKey] = ""
+			}),
+			result: false,
+		},
+		{
+			description: "namespace label",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withNamespace("protected")),
+			objects:     []runtime.Object{buildNamespace("protected", map[string]string{preventEvictionKey: "true"}, nil)},
+			result:      false,
+		},
+		{
+			description: "namespace label disabling the protection",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withNamespace("protected")),
+			objects:     []runtime.Object{buildNamespace("protected", map[string]string{preventEvictionKey: "false"}, nil)},
+			result:      true,
+		},
+		{
+			// label values can not hold a RFC3339 timestamp, other values protect the pods
+			description: "namespace label with a date",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withNamespace("protected")),
+			objects:     []runtime.Object{buildNamespace("protected", map[string]string{preventEvictionKey: "2024-06-01"}, nil)},
+			result:      false,
+		},
+		{
+			description: "pod label is ignored",
+			pod: test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+				withNamespace("default")(pod)
+				pod.Labels = map[string]string{preventEvictionKey: "true"}
+			}),
+			objects: []runtime.Object{buildNamespace("default", nil, nil)},
+			result:  true,
+		},
+		{
+			description: "namespace annotation",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withNamespace("protected")),
+			objects:     []runtime.Object{buildNamespace("protected", nil, map[string]string{preventEvictionKey: future})},
+			result:      false,
+		},
+		{
+			description: "namespace annotation protection expired",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withNamespace("protected")),
+			objects:     []runtime.Object{buildNamespace("protected", nil, map[string]string{preventEvictionKey: expired})},
+			result:      true,
+		},
+		{
+			description:          "replicaset annotation",
+			pod:                  test.BuildTestPod("p1", 100, 0, "node1", withNamespace("default")),
+			objects:              []runtime.Object{buildReplicaSet("default", map[string]string{preventEvictionKey: "true"})},
+			ownerPreventEviction: true,
+			result:               false,
+		},
+		{
+			description: "deployment annotation",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withNamespace("default")),
+			objects: []runtime.Object{
+				buildReplicaSet("default", nil),
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "default", Annotations: map[string]string{preventEvictionKey: future}}},
+			},
+			ownerPreventEviction: true,
+			result:               false,
+		},
+		{
+			description: "owner annotation ignored unless enabled",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withNamespace("default")),
+			objects:     []runtime.Object{buildReplicaSet("default", map[string]string{preventEvictionKey: "true"})},
+			result:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(append(tc.objects, tc.pod)...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+
+			evictorPlugin, err := New(&DefaultEvictorArgs{OwnerPreventEviction: tc.ownerPreventEviction}, &frameworkfake.HandleImpl{
+				ClientsetImpl:             fakeClient,
+				SharedInformerFactoryImpl: sharedInformerFactory,
+			})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			if result := evictorPlugin.(frameworktypes.EvictorPlugin).Filter(tc.pod); result != tc.result {
+				t.Errorf("Expected pod %s to be evictable: %t, got %t", tc.pod.Name, tc.result, result)
+			}
+		})
+	}
+}
+
+func TestDefaultEvictorProtectedPodsCountedOnce(t *testing.T) {
+	metrics.Register()
+
+	p1 := test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+		pod.Namespace = "counted"
+		pod.UID = "p1"
+		pod.Annotations = map[string]string{preventEvictionKey: "true"}
+	})
+	p2 := test.BuildTestPod("p2", 100, 0, "node1", func(pod *v1.Pod) {
+		pod.Namespace = "counted"
+		pod.UID = "p2"
+		pod.Annotations = map[string]string{preventEvictionKey: "true"}
+	})
+	counter := metrics.PodsProtected.With(map[string]string{"reason": protectedByPodAnnotation, "namespace": "counted"})
+	count := func() float64 {
+		value, err := testutil.GetCounterMetricValue(counter)
+		if err != nil {
+			t.Fatalf("Unable to read the pods_protected metric: %v", err)
+		}
+		return value
+	}
+
+	newFilter := func() frameworktypes.EvictorPlugin {
+		fakeClient := fake.NewSimpleClientset(p1, p2)
+		evictorPlugin, err := New(&DefaultEvictorArgs{}, &frameworkfake.HandleImpl{
+			ClientsetImpl:             fakeClient,
+			SharedInformerFactoryImpl: informers.NewSharedInformerFactory(fakeClient, 0),
+		})
+		if err != nil {
+			t.Fatalf("Unable to initialize the plugin: %v", err)
+		}
+		return evictorPlugin.(frameworktypes.EvictorPlugin)
+	}
+
+	before := count()
+	// the evictor is rebuilt every descheduling cycle
+	for cycle := 1; cycle <= 2; cycle++ {
+		evictorPlugin := newFilter()
+		for _, pod := range []*v1.Pod{p1, p2, p1, p2} {
+			if evictorPlugin.Filter(pod) {
+				t.Errorf("Expected pod %s to be protected", pod.Name)
+			}
+		}
+		if counted := count() - before; counted != float64(2*cycle) {
+			t.Errorf("Expected %d protected pods counted after %d cycles, got %v", 2*cycle, cycle, counted)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+// stabilityChecker rejects the eviction of pods whose top-level owner is rolling
+// out or does not have all of its replicas available.
+type stabilityChecker struct {
+	replicaSetLister  appsv1listers.ReplicaSetLister
+	deploymentLister  appsv1listers.DeploymentLister
+	statefulSetLister appsv1listers.StatefulSetLister
+	daemonSetLister   appsv1listers.DaemonSetLister
+}
+
+func newStabilityChecker(handle frameworktypes.Handle) *stabilityChecker {
+	informerFactory := handle.SharedInformerFactory()
+	return &stabilityChecker{
+		replicaSetLister:  informerFactory.Apps().V1().ReplicaSets().Lister(),
+		deploymentLister:  informerFactory.Apps().V1().Deployments().Lister(),
+		statefulSetLister: informerFactory.Apps().V1().StatefulSets().Lister(),
+		daemonSetLister:   informerFactory.Apps().V1().DaemonSets().Lister(),
+	}
+}
+
+// ownerStatus is the rollout status common to the workload kinds
+type ownerStatus struct {
+	generation         int64
+	observedGeneration int64
+	desired            int32
+	updated            int32
+	available          int32
+}
+
+// check returns an error describing why the owner of the pod is not stable.
+// Owners missing from the informer caches are not checked.
+func (c *stabilityChecker) check(pod *v1.Pod) error {
+	ownerRef := metav1.GetControllerOfNoCopy(pod)
+	if ownerRef == nil {
+		return nil
+	}
+	kind, name := ownerRef.Kind, ownerRef.Name
+	var status *ownerStatus
+	switch kind {
+	case "ReplicaSet":
+		rs, err := c.replicaSetLister.ReplicaSets(pod.Namespace).Get(name)
+		if err != nil {
+			return nil
+		}
+		if rsOwnerRef := metav1.GetControllerOfNoCopy(rs); rsOwnerRef != nil && rsOwnerRef.Kind == "Deployment" {
+			kind, name = rsOwnerRef.Kind, rsOwnerRef.Name
+			deployment, err := c.deploymentLister.Deployments(pod.Namespace).Get(name)
+			if err != nil {
+				return nil
+			}
+			status = &ownerStatus{
+				generation:         deployment.Generation,
+				observedGeneration: deployment.Status.ObservedGeneration,
+				desired:            replicas(deployment.Spec.Replicas),
+				updated:            deployment.Status.UpdatedReplicas,
+				available:          deployment.Status.AvailableReplicas,
+			}
+		} else {
+			// a ReplicaSet has a single revision, all of its replicas are up to date
+			status = &ownerStatus{
+				generation:         rs.Generation,
+				observedGeneration: rs.Status.ObservedGeneration,
+				desired:            replicas(rs.Spec.Replicas),
+				updated:            replicas(rs.Spec.Replicas),
+				available:          rs.Status.AvailableReplicas,
+			}
+		}
+	case "StatefulSet":
+		ss, err := c.statefulSetLister.StatefulSets(pod.Namespace).Get(name)
+		if err != nil {
+			return nil
+		}
+		status = &ownerStatus{
+			generation:         ss.Generation,
+			observedGeneration: ss.Status.ObservedGeneration,
+			desired:            replicas(ss.Spec.Replicas),
+			updated:            ss.Status.UpdatedReplicas,
+			available:          ss.Status.AvailableReplicas,
+		}
+	case "DaemonSet":
+		ds, err := c.daemonSetLister.DaemonSets(pod.Namespace).Get(name)
+		if err != nil {
+			return nil
+		}
+		status = &ownerStatus{
+			generation:         ds.Generation,
+			observedGeneration: ds.Status.ObservedGeneration,
+			desired:            ds.Status.DesiredNumberScheduled,
+			updated:            ds.Status.UpdatedNumberScheduled,
+			available:          ds.Status.NumberAvailable,
+		}
+	default:
+		return nil
+	}
+
+	switch {
+	case status.observedGeneration < status.generation:
+		return fmt.Errorf("owner %s/%s has not observed its latest generation %d yet", kind, name, status.generation)
+	case status.updated < status.desired:
+		return fmt.Errorf("owner %s/%s is rolling out, %d of %d replicas updated", kind, name, status.updated, status.desired)
+	case status.available < status.desired:
+		return fmt.Errorf("owner %s/%s is degraded, %d of %d replicas available", kind, name, status.available, status.desired)
+	}
+	return nil
+}
+
+// replicas returns the desired number of replicas, which defaults to 1
+func replicas(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
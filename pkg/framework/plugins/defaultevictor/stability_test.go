@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	utilptr "k8s.io/utils/ptr"
+
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestDefaultEvictorIgnoreUnstableOwners(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	withOwner := func(kind, name string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: name, Controller: utilptr.To(true)}}
+		}
+	}
+	deploymentReplicaSet := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "web-1",
+		Namespace:       "default",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: utilptr.To(true)}},
+	}}
+	buildDeployment := func(generation, observedGeneration int64, updated, available int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: utilptr.To[int32](3)},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: observedGeneration,
+				UpdatedReplicas:    updated,
+				AvailableReplicas:  available,
+			},
+		}
+	}
+	buildStatefulSet := func(updated, available int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Generation: 1},
+			Spec:       appsv1.StatefulSetSpec{Replicas: utilptr.To[int32](3)},
+			Status:     appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: updated, AvailableReplicas: available},
+		}
+	}
+	deploymentPod := test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "web-1"))
+	statefulSetPod := test.BuildTestPod("p1", 100, 0, "node1", withOwner("StatefulSet", "db"))
+
+	tests := []struct {
+		description string
+		pod         *v1.Pod
+		objects     []runtime.Object
+		result      bool
+	}{
+		{
+			description: "stable deployment",
+			pod:         deploymentPod,
+			objects:     []runtime.Object{deploymentReplicaSet, buildDeployment(2, 2, 3, 3)},
+			result:      true,
+		},
+		{
+			description: "deployment generation not observed yet",
+			pod:         deploymentPod,
+			objects:     []runtime.Object{deploymentReplicaSet, buildDeployment(3, 2, 3, 3)},
+			result:      false,
+		},
+		{
+			description: "deployment rolling out",
+			pod:         deploymentPod,
+			objects:     []runtime.Object{deploymentReplicaSet, buildDeployment(2, 2, 1, 3)},
+			result:      false,
+		},
+		{
+			description: "deployment degraded",
+			pod:         deploymentPod,
+			objects:     []runtime.Object{deploymentReplicaSet, buildDeployment(2, 2, 3, 2)},
+			result:      false,
+		},
+		{
+			description: "replicaset without deployment degraded",
+			pod:         test.BuildTestPod("p1", 100, 0, "node1", withOwner("ReplicaSet", "standalone")),
+			objects: []runtime.Object{&appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default"},
+				Spec:       appsv1.ReplicaSetSpec{Replicas: utilptr.To[int32](2)},
+				Status:     appsv1.ReplicaSetStatus{AvailableReplicas: 1},
+			}},
+			result: false,
+		},
+		{
+			description: "stable statefulset",
+			pod:         statefulSetPod,
+			objects:     []runtime.Object{buildStatefulSet(3, 3)},
+			result:      true,
+		},
+		{
+			description: "statefulset rolling out",
+			pod:         statefulSetPod,
+			objects:     []runtime.Object{buildStatefulSet(2, 3)},
+			result:      false,
+		},
+		{
+			description: "owner missing from the cache",
+			pod:         deploymentPod,
+			result:      true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			fakeClient := fake.NewSimpleClientset(append(tc.objects, tc.pod)...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+
+			evictorPlugin, err := New(&DefaultEvictorArgs{IgnoreUnstableOwners: true}, &frameworkfake.HandleImpl{
+				ClientsetImpl:             fakeClient,
+				SharedInformerFactoryImpl: sharedInformerFactory,
+			})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			if result := evictorPlugin.(frameworktypes.EvictorPlugin).Filter(tc.pod); result != tc.result {
+				t.Errorf("Expected pod %s to be evictable: %t, got %t", tc.pod.Name, tc.result, result)
+			}
+		})
+	}
+}
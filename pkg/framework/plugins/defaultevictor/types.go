@@ -14,7 +14,9 @@ limitations under the License.
 package defaultevictor
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/descheduler/pkg/api"
 )
 
@@ -25,13 +27,26 @@ import (
 type DefaultEvictorArgs struct {
 	metav1.TypeMeta `json:",inline"`
 
-	NodeSelector            string                 `json:"nodeSelector"`
-	EvictLocalStoragePods   bool                   `json:"evictLocalStoragePods"`
-	EvictSystemCriticalPods bool                   `json:"evictSystemCriticalPods"`
-	IgnorePvcPods           bool                   `json:"ignorePvcPods"`
-	EvictFailedBarePods     bool                   `json:"evictFailedBarePods"`
-	LabelSelector           *metav1.LabelSelector  `json:"labelSelector"`
-	PriorityThreshold       *api.PriorityThreshold `json:"priorityThreshold"`
-	NodeFit                 bool                   `json:"nodeFit"`
-	MinReplicas             uint                   `json:"minReplicas"`
+	NodeSelector               string                 `json:"nodeSelector"`
+	EvictLocalStoragePods      bool                   `json:"evictLocalStoragePods"`
+	EvictSystemCriticalPods    bool                   `json:"evictSystemCriticalPods"`
+	IgnorePvcPods              bool                   `json:"ignorePvcPods"`
+	EvictFailedBarePods        bool                   `json:"evictFailedBarePods"`
+	LabelSelector              *metav1.LabelSelector  `json:"labelSelector"`
+	PriorityThreshold          *api.PriorityThreshold `json:"priorityThreshold"`
+	NodeFit                    bool                   `json:"nodeFit"`
+	MinReplicas                uint                   `json:"minReplicas"`
+	MaxUnavailable             *intstr.IntOrString    `json:"maxUnavailable"`
+	OwnerPreventEviction       bool                   `json:"ownerPreventEviction"`
+	NodeFitPredicates          map[string]bool        `json:"nodeFitPredicates"`
+	IncludeOwnerKinds          []string               `json:"includeOwnerKinds"`
+	ExcludeOwnerKinds          []string               `json:"excludeOwnerKinds"`
+	IncludeOwnerNames          []string               `json:"includeOwnerNames"`
+	ExcludeOwnerNames          []string               `json:"excludeOwnerNames"`
+	MinPodAgeSeconds           *uint                  `json:"minPodAgeSeconds"`
+	MinPodAgeFrom              string                 `json:"minPodAgeFrom"`
+	MinOwnerRevisionAgeSeconds *uint                  `json:"minOwnerRevisionAgeSeconds"`
+	IgnoreUnstableOwners       bool                   `json:"ignoreUnstableOwners"`
+	EvictMemoryBackedEmptyDirs bool                   `json:"evictMemoryBackedEmptyDirs"`
+	EvictEmptyDirSizeLimit     *resource.Quantity     `json:"evictEmptyDirSizeLimit"`
 }
@@ -15,10 +15,16 @@ package defaultevictor
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
 	"k8s.io/klog/v2"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 )
 
 func ValidateDefaultEvictorArgs(obj runtime.Object) error {
@@ -28,6 +34,53 @@ func ValidateDefaultEvictorArgs(obj runtime.Object) error {
 		return fmt.Errorf("priority threshold misconfigured, only one of priorityThreshold fields can be set, got %v", args)
 	}
 
+	if args.MaxUnavailable != nil {
+		if _, err := intstr.GetScaledValueFromIntOrPercent(args.MaxUnavailable, 100, true); err != nil {
+			return fmt.Errorf("invalid maxUnavailable: %v", err)
+		}
+		if args.MaxUnavailable.Type == intstr.Int && args.MaxUnavailable.IntVal < 0 {
+			return fmt.Errorf("maxUnavailable must not be negative, got %v", args.MaxUnavailable.IntVal)
+		}
+	}
+
+	for name := range args.NodeFitPredicates {
+		if !nodeutil.AllNodeFitPredicates.Has(nodeutil.NodeFitPredicate(name)) {
+			return fmt.Errorf("unknown nodeFitPredicates predicate %q, must be one of %v", name, sets.List(nodeutil.AllNodeFitPredicates))
+		}
+	}
+
+	if len(args.IncludeOwnerKinds) > 0 && len(args.ExcludeOwnerKinds) > 0 {
+		return fmt.Errorf("only one of includeOwnerKinds and excludeOwnerKinds can be set")
+	}
+	if len(args.IncludeOwnerNames) > 0 && len(args.ExcludeOwnerNames) > 0 {
+		return fmt.Errorf("only one of includeOwnerNames and excludeOwnerNames can be set")
+	}
+	for _, kind := range append(append([]string{}, args.IncludeOwnerKinds...), args.ExcludeOwnerKinds...) {
+		if kind == "" {
+			return fmt.Errorf("owner kinds must not be empty")
+		}
+	}
+	for _, entry := range append(append([]string{}, args.IncludeOwnerNames...), args.ExcludeOwnerNames...) {
+		kind, name, found := strings.Cut(entry, "/")
+		if !found {
+			name = entry
+		}
+		if name == "" || (found && kind == "") {
+			return fmt.Errorf("invalid owner name %q, must be of the form name or Kind/name", entry)
+		}
+		if _, err := path.Match(name, ""); err != nil {
+			return fmt.Errorf("invalid owner name pattern %q: %v", entry, err)
+		}
+	}
+
+	if args.MinPodAgeFrom != "" && args.MinPodAgeFrom != PodAgeFromStartTime && args.MinPodAgeFrom != PodAgeFromReady {
+		return fmt.Errorf("minPodAgeFrom must be one of %q or %q, got %q", PodAgeFromStartTime, PodAgeFromReady, args.MinPodAgeFrom)
+	}
+
+	if args.EvictEmptyDirSizeLimit != nil && args.EvictEmptyDirSizeLimit.Sign() < 0 {
+		return fmt.Errorf("evictEmptyDirSizeLimit must not be negative, got %v", args.EvictEmptyDirSizeLimit.String())
+	}
+
 	if args.MinReplicas == 1 {
 		klog.V(4).Info("DefaultEvictor minReplicas must be greater than 1 to check for min pods during eviction. This check will be ignored during eviction.")
 	}
@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultevictor
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestValidateDefaultEvictorArgs(t *testing.T) {
+	tests := []struct {
+		description string
+		args        *DefaultEvictorArgs
+		expectErr   bool
+	}{
+		{
+			description: "empty args",
+			args:        &DefaultEvictorArgs{},
+		},
+		{
+			description: "maxUnavailable as number",
+			args:        &DefaultEvictorArgs{MaxUnavailable: utilptr.To(intstr.FromInt32(2))},
+		},
+		{
+			description: "maxUnavailable as percentage",
+			args:        &DefaultEvictorArgs{MaxUnavailable: utilptr.To(intstr.FromString("25%"))},
+		},
+		{
+			description: "negative maxUnavailable",
+			args:        &DefaultEvictorArgs{MaxUnavailable: utilptr.To(intstr.FromInt32(-1))},
+			expectErr:   true,
+		},
+		{
+			description: "invalid maxUnavailable percentage",
+			args:        &DefaultEvictorArgs{MaxUnavailable: utilptr.To(intstr.FromString("many"))},
+			expectErr:   true,
+		},
+		{
+			description: "known nodeFit predicates",
+			args:        &DefaultEvictorArgs{NodeFitPredicates: map[string]bool{"PodTopologySpread": true, "MaxPods": false}},
+		},
+		{
+			description: "unknown nodeFit predicate",
+			args:        &DefaultEvictorArgs{NodeFitPredicates: map[string]bool{"PodFitsHost": true}},
+			expectErr:   true,
+		},
+		{
+			description: "minPodAge from Ready",
+			args:        &DefaultEvictorArgs{MinPodAgeSeconds: utilptr.To[uint](600), MinPodAgeFrom: "Ready"},
+		},
+		{
+			description: "unknown minPodAgeFrom",
+			args:        &DefaultEvictorArgs{MinPodAgeSeconds: utilptr.To[uint](600), MinPodAgeFrom: "Scheduled"},
+			expectErr:   true,
+		},
+		{
+			description: "owner kinds and names",
+			args:        &DefaultEvictorArgs{ExcludeOwnerKinds: []string{"StatefulSet"}, IncludeOwnerNames: []string{"Deployment/web-*", "api"}},
+		},
+		{
+			description: "both include and exclude owner kinds",
+			args:        &DefaultEvictorArgs{IncludeOwnerKinds: []string{"Deployment"}, ExcludeOwnerKinds: []string{"StatefulSet"}},
+			expectErr:   true,
+		},
+		{
+			description: "owner name without kind",
+			args:        &DefaultEvictorArgs{ExcludeOwnerNames: []string{"/web"}},
+			expectErr:   true,
+		},
+		{
+			description: "invalid owner name pattern",
+			args:        &DefaultEvictorArgs{ExcludeOwnerNames: []string{"Deployment/web-["}},
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateDefaultEvictorArgs(tc.args)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("Expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
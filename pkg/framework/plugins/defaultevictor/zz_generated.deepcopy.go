@@ -24,6 +24,7 @@ package defaultevictor
 import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 	api "sigs.k8s.io/descheduler/pkg/api"
 )
 
@@ -41,6 +42,53 @@ func (in *DefaultEvictorArgs) DeepCopyInto(out *DefaultEvictorArgs) {
 		*out = new(api.PriorityThreshold)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.NodeFitPredicates != nil {
+		in, out := &in.NodeFitPredicates, &out.NodeFitPredicates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IncludeOwnerKinds != nil {
+		in, out := &in.IncludeOwnerKinds, &out.IncludeOwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeOwnerKinds != nil {
+		in, out := &in.ExcludeOwnerKinds, &out.ExcludeOwnerKinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeOwnerNames != nil {
+		in, out := &in.IncludeOwnerNames, &out.IncludeOwnerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeOwnerNames != nil {
+		in, out := &in.ExcludeOwnerNames, &out.ExcludeOwnerNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinPodAgeSeconds != nil {
+		in, out := &in.MinPodAgeSeconds, &out.MinPodAgeSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.MinOwnerRevisionAgeSeconds != nil {
+		in, out := &in.MinOwnerRevisionAgeSeconds, &out.MinOwnerRevisionAgeSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.EvictEmptyDirSizeLimit != nil {
+		in, out := &in.EvictEmptyDirSizeLimit, &out.EvictEmptyDirSizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extendedresourcedefragmentation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestSetDefaults_ExtendedResourceDefragmentationArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *ExtendedResourceDefragmentationArgs
+		want *ExtendedResourceDefragmentationArgs
+	}{
+		{
+			name: "ExtendedResourceDefragmentationArgs empty",
+			in:   &ExtendedResourceDefragmentationArgs{},
+			want: &ExtendedResourceDefragmentationArgs{},
+		},
+		{
+			name: "ExtendedResourceDefragmentationArgs with value",
+			in: &ExtendedResourceDefragmentationArgs{
+				Namespaces:    &api.Namespaces{Exclude: []string{"kube-system"}},
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "training"}},
+				ResourceNames: []v1.ResourceName{"nvidia.com/gpu"},
+				BlockSize:     utilptr.To[uint](8),
+			},
+			want: &ExtendedResourceDefragmentationArgs{
+				Namespaces:    &api.Namespaces{Exclude: []string{"kube-system"}},
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "training"}},
+				ResourceNames: []v1.ResourceName{"nvidia.com/gpu"},
+				BlockSize:     utilptr.To[uint](8),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_ExtendedResourceDefragmentationArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
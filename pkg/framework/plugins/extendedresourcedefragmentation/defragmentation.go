@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extendedresourcedefragmentation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "ExtendedResourceDefragmentation"
+
+// ExtendedResourceDefragmentation consolidates the consumers of extended resources so that
+// nodes get whole blocks of free units instead of a few units scattered over many nodes.
+type ExtendedResourceDefragmentation struct {
+	handle    frameworktypes.Handle
+	args      *ExtendedResourceDefragmentationArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.BalancePlugin = &ExtendedResourceDefragmentation{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	defragmentationArgs, ok := args.(*ExtendedResourceDefragmentationArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type ExtendedResourceDefragmentationArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		WithNamespaceFilter(defragmentationArgs.Namespaces, handle.SharedInformerFactory()).
+		WithLabelSelector(defragmentationArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &ExtendedResourceDefragmentation{
+		handle:    handle,
+		args:      defragmentationArgs,
+		podFilter: podFilter,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *ExtendedResourceDefragmentation) Name() string {
+	return PluginName
+}
+
+// nodeUsage holds the usage of a single extended resource on a node
+type nodeUsage struct {
+	node        *v1.Node
+	allocatable int64
+	used        int64
+	// consumers are the pods requesting the resource
+	consumers []*v1.Pod
+}
+
+func (u *nodeUsage) free() int64 {
+	return u.allocatable - u.used
+}
+
+// move describes the eviction of a consumer expected to be rescheduled onto the destination
+type move struct {
+	pod         *v1.Pod
+	request     int64
+	destination *nodeUsage
+}
+
+// Balance extension point implementation for the plugin
+func (d *ExtendedResourceDefragmentation) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	if snapshot := d.handle.NodeSnapshot(); snapshot != nil {
+		getPodsAssignedToNode = snapshot.GetPodsAssignedToNode
+	}
+	for _, resourceName := range d.args.ResourceNames {
+		if err := d.defragment(ctx, resourceName, nodes, getPodsAssignedToNode); err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error defragmenting %v: %v", resourceName, err),
+			}
+		}
+	}
+	return nil
+}
+
+// defragment frees up blocks of the resource on the least used nodes by moving their
+// consumers to nodes which do not have a free block. A node is only drained when all
+// the moves needed for a free block can be made, so that the evictions never leave the
+// cluster more fragmented than it was.
+func (d *ExtendedResourceDefragmentation) defragment(ctx context.Context, resourceName v1.ResourceName, nodes []*v1.Node, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) error {
+	var usages []*nodeUsage
+	for _, node := range nodes {
+		allocatable, ok := node.Status.Allocatable[resourceName]
+		if !ok || allocatable.Value() == 0 {
+			continue
+		}
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
+		if err != nil {
+			return fmt.Errorf("error listing pods on a node: %v", err)
+		}
+		usage := &nodeUsage{
+			node:        node,
+			allocatable: allocatable.Value(),
+			used:        nodeutil.NodeUtilization(pods, []v1.ResourceName{resourceName})[resourceName].Value(),
+		}
+		for _, pod := range pods {
+			if podRequest(pod, resourceName) > 0 {
+				usage.consumers = append(usage.consumers, pod)
+			}
+		}
+		usages = append(usages, usage)
+	}
+	if len(usages) < 2 {
+		klog.V(1).InfoS("Not enough nodes with the resource to defragment", "resource", resourceName)
+		return nil
+	}
+
+	// drain the least used nodes first
+	sort.SliceStable(usages, func(i, j int) bool {
+		return usages[i].used < usages[j].used
+	})
+
+	// nodes receiving pods are never drained and drained nodes never receive pods
+	destinations := map[*nodeUsage]bool{}
+	drained := map[*nodeUsage]bool{}
+	for _, source := range usages {
+		if source.used == 0 || destinations[source] || d.blockSize(source) > source.allocatable || d.hasBlock(source) {
+			continue
+		}
+		moves := d.planMoves(source, usages, nodes, resourceName, drained, getPodsAssignedToNode)
+		if moves == nil {
+			klog.V(2).InfoS("Unable to free up a block of the resource on node", "node", klog.KObj(source.node), "resource", resourceName)
+			continue
+		}
+		pods := make([]*v1.Pod, 0, len(moves))
+		for _, m := range moves {
+			pods = append(pods, m.pod)
+		}
+		// a partially drained node would only add to the fragmentation
+		if !d.handle.Evictor().EvictionsAllowed(pods) {
+			klog.V(2).InfoS("Eviction limits do not allow freeing up a block of the resource on node", "node", klog.KObj(source.node), "resource", resourceName, "evictions", len(moves))
+			continue
+		}
+
+		klog.V(1).InfoS("Freeing up a block of the resource on node", "node", klog.KObj(source.node), "resource", resourceName, "evictions", len(moves))
+		drained[source] = true
+		for _, m := range moves {
+			if !d.handle.Evictor().Evict(ctx, m.pod, evictions.EvictOptions{StrategyName: PluginName}) {
+				break
+			}
+			source.used -= m.request
+			m.destination.used += m.request
+			destinations[m.destination] = true
+		}
+	}
+	return nil
+}
+
+// planMoves picks the consumers to evict from the source node, the largest first, so that
+// the node gets a free block, each of them fitting another node without a free block, the
+// fullest first. It returns nil when no such moves exist.
+func (d *ExtendedResourceDefragmentation) planMoves(source *nodeUsage, usages []*nodeUsage, nodes []*v1.Node, resourceName v1.ResourceName, drained map[*nodeUsage]bool, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) []move {
+	need := d.blockSize(source) - source.free()
+
+	consumers := append([]*v1.Pod{}, source.consumers...)
+	sort.SliceStable(consumers, func(i, j int) bool {
+		return podRequest(consumers[i], resourceName) > podRequest(consumers[j], resourceName)
+	})
+
+	// the units taken on the destinations by the planned moves
+	planned := map[*nodeUsage]int64{}
+	var moves []move
+	for _, pod := range consumers {
+		if need <= 0 {
+			break
+		}
+		if !d.podFilter(pod) || !d.handle.Evictor().PreEvictionFilter(pod) {
+			continue
+		}
+		request := podRequest(pod, resourceName)
+		var destination *nodeUsage
+		for _, candidate := range usages {
+			if candidate == source || drained[candidate] {
+				continue
+			}
+			free := candidate.free() - planned[candidate]
+			// taking units from a free block would only move the fragmentation around
+			if free < request || free >= d.blockSize(candidate) {
+				continue
+			}
+			if destination != nil && destination.free()-planned[destination] <= free {
+				continue
+			}
+			if len(nodeutil.NodeFit(getPodsAssignedToNode, pod, candidate.node, nodeutil.WithNodes(nodes))) != 0 {
+				continue
+			}
+			destination = candidate
+		}
+		if destination == nil {
+			continue
+		}
+		planned[destination] += request
+		moves = append(moves, move{pod: pod, request: request, destination: destination})
+		need -= request
+	}
+	if need > 0 {
+		return nil
+	}
+	return moves
+}
+
+// blockSize returns the number of free units making a block on the node
+func (d *ExtendedResourceDefragmentation) blockSize(usage *nodeUsage) int64 {
+	if d.args.BlockSize != nil {
+		return int64(*d.args.BlockSize)
+	}
+	return usage.allocatable
+}
+
+func (d *ExtendedResourceDefragmentation) hasBlock(usage *nodeUsage) bool {
+	return usage.free() >= d.blockSize(usage)
+}
+
+func podRequest(pod *v1.Pod, resourceName v1.ResourceName) int64 {
+	return nodeutil.NodeUtilization([]*v1.Pod{pod}, []v1.ResourceName{resourceName})[resourceName].Value()
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extendedresourcedefragmentation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+const fpga v1.ResourceName = "example.com/fpga"
+
+func buildNode(name string, units int64) *v1.Node {
+	return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+		if units > 0 {
+			node.Status.Capacity[fpga] = *resource.NewQuantity(units, resource.DecimalSI)
+			node.Status.Allocatable[fpga] = *resource.NewQuantity(units, resource.DecimalSI)
+		}
+	})
+}
+
+// buildPods builds one pod for each of the given requests on the node
+func buildPods(node *v1.Node, evictable bool, requests ...int64) []*v1.Pod {
+	var pods []*v1.Pod
+	for i, units := range requests {
+		name := fmt.Sprintf("%s-%d", node.Name, i)
+		pods = append(pods, test.BuildTestPod(name, 100, 0, node.Name, func(pod *v1.Pod) {
+			pod.UID = types.UID(name)
+			if evictable {
+				pod.OwnerReferences = test.GetNormalPodOwnerRefList()
+			}
+			pod.Spec.Containers[0].Resources.Requests[fpga] = *resource.NewQuantity(units, resource.DecimalSI)
+		}))
+	}
+	return pods
+}
+
+func TestExtendedResourceDefragmentation(t *testing.T) {
+	n1 := buildNode("n1", 2)
+	n2 := buildNode("n2", 2)
+	n3 := buildNode("n3", 2)
+	n4 := buildNode("n4", 2)
+	large1 := buildNode("large1", 4)
+	large2 := buildNode("large2", 4)
+	noFPGA := buildNode("nofpga", 0)
+
+	tests := []struct {
+		description             string
+		nodes                   []*v1.Node
+		pods                    []*v1.Pod
+		blockSize               *uint
+		maxPodsToEvictPerNode   *uint
+		expectedEvictedPodCount uint
+	}{
+		{
+			description: "Free up whole nodes",
+			nodes:       []*v1.Node{n1, n2, n3, n4},
+			pods: append(append(append(
+				buildPods(n1, true, 1),
+				buildPods(n2, true, 1)...),
+				buildPods(n3, true, 1)...),
+				buildPods(n4, true, 1)...),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Non evictable consumers are kept",
+			nodes:       []*v1.Node{n1, n2},
+			pods: append(
+				buildPods(n1, false, 1),
+				buildPods(n2, true, 1)...),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Nothing is evicted when no consumer can be moved",
+			nodes:       []*v1.Node{n1, n2},
+			pods: append(
+				buildPods(n1, false, 1),
+				buildPods(n2, false, 1)...),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Free up blocks of units",
+			nodes:       []*v1.Node{large1, large2},
+			pods: append(
+				buildPods(large1, true, 1, 1, 1),
+				buildPods(large2, true, 1, 1, 1)...),
+			blockSize:               utilptr.To[uint](2),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Nodes with a free block do not receive pods",
+			nodes:       []*v1.Node{n1, n2, n3},
+			pods: append(
+				buildPods(n1, true, 1),
+				buildPods(n2, true, 2)...),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Nodes are only drained when all the consumers can be moved",
+			nodes:       []*v1.Node{large1, n1, n2},
+			pods: append(append(
+				buildPods(large1, true, 1, 1),
+				buildPods(n1, true, 1)...),
+				buildPods(n2, true, 2)...),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Nodes are drained with several moves",
+			nodes:       []*v1.Node{large1, n1, n2},
+			pods: append(append(
+				buildPods(large1, true, 1, 1),
+				buildPods(n1, false, 1)...),
+				buildPods(n2, false, 1)...),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Nodes are not drained when the moves exceed the eviction limits",
+			nodes:       []*v1.Node{large1, n1, n2},
+			pods: append(append(
+				buildPods(large1, true, 1, 1),
+				buildPods(n1, false, 1)...),
+				buildPods(n2, false, 1)...),
+			maxPodsToEvictPerNode:   utilptr.To[uint](1),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Nodes without the resource are ignored",
+			nodes:       []*v1.Node{n1, noFPGA},
+			pods: append(
+				buildPods(n1, true, 1),
+				buildPods(noFPGA, true, 0)...),
+			expectedEvictedPodCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				tc.maxPodsToEvictPerNode,
+				nil,
+				tc.nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+			}
+			args := &ExtendedResourceDefragmentationArgs{
+				ResourceNames: []v1.ResourceName{fpga},
+				BlockSize:     tc.blockSize,
+			}
+			SetDefaults_ExtendedResourceDefragmentationArgs(args)
+			if err := ValidateExtendedResourceDefragmentationArgs(args); err != nil {
+				t.Fatalf("Invalid args: %v", err)
+			}
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, tc.nodes)
+			if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != tc.expectedEvictedPodCount {
+				t.Errorf("Unexpected no of pods evicted: pods evicted: %d, expected: %d", podsEvicted, tc.expectedEvictedPodCount)
+			}
+		})
+	}
+}
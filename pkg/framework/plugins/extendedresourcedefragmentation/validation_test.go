@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extendedresourcedefragmentation
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateExtendedResourceDefragmentationArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *ExtendedResourceDefragmentationArgs
+		expectError bool
+	}{
+		{
+			description: "extended resource, no errors",
+			args: &ExtendedResourceDefragmentationArgs{
+				ResourceNames: []v1.ResourceName{"nvidia.com/gpu"},
+			},
+			expectError: false,
+		},
+		{
+			description: "extended resources with block size, no errors",
+			args: &ExtendedResourceDefragmentationArgs{
+				ResourceNames: []v1.ResourceName{"nvidia.com/gpu", "example.com/fpga"},
+				BlockSize:     utilptr.To[uint](4),
+				Namespaces:    &api.Namespaces{Include: []string{"default"}},
+			},
+			expectError: false,
+		},
+		{
+			description: "no resource names, expects errors",
+			args:        &ExtendedResourceDefragmentationArgs{},
+			expectError: true,
+		},
+		{
+			description: "basic resource name, expects errors",
+			args: &ExtendedResourceDefragmentationArgs{
+				ResourceNames: []v1.ResourceName{"nvidia.com/gpu", v1.ResourceCPU},
+			},
+			expectError: true,
+		},
+		{
+			description: "zero blockSize, expects errors",
+			args: &ExtendedResourceDefragmentationArgs{
+				ResourceNames: []v1.ResourceName{"nvidia.com/gpu"},
+				BlockSize:     utilptr.To[uint](0),
+			},
+			expectError: true,
+		},
+		{
+			description: "namespace include and exclude, expects errors",
+			args: &ExtendedResourceDefragmentationArgs{
+				ResourceNames: []v1.ResourceName{"nvidia.com/gpu"},
+				Namespaces: &api.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects errors",
+			args: &ExtendedResourceDefragmentationArgs{
+				ResourceNames: []v1.ResourceName{"nvidia.com/gpu"},
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Foo"}},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateExtendedResourceDefragmentationArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Errorf("unexpected arg validation behavior: %v", err)
+			}
+		})
+	}
+}
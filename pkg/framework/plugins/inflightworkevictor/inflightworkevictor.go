@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflightworkevictor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "InFlightWorkEvictor"
+
+var _ frameworktypes.EvictorPlugin = &InFlightWorkEvictor{}
+
+// InFlightWorkEvictor is an EvictorPlugin asking pods whether they are safe to evict
+// right before they are evicted. Pods reporting in-flight work are not evicted.
+// The plugin is built for every descheduling cycle, so the answers are cached per cycle.
+type InFlightWorkEvictor struct {
+	handle frameworktypes.Handle
+	args   *InFlightWorkEvictorArgs
+	probes []Probe
+
+	lock    sync.Mutex
+	results map[types.UID]error
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	inFlightWorkArgs, ok := args.(*InFlightWorkEvictorArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type InFlightWorkEvictorArgs, got %T", args)
+	}
+
+	var probes []Probe
+	for _, name := range inFlightWorkArgs.Probes {
+		builder, ok := probeBuilders[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown probe %q", name)
+		}
+		probes = append(probes, builder(inFlightWorkArgs))
+	}
+
+	return &InFlightWorkEvictor{
+		handle:  handle,
+		args:    inFlightWorkArgs,
+		probes:  probes,
+		results: map[types.UID]error{},
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (e *InFlightWorkEvictor) Name() string {
+	return PluginName
+}
+
+// Filter accepts all pods, the probes are only run before evicting the pods
+// so the candidates of the deschedule and balance plugins are not all probed.
+func (e *InFlightWorkEvictor) Filter(pod *v1.Pod) bool {
+	return true
+}
+
+func (e *InFlightWorkEvictor) PreEvictionFilter(pod *v1.Pod) bool {
+	if err := e.probe(pod); err != nil {
+		klog.V(4).InfoS("Pod fails the following checks", "pod", klog.KObj(pod), "checks", err.Error())
+		return false
+	}
+	return true
+}
+
+// probe runs the probes against the pod once per descheduling cycle
+func (e *InFlightWorkEvictor) probe(pod *v1.Pod) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if err, ok := e.results[pod.UID]; ok {
+		return err
+	}
+	var result error
+	for _, probe := range e.probes {
+		if err := probe.Probe(context.TODO(), pod); err != nil {
+			result = fmt.Errorf("pod has in-flight work: %v", err)
+			break
+		}
+	}
+	e.results[pod.UID] = result
+	return result
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflightworkevictor
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestInFlightWorkEvictor(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/idle":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Unable to get the port of the test server: %v", err)
+	}
+
+	buildPod := func(apply func(pod *v1.Pod)) *v1.Pod {
+		return test.BuildTestPod("p1", 100, 0, "node1", func(pod *v1.Pod) {
+			pod.UID = uuid.NewUUID()
+			pod.Status.PodIP = "127.0.0.1"
+			if apply != nil {
+				apply(pod)
+			}
+		})
+	}
+	withProbe := func(path string) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Annotations = map[string]string{probeAnnotationKey: ":" + port + path}
+		}
+	}
+	withCondition := func(conditionType string, status v1.ConditionStatus) func(pod *v1.Pod) {
+		return func(pod *v1.Pod) {
+			pod.Status.Conditions = []v1.PodCondition{{Type: v1.PodConditionType(conditionType), Status: status}}
+		}
+	}
+
+	tests := []struct {
+		description string
+		args        *InFlightWorkEvictorArgs
+		pod         *v1.Pod
+		result      bool
+	}{
+		{
+			description: "pod without probe or condition",
+			pod:         buildPod(nil),
+			result:      true,
+		},
+		{
+			description: "pod reporting busy through the condition",
+			pod:         buildPod(withCondition(DefaultConditionType, v1.ConditionFalse)),
+			result:      false,
+		},
+		{
+			description: "pod reporting evictable through the condition",
+			pod:         buildPod(withCondition(DefaultConditionType, v1.ConditionTrue)),
+			result:      true,
+		},
+		{
+			description: "custom condition type",
+			args:        &InFlightWorkEvictorArgs{Probes: []string{ConditionProbeName}, ConditionType: "example.com/Idle"},
+			pod:         buildPod(withCondition("example.com/Idle", v1.ConditionFalse)),
+			result:      false,
+		},
+		{
+			description: "pod reporting idle through the endpoint",
+			pod:         buildPod(withProbe("/idle")),
+			result:      true,
+		},
+		{
+			description: "pod reporting busy through the endpoint",
+			pod:         buildPod(withProbe("/busy")),
+			result:      false,
+		},
+		{
+			description: "endpoint ignored when the HTTP probe is disabled",
+			args:        &InFlightWorkEvictorArgs{Probes: []string{ConditionProbeName}, ConditionType: DefaultConditionType},
+			pod:         buildPod(withProbe("/busy")),
+			result:      true,
+		},
+		{
+			description: "invalid annotation",
+			pod: buildPod(func(pod *v1.Pod) {
+				pod.Annotations = map[string]string{probeAnnotationKey: "/idle"}
+			}),
+			result: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			args := tc.args
+			if args == nil {
+				args = &InFlightWorkEvictorArgs{}
+			}
+			SetDefaults_InFlightWorkEvictorArgs(args)
+			plugin, err := New(args, &frameworkfake.HandleImpl{})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			evictor := plugin.(frameworktypes.EvictorPlugin)
+			if !evictor.Filter(tc.pod) {
+				t.Errorf("Expected pod %s to pass the filter", tc.pod.Name)
+			}
+			if result := evictor.PreEvictionFilter(tc.pod); result != tc.result {
+				t.Errorf("Expected pod %s to be evictable: %t, got %t", tc.pod.Name, tc.result, result)
+			}
+		})
+	}
+
+	t.Run("results are cached", func(t *testing.T) {
+		args := &InFlightWorkEvictorArgs{}
+		SetDefaults_InFlightWorkEvictorArgs(args)
+		plugin, err := New(args, &frameworkfake.HandleImpl{})
+		if err != nil {
+			t.Fatalf("Unable to initialize the plugin: %v", err)
+		}
+		pod := buildPod(withProbe("/busy"))
+		before := requests.Load()
+		for i := 0; i < 3; i++ {
+			if plugin.(frameworktypes.EvictorPlugin).PreEvictionFilter(pod) {
+				t.Errorf("Expected pod %s not to be evictable", pod.Name)
+			}
+		}
+		if probed := requests.Load() - before; probed != 1 {
+			t.Errorf("Expected the pod to be probed once, got %d", probed)
+		}
+	})
+}
+
+func TestProbeURL(t *testing.T) {
+	tests := []struct {
+		podIP     string
+		value     string
+		url       string
+		expectErr bool
+	}{
+		{podIP: "10.0.0.1", value: ":8080/safe-to-evict", url: "http://10.0.0.1:8080/safe-to-evict"},
+		{podIP: "10.0.0.1", value: ":8080", url: "http://10.0.0.1:8080/"},
+		{podIP: "fd00::1", value: ":8080/idle", url: "http://[fd00::1]:8080/idle"},
+		{podIP: "10.0.0.1", value: "8080/idle", expectErr: true},
+		{podIP: "10.0.0.1", value: ":http/idle", expectErr: true},
+		{podIP: "", value: ":8080/idle", expectErr: true},
+	}
+	for _, tc := range tests {
+		url, err := probeURL(tc.podIP, tc.value)
+		if tc.expectErr != (err != nil) {
+			t.Errorf("%q: expected error: %v, got: %v", tc.value, tc.expectErr, err)
+		}
+		if url != tc.url {
+			t.Errorf("%q: expected %q, got %q", tc.value, tc.url, url)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflightworkevictor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ConditionProbeName is the probe checking a pod condition set by the application
+	ConditionProbeName = "Condition"
+	// HTTPProbeName is the probe requesting an HTTP endpoint declared by the pod
+	HTTPProbeName = "HTTP"
+
+	// DefaultConditionType is the pod condition checked by default
+	DefaultConditionType = "Evictable"
+
+	// probeAnnotationKey declares the port and path of the HTTP endpoint of a pod, e.g. ":8080/safe-to-evict".
+	// The endpoint answers with a 2xx status when the pod is safe to evict.
+	probeAnnotationKey = "descheduler.alpha.kubernetes.io/safe-to-evict-probe"
+)
+
+// Probe asks a pod whether it can be evicted without losing in-flight work.
+type Probe interface {
+	// Probe returns an error describing why the pod is not safe to evict.
+	// Pods the probe does not apply to are safe to evict.
+	Probe(ctx context.Context, pod *v1.Pod) error
+}
+
+type probeBuilder func(args *InFlightWorkEvictorArgs) Probe
+
+// probeBuilders are the probes which can be enabled by name
+var probeBuilders = map[string]probeBuilder{
+	ConditionProbeName: newConditionProbe,
+	HTTPProbeName:      newHTTPProbe,
+}
+
+// conditionProbe reports pods with the condition set to False as busy
+type conditionProbe struct {
+	conditionType v1.PodConditionType
+}
+
+func newConditionProbe(args *InFlightWorkEvictorArgs) Probe {
+	return &conditionProbe{conditionType: v1.PodConditionType(args.ConditionType)}
+}
+
+func (p *conditionProbe) Probe(_ context.Context, pod *v1.Pod) error {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == p.conditionType && condition.Status == v1.ConditionFalse {
+			if condition.Message != "" {
+				return fmt.Errorf("pod condition %s is False: %s", p.conditionType, condition.Message)
+			}
+			return fmt.Errorf("pod condition %s is False", p.conditionType)
+		}
+	}
+	return nil
+}
+
+// httpProbe requests the endpoint declared by the safe-to-evict-probe annotation of the
+// pod. Pods answering with any other status than 2xx, or not answering, are busy.
+type httpProbe struct {
+	client *http.Client
+}
+
+func newHTTPProbe(args *InFlightWorkEvictorArgs) Probe {
+	timeout := time.Second
+	if args.HTTPTimeoutSeconds != nil {
+		timeout = time.Duration(*args.HTTPTimeoutSeconds) * time.Second
+	}
+	return &httpProbe{client: &http.Client{Timeout: timeout}}
+}
+
+func (p *httpProbe) Probe(ctx context.Context, pod *v1.Pod) error {
+	value, ok := pod.Annotations[probeAnnotationKey]
+	if !ok {
+		return nil
+	}
+	url, err := probeURL(pod.Status.PodIP, value)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create the request of the %s annotation: %v", probeAnnotationKey, err)
+	}
+	response, err := p.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("unable to probe %s: %v", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("probe %s answered with status %d", url, response.StatusCode)
+	}
+	return nil
+}
+
+// probeURL builds the URL of the endpoint from the pod IP and an annotation value of
+// the form ":port/path"
+func probeURL(podIP, value string) (string, error) {
+	if podIP == "" {
+		return "", fmt.Errorf("pod has no IP to probe")
+	}
+	if !strings.HasPrefix(value, ":") {
+		return "", fmt.Errorf("invalid %s annotation %q, must be of the form :port/path", probeAnnotationKey, value)
+	}
+	port, path, _ := strings.Cut(value[1:], "/")
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return "", fmt.Errorf("invalid port in %s annotation %q", probeAnnotationKey, value)
+	}
+	return "http://" + net.JoinHostPort(podIP, port) + "/" + path, nil
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflightworkevictor
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InFlightWorkEvictorArgs holds arguments used to configure InFlightWorkEvictor plugin.
+type InFlightWorkEvictorArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Probes asking pods whether they are safe to evict, all of them have to agree.
+	// Defaults to Condition and HTTP.
+	Probes []string `json:"probes"`
+	// ConditionType is the pod condition an application sets to False while it is
+	// busy, used by the Condition probe. Defaults to Evictable.
+	ConditionType string `json:"conditionType"`
+	// HTTPTimeoutSeconds bounds the requests of the HTTP probe. Defaults to 1.
+	HTTPTimeoutSeconds *uint `json:"httpTimeoutSeconds"`
+}
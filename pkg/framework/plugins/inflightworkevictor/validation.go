@@ -0,0 +1,41 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflightworkevictor
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ValidateInFlightWorkEvictorArgs validates InFlightWorkEvictor arguments
+func ValidateInFlightWorkEvictorArgs(obj runtime.Object) error {
+	args := obj.(*InFlightWorkEvictorArgs)
+	if len(args.Probes) == 0 {
+		return fmt.Errorf("at least one probe must be set")
+	}
+	for _, name := range args.Probes {
+		if _, ok := probeBuilders[name]; !ok {
+			return fmt.Errorf("unknown probe %q, must be one of %v", name, sets.List(sets.KeySet(probeBuilders)))
+		}
+	}
+	if args.HTTPTimeoutSeconds != nil && *args.HTTPTimeoutSeconds == 0 {
+		return fmt.Errorf("httpTimeoutSeconds must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inflightworkevictor
+
+import (
+	"testing"
+
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestValidateInFlightWorkEvictorArgs(t *testing.T) {
+	tests := []struct {
+		description string
+		args        *InFlightWorkEvictorArgs
+		expectErr   bool
+	}{
+		{
+			description: "default probes",
+			args:        &InFlightWorkEvictorArgs{Probes: []string{ConditionProbeName, HTTPProbeName}, HTTPTimeoutSeconds: utilptr.To[uint](1)},
+		},
+		{
+			description: "no probes",
+			args:        &InFlightWorkEvictorArgs{Probes: []string{}},
+			expectErr:   true,
+		},
+		{
+			description: "unknown probe",
+			args:        &InFlightWorkEvictorArgs{Probes: []string{"Exec"}},
+			expectErr:   true,
+		},
+		{
+			description: "zero timeout",
+			args:        &InFlightWorkEvictorArgs{Probes: []string{HTTPProbeName}, HTTPTimeoutSeconds: utilptr.To[uint](0)},
+			expectErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateInFlightWorkEvictorArgs(tc.args)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("Expected error: %v, got: %v", tc.expectErr, err)
+			}
+		})
+	}
+}
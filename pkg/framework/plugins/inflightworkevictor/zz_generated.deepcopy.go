@@ -0,0 +1,61 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package inflightworkevictor
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InFlightWorkEvictorArgs) DeepCopyInto(out *InFlightWorkEvictorArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPTimeoutSeconds != nil {
+		in, out := &in.HTTPTimeoutSeconds, &out.HTTPTimeoutSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InFlightWorkEvictorArgs.
+func (in *InFlightWorkEvictorArgs) DeepCopy() *InFlightWorkEvictorArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(InFlightWorkEvictorArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InFlightWorkEvictorArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
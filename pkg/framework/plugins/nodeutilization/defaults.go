@@ -15,6 +15,7 @@ package nodeutilization
 
 import (
 	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -50,3 +51,21 @@ func SetDefaults_HighNodeUtilizationArgs(obj runtime.Object) {
 		args.NumberOfNodes = 0
 	}
 }
+
+// SetDefaults_RebalanceOntoNewNodesArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_RebalanceOntoNewNodesArgs(obj runtime.Object) {
+	args := obj.(*RebalanceOntoNewNodesArgs)
+	if args.MaxNodeAgeSeconds == nil {
+		args.MaxNodeAgeSeconds = utilptr.To[uint](900)
+	}
+	if args.NodeAgeFrom == "" {
+		args.NodeAgeFrom = NodeAgeFromCreationTimestamp
+	}
+	if args.TargetShare == 0 {
+		args.TargetShare = 80
+	}
+	if args.MaxPodsToEvict == nil {
+		args.MaxPodsToEvict = utilptr.To[uint](10)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilptr "k8s.io/utils/ptr"
 	"sigs.k8s.io/descheduler/pkg/api"
 )
 
@@ -122,3 +123,47 @@ func TestSetDefaults_HighNodeUtilizationArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestSetDefaults_RebalanceOntoNewNodesArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "RebalanceOntoNewNodesArgs empty",
+			in:   &RebalanceOntoNewNodesArgs{},
+			want: &RebalanceOntoNewNodesArgs{
+				MaxNodeAgeSeconds: utilptr.To[uint](900),
+				NodeAgeFrom:       NodeAgeFromCreationTimestamp,
+				TargetShare:       80,
+				MaxPodsToEvict:    utilptr.To[uint](10),
+			},
+		},
+		{
+			name: "RebalanceOntoNewNodesArgs with value",
+			in: &RebalanceOntoNewNodesArgs{
+				MaxNodeAgeSeconds: utilptr.To[uint](60),
+				NodeAgeFrom:       NodeAgeFromReady,
+				PoolLabel:         "example.com/pool",
+				TargetShare:       50,
+				MaxPodsToEvict:    utilptr.To[uint](2),
+			},
+			want: &RebalanceOntoNewNodesArgs{
+				MaxNodeAgeSeconds: utilptr.To[uint](60),
+				NodeAgeFrom:       NodeAgeFromReady,
+				PoolLabel:         "example.com/pool",
+				TargetShare:       50,
+				MaxPodsToEvict:    utilptr.To[uint](2),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RebalanceOntoNewNodesArgs(tc.in)
+			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 
 	"sigs.k8s.io/descheduler/pkg/api"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
 	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
@@ -437,109 +438,116 @@ func TestHighNodeUtilization(t *testing.T) {
 	}
 
 	for _, testCase := range testCases {
-		t.Run(testCase.name, func(t *testing.T) {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-
-			var objs []runtime.Object
-			for _, node := range testCase.nodes {
-				objs = append(objs, node)
-			}
-			for _, pod := range testCase.pods {
-				objs = append(objs, pod)
-			}
-			fakeClient := fake.NewSimpleClientset(objs...)
-
-			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
-			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
-
-			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
-			if err != nil {
-				t.Errorf("Build get pods assigned to node function error: %v", err)
-			}
-
-			podsForEviction := make(map[string]struct{})
-			for _, pod := range testCase.evictedPods {
-				podsForEviction[pod] = struct{}{}
-			}
-
-			evictionFailed := false
-			if len(testCase.evictedPods) > 0 {
-				fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
-					getAction := action.(core.CreateAction)
-					obj := getAction.GetObject()
-					if eviction, ok := obj.(*policy.Eviction); ok {
-						if _, exists := podsForEviction[eviction.Name]; exists {
-							return true, obj, nil
+		for _, withSnapshot := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s (snapshot: %t)", testCase.name, withSnapshot), func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				var objs []runtime.Object
+				for _, node := range testCase.nodes {
+					objs = append(objs, node)
+				}
+				for _, pod := range testCase.pods {
+					objs = append(objs, pod)
+				}
+				fakeClient := fake.NewSimpleClientset(objs...)
+
+				sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+				podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+				getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+				if err != nil {
+					t.Errorf("Build get pods assigned to node function error: %v", err)
+				}
+
+				podsForEviction := make(map[string]struct{})
+				for _, pod := range testCase.evictedPods {
+					podsForEviction[pod] = struct{}{}
+				}
+
+				evictionFailed := false
+				if len(testCase.evictedPods) > 0 {
+					fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+						getAction := action.(core.CreateAction)
+						obj := getAction.GetObject()
+						if eviction, ok := obj.(*policy.Eviction); ok {
+							if _, exists := podsForEviction[eviction.Name]; exists {
+								return true, obj, nil
+							}
+							evictionFailed = true
+							return true, nil, fmt.Errorf("pod %q was unexpectedly evicted", eviction.Name)
 						}
-						evictionFailed = true
-						return true, nil, fmt.Errorf("pod %q was unexpectedly evicted", eviction.Name)
-					}
-					return true, obj, nil
-				})
-			}
-
-			sharedInformerFactory.Start(ctx.Done())
-			sharedInformerFactory.WaitForCacheSync(ctx.Done())
-
-			eventRecorder := &events.FakeRecorder{}
-
-			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				"v1",
-				false,
-				nil,
-				nil,
-				testCase.nodes,
-				false,
-				eventRecorder,
-			)
-
-			defaultevictorArgs := &defaultevictor.DefaultEvictorArgs{
-				EvictLocalStoragePods:   false,
-				EvictSystemCriticalPods: false,
-				IgnorePvcPods:           false,
-				EvictFailedBarePods:     false,
-				NodeFit:                 true,
-			}
-
-			evictorFilter, err := defaultevictor.New(
-				defaultevictorArgs,
-				&frameworkfake.HandleImpl{
+						return true, obj, nil
+					})
+				}
+
+				var snapshot *nodeutil.Snapshot
+				if withSnapshot {
+					snapshot = nodeutil.NewSnapshot(fakeClient, sharedInformerFactory.Core().V1().Nodes().Lister(), getPodsAssignedToNode)
+				}
+
+				sharedInformerFactory.Start(ctx.Done())
+				sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+				eventRecorder := &events.FakeRecorder{}
+
+				podEvictor := evictions.NewPodEvictor(
+					evictions.NewEvictionAPIBackend(fakeClient, "v1"),
+					nil,
+					nil,
+					testCase.nodes,
+					false,
+					eventRecorder,
+				)
+
+				defaultevictorArgs := &defaultevictor.DefaultEvictorArgs{
+					EvictLocalStoragePods:   false,
+					EvictSystemCriticalPods: false,
+					IgnorePvcPods:           false,
+					EvictFailedBarePods:     false,
+					NodeFit:                 true,
+				}
+
+				evictorFilter, err := defaultevictor.New(
+					defaultevictorArgs,
+					&frameworkfake.HandleImpl{
+						ClientsetImpl:                 fakeClient,
+						GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+						SharedInformerFactoryImpl:     sharedInformerFactory,
+						NodeSnapshotImpl:              snapshot,
+					},
+				)
+				if err != nil {
+					t.Fatalf("Unable to initialize the plugin: %v", err)
+				}
+
+				handle := &frameworkfake.HandleImpl{
 					ClientsetImpl:                 fakeClient,
 					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					PodEvictorImpl:                podEvictor,
+					EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
 					SharedInformerFactoryImpl:     sharedInformerFactory,
-				},
-			)
-			if err != nil {
-				t.Fatalf("Unable to initialize the plugin: %v", err)
-			}
+					NodeSnapshotImpl:              snapshot,
+				}
 
-			handle := &frameworkfake.HandleImpl{
-				ClientsetImpl:                 fakeClient,
-				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
-				PodEvictorImpl:                podEvictor,
-				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
-				SharedInformerFactoryImpl:     sharedInformerFactory,
-			}
-
-			plugin, err := NewHighNodeUtilization(&HighNodeUtilizationArgs{
-				Thresholds: testCase.thresholds,
-			},
-				handle)
-			if err != nil {
-				t.Fatalf("Unable to initialize the plugin: %v", err)
-			}
-			plugin.(frameworktypes.BalancePlugin).Balance(ctx, testCase.nodes)
-
-			podsEvicted := podEvictor.TotalEvicted()
-			if testCase.expectedPodsEvicted != podsEvicted {
-				t.Errorf("Expected %v pods to be evicted but %v got evicted", testCase.expectedPodsEvicted, podsEvicted)
-			}
-			if evictionFailed {
-				t.Errorf("Pod evictions failed unexpectedly")
-			}
-		})
+				plugin, err := NewHighNodeUtilization(&HighNodeUtilizationArgs{
+					Thresholds: testCase.thresholds,
+				},
+					handle)
+				if err != nil {
+					t.Fatalf("Unable to initialize the plugin: %v", err)
+				}
+				plugin.(frameworktypes.BalancePlugin).Balance(ctx, testCase.nodes)
+
+				podsEvicted := podEvictor.TotalEvicted()
+				if testCase.expectedPodsEvicted != podsEvicted {
+					t.Errorf("Expected %v pods to be evicted but %v got evicted", testCase.expectedPodsEvicted, podsEvicted)
+				}
+				if evictionFailed {
+					t.Errorf("Pod evictions failed unexpectedly")
+				}
+			})
+		}
 	}
 }
 
@@ -637,9 +645,7 @@ func TestHighNodeUtilizationWithTaints(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				"policy/v1",
-				false,
+				evictions.NewEvictionAPIBackend(fakeClient, "policy/v1"),
 				&item.evictionsExpected,
 				nil,
 				item.nodes,
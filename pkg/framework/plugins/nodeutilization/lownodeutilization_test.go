@@ -36,6 +36,7 @@ import (
 	"k8s.io/client-go/tools/events"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/utils"
 	"sigs.k8s.io/descheduler/test"
@@ -839,112 +840,119 @@ func TestLowNodeUtilization(t *testing.T) {
 	}
 
 	for _, test := range testCases {
-		t.Run(test.name, func(t *testing.T) {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
+		for _, withSnapshot := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s (snapshot: %t)", test.name, withSnapshot), func(t *testing.T) {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
 
-			var objs []runtime.Object
-			for _, node := range test.nodes {
-				objs = append(objs, node)
-			}
-			for _, pod := range test.pods {
-				objs = append(objs, pod)
-			}
-			fakeClient := fake.NewSimpleClientset(objs...)
+				var objs []runtime.Object
+				for _, node := range test.nodes {
+					objs = append(objs, node)
+				}
+				for _, pod := range test.pods {
+					objs = append(objs, pod)
+				}
+				fakeClient := fake.NewSimpleClientset(objs...)
 
-			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
-			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+				sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+				podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
 
-			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
-			if err != nil {
-				t.Errorf("Build get pods assigned to node function error: %v", err)
-			}
+				getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+				if err != nil {
+					t.Errorf("Build get pods assigned to node function error: %v", err)
+				}
 
-			podsForEviction := make(map[string]struct{})
-			for _, pod := range test.evictedPods {
-				podsForEviction[pod] = struct{}{}
-			}
+				podsForEviction := make(map[string]struct{})
+				for _, pod := range test.evictedPods {
+					podsForEviction[pod] = struct{}{}
+				}
 
-			evictionFailed := false
-			if len(test.evictedPods) > 0 {
-				fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
-					getAction := action.(core.CreateAction)
-					obj := getAction.GetObject()
-					if eviction, ok := obj.(*policy.Eviction); ok {
-						if _, exists := podsForEviction[eviction.Name]; exists {
-							return true, obj, nil
+				evictionFailed := false
+				if len(test.evictedPods) > 0 {
+					fakeClient.Fake.AddReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+						getAction := action.(core.CreateAction)
+						obj := getAction.GetObject()
+						if eviction, ok := obj.(*policy.Eviction); ok {
+							if _, exists := podsForEviction[eviction.Name]; exists {
+								return true, obj, nil
+							}
+							evictionFailed = true
+							return true, nil, fmt.Errorf("pod %q was unexpectedly evicted", eviction.Name)
 						}
-						evictionFailed = true
-						return true, nil, fmt.Errorf("pod %q was unexpectedly evicted", eviction.Name)
-					}
-					return true, obj, nil
-				})
-			}
+						return true, obj, nil
+					})
+				}
 
-			sharedInformerFactory.Start(ctx.Done())
-			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+				var snapshot *nodeutil.Snapshot
+				if withSnapshot {
+					snapshot = nodeutil.NewSnapshot(fakeClient, sharedInformerFactory.Core().V1().Nodes().Lister(), getPodsAssignedToNode)
+				}
 
-			eventRecorder := &events.FakeRecorder{}
+				sharedInformerFactory.Start(ctx.Done())
+				sharedInformerFactory.WaitForCacheSync(ctx.Done())
 
-			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				policy.SchemeGroupVersion.String(),
-				false,
-				nil,
-				nil,
-				test.nodes,
-				false,
-				eventRecorder,
-			)
+				eventRecorder := &events.FakeRecorder{}
 
-			defaultEvictorFilterArgs := &defaultevictor.DefaultEvictorArgs{
-				EvictLocalStoragePods:   false,
-				EvictSystemCriticalPods: false,
-				IgnorePvcPods:           false,
-				EvictFailedBarePods:     false,
-				NodeFit:                 true,
-			}
+				podEvictor := evictions.NewPodEvictor(
+					evictions.NewEvictionAPIBackend(fakeClient, policy.SchemeGroupVersion.String()),
+					nil,
+					nil,
+					test.nodes,
+					false,
+					eventRecorder,
+				)
 
-			evictorFilter, err := defaultevictor.New(
-				defaultEvictorFilterArgs,
-				&frameworkfake.HandleImpl{
+				defaultEvictorFilterArgs := &defaultevictor.DefaultEvictorArgs{
+					EvictLocalStoragePods:   false,
+					EvictSystemCriticalPods: false,
+					IgnorePvcPods:           false,
+					EvictFailedBarePods:     false,
+					NodeFit:                 true,
+				}
+
+				evictorFilter, err := defaultevictor.New(
+					defaultEvictorFilterArgs,
+					&frameworkfake.HandleImpl{
+						ClientsetImpl:                 fakeClient,
+						GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+						SharedInformerFactoryImpl:     sharedInformerFactory,
+						NodeSnapshotImpl:              snapshot,
+					},
+				)
+				if err != nil {
+					t.Fatalf("Unable to initialize the plugin: %v", err)
+				}
+
+				handle := &frameworkfake.HandleImpl{
 					ClientsetImpl:                 fakeClient,
 					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					PodEvictorImpl:                podEvictor,
+					EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
 					SharedInformerFactoryImpl:     sharedInformerFactory,
-				},
-			)
-			if err != nil {
-				t.Fatalf("Unable to initialize the plugin: %v", err)
-			}
-
-			handle := &frameworkfake.HandleImpl{
-				ClientsetImpl:                 fakeClient,
-				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
-				PodEvictorImpl:                podEvictor,
-				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
-				SharedInformerFactoryImpl:     sharedInformerFactory,
-			}
+					NodeSnapshotImpl:              snapshot,
+				}
 
-			plugin, err := NewLowNodeUtilization(&LowNodeUtilizationArgs{
-				Thresholds:             test.thresholds,
-				TargetThresholds:       test.targetThresholds,
-				UseDeviationThresholds: test.useDeviationThresholds,
-				EvictableNamespaces:    test.evictableNamespaces,
-			},
-				handle)
-			if err != nil {
-				t.Fatalf("Unable to initialize the plugin: %v", err)
-			}
-			plugin.(frameworktypes.BalancePlugin).Balance(ctx, test.nodes)
+				plugin, err := NewLowNodeUtilization(&LowNodeUtilizationArgs{
+					Thresholds:             test.thresholds,
+					TargetThresholds:       test.targetThresholds,
+					UseDeviationThresholds: test.useDeviationThresholds,
+					EvictableNamespaces:    test.evictableNamespaces,
+				},
+					handle)
+				if err != nil {
+					t.Fatalf("Unable to initialize the plugin: %v", err)
+				}
+				plugin.(frameworktypes.BalancePlugin).Balance(ctx, test.nodes)
 
-			podsEvicted := podEvictor.TotalEvicted()
-			if test.expectedPodsEvicted != podsEvicted {
-				t.Errorf("Expected %v pods to be evicted but %v got evicted", test.expectedPodsEvicted, podsEvicted)
-			}
-			if evictionFailed {
-				t.Errorf("Pod evictions failed unexpectedly")
-			}
-		})
+				podsEvicted := podEvictor.TotalEvicted()
+				if test.expectedPodsEvicted != podsEvicted {
+					t.Errorf("Expected %v pods to be evicted but %v got evicted", test.expectedPodsEvicted, podsEvicted)
+				}
+				if evictionFailed {
+					t.Errorf("Pod evictions failed unexpectedly")
+				}
+			})
+		}
 	}
 }
 
@@ -1059,9 +1067,7 @@ func TestLowNodeUtilizationWithTaints(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				policy.SchemeGroupVersion.String(),
-				false,
+				evictions.NewEvictionAPIBackend(fakeClient, policy.SchemeGroupVersion.String()),
 				&item.evictionsExpected,
 				nil,
 				item.nodes,
@@ -25,7 +25,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
-	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	"sigs.k8s.io/descheduler/pkg/descheduler/node"
@@ -79,13 +79,14 @@ func getNodeThresholds(
 	lowThreshold, highThreshold api.ResourceThresholds,
 	resourceNames []v1.ResourceName,
 	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc,
+	snapshot *nodeutil.Snapshot,
 	useDeviationThresholds bool,
 ) map[string]NodeThresholds {
 	nodeThresholdsMap := map[string]NodeThresholds{}
 
 	averageResourceUsagePercent := api.ResourceThresholds{}
 	if useDeviationThresholds {
-		averageResourceUsagePercent = averageNodeBasicresources(nodes, getPodsAssignedToNode, resourceNames)
+		averageResourceUsagePercent = averageNodeBasicresources(nodes, getPodsAssignedToNode, snapshot, resourceNames)
 	}
 
 	for _, node := range nodes {
@@ -123,11 +124,12 @@ func getNodeUsage(
 	nodes []*v1.Node,
 	resourceNames []v1.ResourceName,
 	getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc,
+	snapshot *nodeutil.Snapshot,
 ) []NodeUsage {
 	var nodeUsageList []NodeUsage
 
 	for _, node := range nodes {
-		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
+		pods, usage, err := podsUsage(node, resourceNames, getPodsAssignedToNode, snapshot)
 		if err != nil {
 			klog.V(2).InfoS("Node will not be processed, error accessing its pods", "node", klog.KObj(node), "err", err)
 			continue
@@ -135,7 +137,7 @@ func getNodeUsage(
 
 		nodeUsageList = append(nodeUsageList, NodeUsage{
 			node:    node,
-			usage:   nodeutil.NodeUtilization(pods, resourceNames),
+			usage:   usage,
 			allPods: pods,
 		})
 	}
@@ -143,6 +145,23 @@ func getNodeUsage(
 	return nodeUsageList
 }
 
+// podsUsage lists the pods of the node and sums up their requests, reusing the
+// requests precomputed by the node snapshot when there is one.
+func podsUsage(node *v1.Node, resourceNames []v1.ResourceName, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, snapshot *nodeutil.Snapshot) ([]*v1.Pod, map[v1.ResourceName]*resource.Quantity, error) {
+	if snapshot == nil {
+		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return pods, nodeutil.NodeUtilization(pods, resourceNames), nil
+	}
+	nodeInfo, err := snapshot.NodeInfo(node.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append([]*v1.Pod(nil), nodeInfo.Pods...), nodeInfo.Usage(resourceNames), nil
+}
+
 func resourceThreshold(nodeCapacity v1.ResourceList, resourceName v1.ResourceName, threshold api.Percentage) *resource.Quantity {
 	defaultFormat := resource.DecimalSI
 	if resourceName == v1.ResourceMemory {
@@ -220,6 +239,7 @@ func classifyNodes(
 func evictPodsFromSourceNodes(
 	ctx context.Context,
 	evictableNamespaces *api.Namespaces,
+	sharedInformerFactory informers.SharedInformerFactory,
 	sourceNodes, destinationNodes []NodeInfo,
 	podEvictor frameworktypes.Evictor,
 	evictOptions evictions.EvictOptions,
@@ -274,7 +294,7 @@ func evictPodsFromSourceNodes(
 		klog.V(1).InfoS("Evicting pods based on priority, if they have same priority, they'll be evicted based on QoS tiers")
 		// sort the evictable Pods based on priority. This also sorts them based on QoS. If there are multiple pods with same priority, they are sorted based on QoS tiers.
 		podutil.SortPodsBasedOnPriorityLowToHigh(removablePods)
-		evictPods(ctx, evictableNamespaces, removablePods, node, totalAvailableUsage, taintsOfDestinationNodes, podEvictor, evictOptions, continueEviction)
+		evictPods(ctx, evictableNamespaces, sharedInformerFactory, removablePods, node, totalAvailableUsage, taintsOfDestinationNodes, podEvictor, evictOptions, continueEviction)
 
 	}
 }
@@ -282,6 +302,7 @@ func evictPodsFromSourceNodes(
 func evictPods(
 	ctx context.Context,
 	evictableNamespaces *api.Namespaces,
+	sharedInformerFactory informers.SharedInformerFactory,
 	inputPods []*v1.Pod,
 	nodeInfo NodeInfo,
 	totalAvailableUsage map[v1.ResourceName]*resource.Quantity,
@@ -290,11 +311,6 @@ func evictPods(
 	evictOptions evictions.EvictOptions,
 	continueEviction continueEvictionCond,
 ) {
-	var excludedNamespaces sets.Set[string]
-	if evictableNamespaces != nil {
-		excludedNamespaces = sets.New(evictableNamespaces.Exclude...)
-	}
-
 	if continueEviction(nodeInfo, totalAvailableUsage) {
 		for _, pod := range inputPods {
 			if !utils.PodToleratesTaints(pod, taintsOfLowNodes) {
@@ -304,7 +320,7 @@ func evictPods(
 
 			preEvictionFilterWithOptions, err := podutil.NewOptions().
 				WithFilter(podEvictor.PreEvictionFilter).
-				WithoutNamespaces(excludedNamespaces).
+				WithNamespaceFilter(evictableNamespaces, sharedInformerFactory).
 				BuildFilterFunc()
 			if err != nil {
 				klog.ErrorS(err, "could not build preEvictionFilter with namespace exclusion")
@@ -424,17 +440,16 @@ func classifyPods(pods []*v1.Pod, filter func(pod *v1.Pod) bool) ([]*v1.Pod, []*
 	return nonRemovablePods, removablePods
 }
 
-func averageNodeBasicresources(nodes []*v1.Node, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, resourceNames []v1.ResourceName) api.ResourceThresholds {
+func averageNodeBasicresources(nodes []*v1.Node, getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc, snapshot *nodeutil.Snapshot, resourceNames []v1.ResourceName) api.ResourceThresholds {
 	total := api.ResourceThresholds{}
 	average := api.ResourceThresholds{}
 	numberOfNodes := len(nodes)
 	for _, node := range nodes {
-		pods, err := podutil.ListPodsOnANode(node.Name, getPodsAssignedToNode, nil)
+		_, usage, err := podsUsage(node, resourceNames, getPodsAssignedToNode, snapshot)
 		if err != nil {
 			numberOfNodes--
 			continue
 		}
-		usage := nodeutil.NodeUtilization(pods, resourceNames)
 		nodeCapacity := node.Status.Capacity
 		if len(node.Status.Allocatable) > 0 {
 			nodeCapacity = node.Status.Allocatable
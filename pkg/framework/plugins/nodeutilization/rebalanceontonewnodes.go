@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const RebalanceOntoNewNodesPluginName = "RebalanceOntoNewNodes"
+
+const (
+	// NodeAgeFromCreationTimestamp computes the age of a node from its creation
+	NodeAgeFromCreationTimestamp = "CreationTimestamp"
+	// NodeAgeFromReady computes the age of a node from the last transition of its Ready condition
+	NodeAgeFromReady = "Ready"
+)
+
+// RebalanceOntoNewNodes evicts pods from the most loaded nodes of a pool when new nodes join the pool,
+// so that the scheduler spreads them onto the new nodes. Note that CPU/Memory requests are used
+// to calculate nodes' utilization and not the actual resource usage.
+type RebalanceOntoNewNodes struct {
+	handle    frameworktypes.Handle
+	args      *RebalanceOntoNewNodesArgs
+	podFilter func(pod *v1.Pod) bool
+	now       func() time.Time
+}
+
+var _ frameworktypes.BalancePlugin = &RebalanceOntoNewNodes{}
+
+// NewRebalanceOntoNewNodes builds plugin from its arguments while passing a handle
+func NewRebalanceOntoNewNodes(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	rebalanceArgs, ok := args.(*RebalanceOntoNewNodesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RebalanceOntoNewNodesArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithFilter(handle.Evictor().Filter).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RebalanceOntoNewNodes{
+		handle:    handle,
+		args:      rebalanceArgs,
+		podFilter: podFilter,
+		now:       time.Now,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (r *RebalanceOntoNewNodes) Name() string {
+	return RebalanceOntoNewNodesPluginName
+}
+
+// Balance extension point implementation for the plugin
+func (r *RebalanceOntoNewNodes) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	pools := map[string][]*v1.Node{}
+	for _, node := range nodes {
+		pool := ""
+		if r.args.PoolLabel != "" {
+			value, ok := node.Labels[r.args.PoolLabel]
+			if !ok {
+				continue
+			}
+			pool = value
+		}
+		pools[pool] = append(pools[pool], node)
+	}
+
+	poolNames := make([]string, 0, len(pools))
+	for pool := range pools {
+		poolNames = append(poolNames, pool)
+	}
+	sort.Strings(poolNames)
+
+	evicted := uint(0)
+	for _, pool := range poolNames {
+		if evicted >= *r.args.MaxPodsToEvict {
+			break
+		}
+		evicted += r.balancePool(ctx, pool, pools[pool], *r.args.MaxPodsToEvict-evicted)
+	}
+	return nil
+}
+
+// balancePool evicts at most maxPods pods from the nodes of the pool above the average utilization
+// of the old nodes, as long as the new nodes are below their target share of that average.
+// It returns the number of pods evicted.
+func (r *RebalanceOntoNewNodes) balancePool(ctx context.Context, pool string, nodes []*v1.Node, maxPods uint) uint {
+	resourceNames := []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory, v1.ResourcePods}
+	var newNodes, oldNodes []NodeUsage
+	for _, usage := range getNodeUsage(nodes, resourceNames, r.handle.GetPodsAssignedToNodeFunc(), r.handle.NodeSnapshot()) {
+		if r.isNew(usage.node) {
+			if nodeutil.IsNodeUnschedulable(usage.node) {
+				klog.V(2).InfoS("Node is unschedulable, thus not considered as new", "node", klog.KObj(usage.node))
+				continue
+			}
+			newNodes = append(newNodes, usage)
+		} else {
+			oldNodes = append(oldNodes, usage)
+		}
+	}
+	if len(newNodes) == 0 || len(oldNodes) == 0 {
+		return 0
+	}
+
+	average := 0.0
+	for _, usage := range oldNodes {
+		average += utilizationScore(usage)
+	}
+	average /= float64(len(oldNodes))
+	target := api.Percentage(average) * r.args.TargetShare / MaxResourcePercentage
+	klog.V(1).InfoS("Rebalancing onto new nodes", "pool", pool, "newNodes", len(newNodes), "averageUtilization", average, "targetUtilization", target)
+
+	var destinationNodes []NodeInfo
+	for _, usage := range newNodes {
+		if utilizationScore(usage) >= float64(target) {
+			klog.V(2).InfoS("New node reached its target share", "node", klog.KObj(usage.node), "usagePercentage", resourceUsagePercentages(usage))
+			continue
+		}
+		destinationNodes = append(destinationNodes, NodeInfo{
+			NodeUsage:  usage,
+			thresholds: targetThresholds(usage.node, resourceNames, target),
+		})
+	}
+	if len(destinationNodes) == 0 {
+		return 0
+	}
+
+	var sourceNodes []NodeInfo
+	for _, usage := range oldNodes {
+		if utilizationScore(usage) > average {
+			sourceNodes = append(sourceNodes, NodeInfo{NodeUsage: usage})
+		}
+	}
+	if len(sourceNodes) == 0 {
+		return 0
+	}
+
+	// evictPodsFromSourceNodes computes the room left on the new nodes before the first call of the
+	// condition and takes a pod off it for every eviction, the pods moved are counted from it
+	var initialPods, remainingPods int64
+	recorded := false
+	// stop once the maximum number of pods is evicted, the source node drops to the average
+	// utilization or the new nodes reach their target share
+	continueEvictionCond := func(nodeInfo NodeInfo, totalAvailableUsage map[v1.ResourceName]*resource.Quantity) bool {
+		if !recorded {
+			initialPods = totalAvailableUsage[v1.ResourcePods].Value()
+			recorded = true
+		}
+		remainingPods = totalAvailableUsage[v1.ResourcePods].Value()
+		if uint(initialPods-remainingPods) >= maxPods {
+			return false
+		}
+		if utilizationScore(nodeInfo.NodeUsage) <= average {
+			return false
+		}
+		for name := range totalAvailableUsage {
+			if totalAvailableUsage[name].CmpInt64(0) < 1 {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Sort the nodes by the usage in descending order
+	sortNodesByUsage(sourceNodes, false)
+
+	evictPodsFromSourceNodes(
+		ctx,
+		r.args.EvictableNamespaces,
+		r.handle.SharedInformerFactory(),
+		sourceNodes,
+		destinationNodes,
+		r.handle.Evictor(),
+		evictions.EvictOptions{StrategyName: RebalanceOntoNewNodesPluginName},
+		r.podFilter,
+		resourceNames,
+		continueEvictionCond)
+
+	return uint(initialPods - remainingPods)
+}
+
+// isNew tells whether the node is younger than MaxNodeAgeSeconds. Nodes which are not ready
+// are never new when the age is computed from the Ready condition.
+func (r *RebalanceOntoNewNodes) isNew(node *v1.Node) bool {
+	since := node.CreationTimestamp.Time
+	if r.args.NodeAgeFrom == NodeAgeFromReady {
+		ready := false
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == v1.NodeReady && condition.Status == v1.ConditionTrue {
+				since = condition.LastTransitionTime.Time
+				ready = true
+			}
+		}
+		if !ready {
+			return false
+		}
+	}
+	return r.now().Sub(since) < time.Duration(*r.args.MaxNodeAgeSeconds)*time.Second
+}
+
+// utilizationScore returns the highest usage percentage among the resources of the node
+func utilizationScore(usage NodeUsage) float64 {
+	score := 0.0
+	for _, percentage := range resourceUsagePercentages(usage) {
+		if percentage > score {
+			score = percentage
+		}
+	}
+	return score
+}
+
+// targetThresholds returns the node capacity corresponding to the target utilization for every resource
+func targetThresholds(node *v1.Node, resourceNames []v1.ResourceName, target api.Percentage) NodeThresholds {
+	nodeCapacity := node.Status.Capacity
+	if len(node.Status.Allocatable) > 0 {
+		nodeCapacity = node.Status.Allocatable
+	}
+	thresholds := NodeThresholds{
+		lowResourceThreshold:  map[v1.ResourceName]*resource.Quantity{},
+		highResourceThreshold: map[v1.ResourceName]*resource.Quantity{},
+	}
+	for _, resourceName := range resourceNames {
+		thresholds.lowResourceThreshold[resourceName] = resourceThreshold(nodeCapacity, resourceName, target)
+		thresholds.highResourceThreshold[resourceName] = resourceThreshold(nodeCapacity, resourceName, target)
+	}
+	return thresholds
+}
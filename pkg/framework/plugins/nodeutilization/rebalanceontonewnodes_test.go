@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeutilization
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRebalanceOntoNewNodes(t *testing.T) {
+	now := time.Now()
+	const poolLabel = "example.com/pool"
+	buildNode := func(name string, created, ready time.Duration, pool string) *v1.Node {
+		return test.BuildTestNode(name, 2000, 3000, 10, func(node *v1.Node) {
+			node.CreationTimestamp = metav1.NewTime(now.Add(-created))
+			node.Labels[poolLabel] = pool
+			node.Status.Conditions = []v1.NodeCondition{
+				{Type: v1.NodeReady, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-ready))},
+			}
+		})
+	}
+	buildPods := func(node *v1.Node, count int) []*v1.Pod {
+		var pods []*v1.Pod
+		for i := 0; i < count; i++ {
+			pods = append(pods, test.BuildTestPod(fmt.Sprintf("pod_%d_%s", i, node.Name), 200, 0, node.Name, test.SetRSOwnerRef))
+		}
+		return pods
+	}
+
+	hot := buildNode("hot", 24*time.Hour, 24*time.Hour, "a")
+	warm := buildNode("warm", 24*time.Hour, 24*time.Hour, "a")
+	oldPods := append(buildPods(hot, 8), buildPods(warm, 4)...)
+
+	tests := []struct {
+		name              string
+		nodes             []*v1.Node
+		pods              []*v1.Pod
+		args              RebalanceOntoNewNodesArgs
+		evictionsExpected uint
+	}{
+		{
+			name:  "Evict pods from the most loaded node until it drops to the average",
+			nodes: []*v1.Node{hot, warm, buildNode("new", time.Minute, time.Minute, "a")},
+			pods:  oldPods,
+			// the average utilization of the old nodes is 60%, the hot node is at 80%
+			evictionsExpected: 2,
+		},
+		{
+			name:              "The number of evictions is bounded",
+			nodes:             []*v1.Node{hot, warm, buildNode("new", time.Minute, time.Minute, "a")},
+			pods:              oldPods,
+			args:              RebalanceOntoNewNodesArgs{MaxPodsToEvict: utilptr.To[uint](1)},
+			evictionsExpected: 1,
+		},
+		{
+			name:              "No eviction without new nodes",
+			nodes:             []*v1.Node{hot, warm, buildNode("new", time.Hour, time.Hour, "a")},
+			pods:              oldPods,
+			evictionsExpected: 0,
+		},
+		{
+			name:              "No eviction once the new node reached its target share",
+			nodes:             []*v1.Node{hot, warm, buildNode("new", time.Minute, time.Minute, "a")},
+			pods:              append(append([]*v1.Pod{}, oldPods...), buildPods(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "new"}}, 5)...),
+			evictionsExpected: 0,
+		},
+		{
+			name:              "New nodes of another pool are ignored",
+			nodes:             []*v1.Node{hot, warm, buildNode("new", time.Minute, time.Minute, "b")},
+			pods:              oldPods,
+			args:              RebalanceOntoNewNodesArgs{PoolLabel: poolLabel},
+			evictionsExpected: 0,
+		},
+		{
+			name:              "New nodes of the same pool are considered",
+			nodes:             []*v1.Node{hot, warm, buildNode("new", time.Minute, time.Minute, "a")},
+			pods:              oldPods,
+			args:              RebalanceOntoNewNodesArgs{PoolLabel: poolLabel},
+			evictionsExpected: 2,
+		},
+		{
+			name:              "The age of a node is computed from its Ready condition",
+			nodes:             []*v1.Node{hot, warm, buildNode("new", 24*time.Hour, time.Minute, "a")},
+			pods:              oldPods,
+			args:              RebalanceOntoNewNodesArgs{NodeAgeFrom: NodeAgeFromReady},
+			evictionsExpected: 2,
+		},
+	}
+
+	for _, item := range tests {
+		t.Run(item.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range item.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range item.pods {
+				objs = append(objs, pod)
+			}
+
+			fakeClient := fake.NewSimpleClientset(objs...)
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policy.SchemeGroupVersion.String()),
+				nil,
+				nil,
+				item.nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+			}
+
+			args := item.args
+			SetDefaults_RebalanceOntoNewNodesArgs(&args)
+			if err := ValidateRebalanceOntoNewNodesArgs(&args); err != nil {
+				t.Fatalf("Invalid args: %v", err)
+			}
+			plugin, err := NewRebalanceOntoNewNodes(&args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			plugin.(*RebalanceOntoNewNodes).now = func() time.Time { return now }
+
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, item.nodes)
+			if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != item.evictionsExpected {
+				t.Errorf("Expected %v evictions but got %v", item.evictionsExpected, podsEvicted)
+			}
+		})
+	}
+}
@@ -48,3 +48,27 @@ type HighNodeUtilizationArgs struct {
 	// but then filtered out before eviction
 	EvictableNamespaces *api.Namespaces `json:"evictableNamespaces"`
 }
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type RebalanceOntoNewNodesArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// MaxNodeAgeSeconds is the age below which a node is considered new
+	MaxNodeAgeSeconds *uint `json:"maxNodeAgeSeconds"`
+	// NodeAgeFrom is the point the age of a node is computed from, CreationTimestamp or Ready
+	NodeAgeFrom string `json:"nodeAgeFrom"`
+	// PoolLabel is the node label whose value identifies the pool of a node,
+	// all the nodes belong to the same pool when not set
+	PoolLabel string `json:"poolLabel"`
+	// TargetShare is the percentage of the average utilization of the other nodes
+	// of the pool a new node has to reach
+	TargetShare api.Percentage `json:"targetShare"`
+	// MaxPodsToEvict bounds the number of pods evicted in a descheduling cycle
+	MaxPodsToEvict *uint `json:"maxPodsToEvict"`
+	// Naming this one differently since namespaces are still
+	// considered while considering resources used by pods
+	// but then filtered out before eviction
+	EvictableNamespaces *api.Namespaces `json:"evictableNamespaces"`
+}
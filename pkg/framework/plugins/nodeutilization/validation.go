@@ -23,9 +23,12 @@ import (
 func ValidateHighNodeUtilizationArgs(obj runtime.Object) error {
 	args := obj.(*HighNodeUtilizationArgs)
 	// only exclude can be set, or not at all
-	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 {
+	if args.EvictableNamespaces != nil && (len(args.EvictableNamespaces.Include) > 0 || args.EvictableNamespaces.IncludeSelector != nil) {
 		return fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported")
 	}
+	if err := api.ValidateNamespaces(args.EvictableNamespaces); err != nil {
+		return err
+	}
 	err := validateThresholds(args.Thresholds)
 	if err != nil {
 		return err
@@ -37,9 +40,12 @@ func ValidateHighNodeUtilizationArgs(obj runtime.Object) error {
 func ValidateLowNodeUtilizationArgs(obj runtime.Object) error {
 	args := obj.(*LowNodeUtilizationArgs)
 	// only exclude can be set, or not at all
-	if args.EvictableNamespaces != nil && len(args.EvictableNamespaces.Include) > 0 {
+	if args.EvictableNamespaces != nil && (len(args.EvictableNamespaces.Include) > 0 || args.EvictableNamespaces.IncludeSelector != nil) {
 		return fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported")
 	}
+	if err := api.ValidateNamespaces(args.EvictableNamespaces); err != nil {
+		return err
+	}
 	err := validateLowNodeUtilizationThresholds(args.Thresholds, args.TargetThresholds, args.UseDeviationThresholds)
 	if err != nil {
 		return err
@@ -47,6 +53,27 @@ func ValidateLowNodeUtilizationArgs(obj runtime.Object) error {
 	return nil
 }
 
+func ValidateRebalanceOntoNewNodesArgs(obj runtime.Object) error {
+	args := obj.(*RebalanceOntoNewNodesArgs)
+	// only exclude can be set, or not at all
+	if args.EvictableNamespaces != nil && (len(args.EvictableNamespaces.Include) > 0 || args.EvictableNamespaces.IncludeSelector != nil) {
+		return fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported")
+	}
+	if err := api.ValidateNamespaces(args.EvictableNamespaces); err != nil {
+		return err
+	}
+	if args.NodeAgeFrom != "" && args.NodeAgeFrom != NodeAgeFromCreationTimestamp && args.NodeAgeFrom != NodeAgeFromReady {
+		return fmt.Errorf("nodeAgeFrom must be one of %v or %v", NodeAgeFromCreationTimestamp, NodeAgeFromReady)
+	}
+	if args.TargetShare <= MinResourcePercentage || args.TargetShare > MaxResourcePercentage {
+		return fmt.Errorf("targetShare not in (%v, %v] range", MinResourcePercentage, MaxResourcePercentage)
+	}
+	if args.MaxPodsToEvict != nil && *args.MaxPodsToEvict == 0 {
+		return fmt.Errorf("maxPodsToEvict must be greater than 0")
+	}
+	return nil
+}
+
 func validateLowNodeUtilizationThresholds(thresholds, targetThresholds api.ResourceThresholds, useDeviationThresholds bool) error {
 	// validate thresholds and targetThresholds config
 	if err := validateThresholds(thresholds); err != nil {
@@ -182,3 +182,43 @@ func TestValidateLowNodeUtilizationPluginConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRebalanceOntoNewNodesArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    *RebalanceOntoNewNodesArgs
+		errInfo error
+	}{
+		{
+			name:    "valid args",
+			args:    &RebalanceOntoNewNodesArgs{NodeAgeFrom: NodeAgeFromReady, TargetShare: 80},
+			errInfo: nil,
+		},
+		{
+			name:    "unknown node age reference",
+			args:    &RebalanceOntoNewNodesArgs{NodeAgeFrom: "Boot", TargetShare: 80},
+			errInfo: fmt.Errorf("nodeAgeFrom must be one of %v or %v", NodeAgeFromCreationTimestamp, NodeAgeFromReady),
+		},
+		{
+			name:    "target share out of range",
+			args:    &RebalanceOntoNewNodesArgs{TargetShare: 120},
+			errInfo: fmt.Errorf("targetShare not in (%v, %v] range", MinResourcePercentage, MaxResourcePercentage),
+		},
+		{
+			name:    "included namespaces",
+			args:    &RebalanceOntoNewNodesArgs{TargetShare: 80, EvictableNamespaces: &api.Namespaces{Include: []string{"default"}}},
+			errInfo: fmt.Errorf("only Exclude namespaces can be set, inclusion is not supported"),
+		},
+	}
+
+	for _, testCase := range tests {
+		validateErr := ValidateRebalanceOntoNewNodesArgs(testCase.args)
+		if validateErr == nil || testCase.errInfo == nil {
+			if validateErr != testCase.errInfo {
+				t.Errorf("%v: expected %v but got %v instead", testCase.name, testCase.errInfo, validateErr)
+			}
+		} else if validateErr.Error() != testCase.errInfo.Error() {
+			t.Errorf("%v: expected %v but got %v instead", testCase.name, testCase.errInfo, validateErr)
+		}
+	}
+}
@@ -106,3 +106,43 @@ func (in *LowNodeUtilizationArgs) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebalanceOntoNewNodesArgs) DeepCopyInto(out *RebalanceOntoNewNodesArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.MaxNodeAgeSeconds != nil {
+		in, out := &in.MaxNodeAgeSeconds, &out.MaxNodeAgeSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.MaxPodsToEvict != nil {
+		in, out := &in.MaxPodsToEvict, &out.MaxPodsToEvict
+		*out = new(uint)
+		**out = **in
+	}
+	if in.EvictableNamespaces != nil {
+		in, out := &in.EvictableNamespaces, &out.EvictableNamespaces
+		*out = new(api.Namespaces)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebalanceOntoNewNodesArgs.
+func (in *RebalanceOntoNewNodesArgs) DeepCopy() *RebalanceOntoNewNodesArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(RebalanceOntoNewNodesArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RebalanceOntoNewNodesArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
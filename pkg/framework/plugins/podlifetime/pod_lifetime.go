@@ -50,17 +50,10 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		return nil, fmt.Errorf("want args to be of type PodLifeTimeArgs, got %T", args)
 	}
 
-	var includedNamespaces, excludedNamespaces sets.Set[string]
-	if podLifeTimeArgs.Namespaces != nil {
-		includedNamespaces = sets.New(podLifeTimeArgs.Namespaces.Include...)
-		excludedNamespaces = sets.New(podLifeTimeArgs.Namespaces.Exclude...)
-	}
-
 	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
 	podFilter, err := podutil.NewOptions().
 		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
-		WithNamespaces(includedNamespaces).
-		WithoutNamespaces(excludedNamespaces).
+		WithNamespaceFilter(podLifeTimeArgs.Namespaces, handle.SharedInformerFactory()).
 		WithLabelSelector(podLifeTimeArgs.LabelSelector).
 		BuildFilterFunc()
 	if err != nil {
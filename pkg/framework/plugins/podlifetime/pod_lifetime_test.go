@@ -557,9 +557,7 @@ func TestPodLifeTime(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				policyv1.SchemeGroupVersion.String(),
-				false,
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
 				tc.maxPodsToEvictPerNode,
 				tc.maxPodsToEvictPerNamespace,
 				tc.nodes,
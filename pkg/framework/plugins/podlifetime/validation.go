@@ -24,6 +24,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/descheduler/pkg/api"
 )
 
 // ValidatePodLifeTimeArgs validates PodLifeTime arguments
@@ -37,6 +39,9 @@ func ValidatePodLifeTimeArgs(obj runtime.Object) error {
 	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
 		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
 	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		return err
+	}
 
 	if args.LabelSelector != nil {
 		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
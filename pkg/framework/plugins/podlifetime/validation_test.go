@@ -20,6 +20,9 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
 )
 
 func TestValidateRemovePodLifeTimeArgs(t *testing.T) {
@@ -58,6 +61,35 @@ func TestValidateRemovePodLifeTimeArgs(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			description: "namespace patterns and selectors, no errors",
+			args: &PodLifeTimeArgs{
+				MaxPodLifeTimeSeconds: func(i uint) *uint { return &i }(1),
+				Namespaces: &api.Namespaces{
+					Include:         []string{"team-*"},
+					ExcludeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"frozen": "true"}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			description: "invalid namespace pattern, expects errors",
+			args: &PodLifeTimeArgs{
+				MaxPodLifeTimeSeconds: func(i uint) *uint { return &i }(1),
+				Namespaces:            &api.Namespaces{Include: []string{"team-["}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid namespace selector, expects errors",
+			args: &PodLifeTimeArgs{
+				MaxPodLifeTimeSeconds: func(i uint) *uint { return &i }(1),
+				Namespaces: &api.Namespaces{
+					IncludeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Foo"}}},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -33,6 +33,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 )
@@ -68,17 +69,10 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		return nil, fmt.Errorf("want args to be of type RemoveDuplicatesArgs, got %T", args)
 	}
 
-	var includedNamespaces, excludedNamespaces sets.Set[string]
-	if removeDuplicatesArgs.Namespaces != nil {
-		includedNamespaces = sets.New(removeDuplicatesArgs.Namespaces.Include...)
-		excludedNamespaces = sets.New(removeDuplicatesArgs.Namespaces.Exclude...)
-	}
-
 	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
 	podFilter, err := podutil.NewOptions().
 		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
-		WithNamespaces(includedNamespaces).
-		WithoutNamespaces(excludedNamespaces).
+		WithNamespaceFilter(removeDuplicatesArgs.Namespaces, handle.SharedInformerFactory()).
 		BuildFilterFunc()
 	if err != nil {
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
@@ -100,6 +94,8 @@ func (r *RemoveDuplicates) Name() string {
 func (r *RemoveDuplicates) Balance(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
 	duplicatePods := make(map[podOwner]map[string][]*v1.Pod)
 	ownerKeyOccurence := make(map[podOwner]int32)
+	// all pods of an owner grouped by topology domain, only collected when balancing among topology domains
+	domainPods := make(map[podOwner]map[string][]*v1.Pod)
 	nodeCount := 0
 	nodeMap := make(map[string]*v1.Node)
 
@@ -147,6 +143,12 @@ func (r *RemoveDuplicates) Balance(ctx context.Context, nodes []*v1.Node) *frame
 					imagesHash: imagesHash,
 				}
 				ownerKeyOccurence[ownerKey] = ownerKeyOccurence[ownerKey] + 1
+				if domain, ok := node.Labels[r.args.TopologyKey]; ok && r.args.TopologyKey != "" {
+					if _, ok := domainPods[ownerKey]; !ok {
+						domainPods[ownerKey] = make(map[string][]*v1.Pod)
+					}
+					domainPods[ownerKey][domain] = append(domainPods[ownerKey][domain], pod)
+				}
 				for _, image := range imageList {
 					// Namespace/Kind/Name should be unique for the cluster.
 					// We also consider the image, as 2 pods could have the same owner but serve different purposes
@@ -190,6 +192,11 @@ func (r *RemoveDuplicates) Balance(ctx context.Context, nodes []*v1.Node) *frame
 		}
 	}
 
+	if r.args.TopologyKey != "" {
+		r.balanceTopologyDomains(ctx, nodes, nodeMap, domainPods)
+		return nil
+	}
+
 	// 1. how many pods can be evicted to respect uniform placement of pods among viable nodes?
 	for ownerKey, podNodes := range duplicatePods {
 
@@ -221,6 +228,70 @@ func (r *RemoveDuplicates) Balance(ctx context.Context, nodes []*v1.Node) *frame
 	return nil
 }
 
+// balanceTopologyDomains evicts pods of an owner from topology domains having more than the
+// average occurrence of the owner's pods among the feasible domains. A pod is only evicted
+// when it fits a node in a domain below the average, so it can land in another domain.
+func (r *RemoveDuplicates) balanceTopologyDomains(ctx context.Context, nodes []*v1.Node, nodeMap map[string]*v1.Node, domainPods map[podOwner]map[string][]*v1.Pod) {
+	// a pod with several owners is listed under each of them
+	evicted := sets.New[string]()
+	for ownerKey, podDomains := range domainPods {
+		targetDomains := sets.New[string]()
+		for _, node := range getTargetNodes(podDomains, nodes) {
+			if domain, ok := node.Labels[r.args.TopologyKey]; ok {
+				targetDomains.Insert(domain)
+			}
+		}
+		if targetDomains.Len() < 2 {
+			klog.V(1).InfoS("Less than two feasible topology domains for duplicates to land, skipping eviction", "owner", ownerKey)
+			continue
+		}
+
+		occurrence := 0
+		for _, pods := range podDomains {
+			occurrence += len(pods)
+		}
+		upperAvg := int(math.Ceil(float64(occurrence) / float64(targetDomains.Len())))
+		klog.V(2).InfoS("Average occurrence per topology domain", "ownerKey", ownerKey, "topologyKey", r.args.TopologyKey, "avg", upperAvg)
+
+		var underAvgNodes []*v1.Node
+		for _, node := range nodes {
+			if domain, ok := node.Labels[r.args.TopologyKey]; ok && targetDomains.Has(domain) && len(podDomains[domain]) < upperAvg {
+				underAvgNodes = append(underAvgNodes, node)
+			}
+		}
+
+		for _, domain := range sets.List(sets.KeySet(podDomains)) {
+			pods := podDomains[domain]
+			if len(pods) <= upperAvg {
+				continue
+			}
+			// evict from the nodes running the most pods of the owner first
+			podsPerNode := map[string]int{}
+			for _, pod := range pods {
+				podsPerNode[pod.Spec.NodeName]++
+			}
+			sort.SliceStable(pods, func(i, j int) bool {
+				return podsPerNode[pods[i].Spec.NodeName] < podsPerNode[pods[j].Spec.NodeName]
+			})
+			for _, pod := range pods[upperAvg:] {
+				if evicted.Has(klog.KObj(pod).String()) {
+					continue
+				}
+				if r.handle.Evictor().NodeLimitExceeded(nodeMap[pod.Spec.NodeName]) {
+					continue
+				}
+				if !nodeutil.PodFitsAnyNode(r.handle.GetPodsAssignedToNodeFunc(), pod, underAvgNodes) {
+					klog.V(2).InfoS("Pod does not fit any node in another topology domain, skipping eviction", "pod", klog.KObj(pod), "domain", domain)
+					continue
+				}
+				if r.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName}) {
+					evicted.Insert(klog.KObj(pod).String())
+				}
+			}
+		}
+	}
+}
+
 func getTargetNodes(podNodes map[string][]*v1.Pod, nodes []*v1.Node) []*v1.Node {
 	// In order to reduce the number of pods processed, identify pods which have
 	// equal (tolerations, nodeselectors, node affinity) terms and considered them
@@ -18,6 +18,7 @@ package removeduplicates
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"k8s.io/client-go/tools/events"
@@ -314,9 +315,7 @@ func TestFindDuplicatePods(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				"v1",
-				false,
+				evictions.NewEvictionAPIBackend(fakeClient, "v1"),
 				nil,
 				nil,
 				testCase.nodes,
@@ -763,9 +762,7 @@ func TestRemoveDuplicatesUniformly(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				policyv1.SchemeGroupVersion.String(),
-				false,
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
 				nil,
 				nil,
 				testCase.nodes,
@@ -815,3 +812,163 @@ func TestRemoveDuplicatesUniformly(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveDuplicatesAmongTopologyDomains(t *testing.T) {
+	const zoneKey = "topology.kubernetes.io/zone"
+	buildZoneNode := func(name, zone string, milliCPU int64) *v1.Node {
+		return test.BuildTestNode(name, milliCPU, 3000, 10, func(node *v1.Node) {
+			if zone != "" {
+				node.ObjectMeta.Labels = map[string]string{zoneKey: zone}
+			}
+		})
+	}
+	buildPods := func(nodeName string, count int) []*v1.Pod {
+		var pods []*v1.Pod
+		for i := 0; i < count; i++ {
+			pods = append(pods, test.BuildTestPod(fmt.Sprintf("%s-p%d", nodeName, i), 100, 0, nodeName, test.SetRSOwnerRef))
+		}
+		return pods
+	}
+	concat := func(podLists ...[]*v1.Pod) []*v1.Pod {
+		var pods []*v1.Pod
+		for _, list := range podLists {
+			pods = append(pods, list...)
+		}
+		return pods
+	}
+
+	testCases := []struct {
+		description             string
+		nodes                   []*v1.Node
+		pods                    []*v1.Pod
+		topologyKey             string
+		expectedEvictedPodCount uint
+	}{
+		{
+			description: "pods balanced among nodes but not among zones",
+			// zones (6,0) -> (3,3) -> 3 evictions
+			nodes: []*v1.Node{
+				buildZoneNode("n1", "zone1", 2000),
+				buildZoneNode("n2", "zone1", 2000),
+				buildZoneNode("n3", "zone1", 2000),
+				buildZoneNode("n4", "zone2", 2000),
+			},
+			pods:                    concat(buildPods("n1", 2), buildPods("n2", 2), buildPods("n3", 2)),
+			topologyKey:             zoneKey,
+			expectedEvictedPodCount: 3,
+		},
+		{
+			description: "pods balanced among nodes without topology key",
+			nodes: []*v1.Node{
+				buildZoneNode("n1", "zone1", 2000),
+				buildZoneNode("n2", "zone1", 2000),
+				buildZoneNode("n3", "zone1", 2000),
+				buildZoneNode("n4", "zone2", 2000),
+			},
+			pods:                    concat(buildPods("n1", 2), buildPods("n2", 2), buildPods("n3", 2)),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "evict from the over-represented zone only",
+			// zones (5,1,0) -> (2,2,2) -> 3 evictions
+			nodes: []*v1.Node{
+				buildZoneNode("n1", "zone1", 2000),
+				buildZoneNode("n2", "zone1", 2000),
+				buildZoneNode("n3", "zone2", 2000),
+				buildZoneNode("n4", "zone3", 2000),
+			},
+			pods:                    concat(buildPods("n1", 3), buildPods("n2", 2), buildPods("n3", 1)),
+			topologyKey:             zoneKey,
+			expectedEvictedPodCount: 3,
+		},
+		{
+			description: "nodes without the topology label are not a domain",
+			nodes: []*v1.Node{
+				buildZoneNode("n1", "zone1", 2000),
+				buildZoneNode("n2", "zone1", 2000),
+				buildZoneNode("n3", "", 2000),
+			},
+			pods:                    concat(buildPods("n1", 2), buildPods("n2", 2)),
+			topologyKey:             zoneKey,
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "pods do not fit any node in the under-represented zone",
+			nodes: []*v1.Node{
+				buildZoneNode("n1", "zone1", 2000),
+				buildZoneNode("n2", "zone1", 2000),
+				buildZoneNode("n3", "zone2", 50),
+			},
+			pods:                    concat(buildPods("n1", 2), buildPods("n2", 2)),
+			topologyKey:             zoneKey,
+			expectedEvictedPodCount: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range testCase.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range testCase.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				nil,
+				nil,
+				testCase.nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+			}
+
+			plugin, err := New(&RemoveDuplicatesArgs{TopologyKey: testCase.topologyKey}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.BalancePlugin).Balance(ctx, testCase.nodes)
+			actualEvictedPodCount := podEvictor.TotalEvicted()
+			if actualEvictedPodCount != testCase.expectedEvictedPodCount {
+				t.Errorf("Test %#v failed, Unexpected no of pods evicted: pods evicted: %d, expected: %d", testCase.description, actualEvictedPodCount, testCase.expectedEvictedPodCount)
+			}
+		})
+	}
+}
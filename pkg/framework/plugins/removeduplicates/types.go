@@ -26,4 +26,7 @@ type RemoveDuplicatesArgs struct {
 
 	Namespaces        *api.Namespaces `json:"namespaces"`
 	ExcludeOwnerKinds []string        `json:"excludeOwnerKinds"`
+	// TopologyKey balances duplicates among the topology domains given by the
+	// node label (e.g. topology.kubernetes.io/zone) instead of among nodes.
+	TopologyKey string `json:"topologyKey,omitempty"`
 }
@@ -17,6 +17,9 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"sigs.k8s.io/descheduler/pkg/api"
 )
 
 func ValidateRemoveDuplicatesArgs(obj runtime.Object) error {
@@ -25,6 +28,14 @@ func ValidateRemoveDuplicatesArgs(obj runtime.Object) error {
 	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
 		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
 	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		return err
+	}
+	if args.TopologyKey != "" {
+		if errs := validation.IsQualifiedName(args.TopologyKey); len(errs) > 0 {
+			return fmt.Errorf("invalid topologyKey %q: %v", args.TopologyKey, errs)
+		}
+	}
 
 	return nil
 }
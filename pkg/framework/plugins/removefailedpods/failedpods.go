@@ -50,17 +50,10 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		return nil, fmt.Errorf("want args to be of type RemoveFailedPodsArgs, got %T", args)
 	}
 
-	var includedNamespaces, excludedNamespaces sets.Set[string]
-	if failedPodsArgs.Namespaces != nil {
-		includedNamespaces = sets.New(failedPodsArgs.Namespaces.Include...)
-		excludedNamespaces = sets.New(failedPodsArgs.Namespaces.Exclude...)
-	}
-
 	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
 	podFilter, err := podutil.NewOptions().
 		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
-		WithNamespaces(includedNamespaces).
-		WithoutNamespaces(excludedNamespaces).
+		WithNamespaceFilter(failedPodsArgs.Namespaces, handle.SharedInformerFactory()).
 		WithLabelSelector(failedPodsArgs.LabelSelector).
 		BuildFilterFunc()
 	if err != nil {
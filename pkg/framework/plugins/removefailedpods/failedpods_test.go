@@ -290,9 +290,7 @@ func TestRemoveFailedPods(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				policyv1.SchemeGroupVersion.String(),
-				false,
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
 				nil,
 				nil,
 				tc.nodes,
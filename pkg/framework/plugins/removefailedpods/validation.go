@@ -18,6 +18,8 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/descheduler/pkg/api"
 )
 
 // ValidateRemoveFailedPodsArgs validates RemoveFailedPods arguments
@@ -27,6 +29,9 @@ func ValidateRemoveFailedPodsArgs(obj runtime.Object) error {
 	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
 		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
 	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		return err
+	}
 
 	if args.LabelSelector != nil {
 		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
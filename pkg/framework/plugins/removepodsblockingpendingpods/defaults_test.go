@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsblockingpendingpods
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_RemovePodsBlockingPendingPodsArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *RemovePodsBlockingPendingPodsArgs
+		want *RemovePodsBlockingPendingPodsArgs
+	}{
+		{
+			name: "RemovePodsBlockingPendingPodsArgs empty",
+			in:   &RemovePodsBlockingPendingPodsArgs{},
+			want: &RemovePodsBlockingPendingPodsArgs{
+				MinPendingSeconds: utilptr.To[uint](300),
+				MaxVictimsPerPod:  utilptr.To[uint](3),
+			},
+		},
+		{
+			name: "RemovePodsBlockingPendingPodsArgs with value",
+			in: &RemovePodsBlockingPendingPodsArgs{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"foo": "bar"},
+				},
+				MinPendingSeconds: utilptr.To[uint](60),
+				MaxVictimsPerPod:  utilptr.To[uint](1),
+			},
+			want: &RemovePodsBlockingPendingPodsArgs{
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"foo": "bar"},
+				},
+				MinPendingSeconds: utilptr.To[uint](60),
+				MaxVictimsPerPod:  utilptr.To[uint](1),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemovePodsBlockingPendingPodsArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsblockingpendingpods
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemovePodsBlockingPendingPods"
+
+// RemovePodsBlockingPendingPods evicts the smallest set of lower or equal priority pods from a
+// node so that an unschedulable pending pod fits the node, as long as the evicted pods fit
+// elsewhere. It covers fragmented clusters where scheduler preemption does not apply.
+type RemovePodsBlockingPendingPods struct {
+	handle    frameworktypes.Handle
+	args      *RemovePodsBlockingPendingPodsArgs
+	podFilter podutil.FilterFunc
+	podLister listersv1.PodLister
+	now       func() time.Time
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsBlockingPendingPods{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	pendingPodsArgs, ok := args.(*RemovePodsBlockingPendingPodsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsBlockingPendingPodsArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithNamespaceFilter(pendingPodsArgs.Namespaces, handle.SharedInformerFactory()).
+		WithLabelSelector(pendingPodsArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsBlockingPendingPods{
+		handle:    handle,
+		args:      pendingPodsArgs,
+		podFilter: podFilter,
+		podLister: handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+		now:       time.Now,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsBlockingPendingPods) Name() string {
+	return PluginName
+}
+
+func (d *RemovePodsBlockingPendingPods) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	pendingPods, err := d.pendingPods()
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing pending pods: %v", err),
+		}
+	}
+	if len(pendingPods) == 0 {
+		return nil
+	}
+
+	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
+	if snapshot := d.handle.NodeSnapshot(); snapshot != nil {
+		getPodsAssignedToNode = snapshot.GetPodsAssignedToNode
+	}
+	// the simulation keeps track of the evictions made for the previous pending pods
+	sim := newSimulation(getPodsAssignedToNode)
+	for _, pending := range pendingPods {
+		if nodeutil.PodFitsAnyNode(sim.getPodsAssignedToNode, pending, nodes) {
+			klog.V(3).InfoS("Pending pod fits a node without evictions", "pod", klog.KObj(pending))
+			continue
+		}
+
+		var best *plan
+		for _, node := range nodes {
+			if d.handle.Evictor().NodeLimitExceeded(node) {
+				continue
+			}
+			p, err := d.planForNode(sim, pending, node, nodes)
+			if err != nil {
+				return &frameworktypes.Status{
+					Err: fmt.Errorf("error listing pods on a node: %v", err),
+				}
+			}
+			if p != nil && (best == nil || p.better(best)) {
+				best = p
+			}
+		}
+		if best == nil {
+			klog.V(2).InfoS("No node can be freed up for the pending pod", "pod", klog.KObj(pending))
+			continue
+		}
+		if d.evict(ctx, pending, best) {
+			sim = best.sim
+		}
+	}
+	return nil
+}
+
+// pendingPods lists the pods the scheduler failed to schedule for at least MinPendingSeconds,
+// the pods with the highest priority first. Pods the scheduler nominated a node for are
+// already handled by scheduler preemption.
+func (d *RemovePodsBlockingPendingPods) pendingPods() ([]*v1.Pod, error) {
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	minPending := time.Duration(*d.args.MinPendingSeconds) * time.Second
+	var pendingPods []*v1.Pod
+	for _, pod := range pods {
+		if pod.Spec.NodeName != "" || pod.DeletionTimestamp != nil || pod.Status.NominatedNodeName != "" {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionFalse && condition.Reason == v1.PodReasonUnschedulable &&
+				d.now().Sub(condition.LastTransitionTime.Time) >= minPending {
+				pendingPods = append(pendingPods, pod)
+			}
+		}
+	}
+	sort.SliceStable(pendingPods, func(i, j int) bool {
+		if priority(pendingPods[i]) != priority(pendingPods[j]) {
+			return priority(pendingPods[i]) > priority(pendingPods[j])
+		}
+		return pendingPods[i].CreationTimestamp.Before(&pendingPods[j].CreationTimestamp)
+	})
+	return pendingPods, nil
+}
+
+// plan describes the pods to evict from a node to make room for a pending pod and the state
+// of the cluster once the pending pod and the victims are placed.
+type plan struct {
+	node    *v1.Node
+	victims []*v1.Pod
+	sim     *simulation
+}
+
+// better prefers the plan with fewer victims, then the one with lower victim priorities.
+func (p *plan) better(other *plan) bool {
+	if len(p.victims) != len(other.victims) {
+		return len(p.victims) < len(other.victims)
+	}
+	return highestPriority(p.victims) < highestPriority(other.victims)
+}
+
+// planForNode computes a minimal set of victims on the node whose removal lets the pending pod
+// fit, in the same way the scheduler does for preemption: all the candidates are removed first
+// and then reprieved one by one, the highest priority first, as long as the pending pod still fits.
+func (d *RemovePodsBlockingPendingPods) planForNode(sim *simulation, pending *v1.Pod, node *v1.Node, nodes []*v1.Node) (*plan, error) {
+	pods, err := sim.getPodsAssignedToNode(node.Name, nil)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*v1.Pod
+	for _, pod := range pods {
+		if sim.placed(pod) || priority(pod) > priority(pending) {
+			continue
+		}
+		if d.podFilter(pod) && d.handle.Evictor().Filter(pod) {
+			candidates = append(candidates, pod)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	trial := sim.fork()
+	trial.remove(candidates...)
+	if !nodeutil.PodFitsCurrentNode(trial.getPodsAssignedToNode, pending, node, nodeutil.WithNodes(nodes)) {
+		return nil, nil
+	}
+
+	podutil.SortPodsBasedOnPriorityLowToHigh(candidates)
+	var victims []*v1.Pod
+	for i := len(candidates) - 1; i >= 0; i-- {
+		trial.restore(candidates[i])
+		if !nodeutil.PodFitsCurrentNode(trial.getPodsAssignedToNode, pending, node, nodeutil.WithNodes(nodes)) {
+			trial.remove(candidates[i])
+			victims = append(victims, candidates[i])
+		}
+	}
+	if uint(len(victims)) > *d.args.MaxVictimsPerPod {
+		klog.V(3).InfoS("Too many pods to evict to make room for the pending pod", "pod", klog.KObj(pending), "node", klog.KObj(node), "victims", len(victims))
+		return nil, nil
+	}
+
+	// the victims have to fit elsewhere once the pending pod takes their place
+	trial.place(pending, node.Name)
+	for _, victim := range victims {
+		placed := false
+		for _, other := range nodes {
+			if other.Name == node.Name {
+				continue
+			}
+			if len(nodeutil.NodeFit(trial.getPodsAssignedToNode, victim, other, nodeutil.WithNodes(nodes))) == 0 {
+				trial.place(victim, other.Name)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			klog.V(3).InfoS("Pod evicted for the pending pod would not fit any other node", "pod", klog.KObj(victim), "pending", klog.KObj(pending), "node", klog.KObj(node))
+			return nil, nil
+		}
+	}
+	return &plan{node: node, victims: victims, sim: trial}, nil
+}
+
+// evict evicts the victims of the plan when all of them pass the pre-eviction filters and
+// the eviction limits allow it, and returns whether all the victims were evicted.
+func (d *RemovePodsBlockingPendingPods) evict(ctx context.Context, pending *v1.Pod, p *plan) bool {
+	for _, victim := range p.victims {
+		if !d.handle.Evictor().PreEvictionFilter(victim) {
+			return false
+		}
+	}
+	if !d.handle.Evictor().EvictionsAllowed(p.victims) {
+		klog.V(2).InfoS("Eviction limits do not allow making room for the pending pod", "pod", klog.KObj(pending), "node", klog.KObj(p.node), "victims", len(p.victims))
+		return false
+	}
+	klog.V(1).InfoS("Evicting pods to make room for a pending pod", "pod", klog.KObj(pending), "node", klog.KObj(p.node), "victims", len(p.victims))
+	for _, victim := range p.victims {
+		if !d.handle.Evictor().Evict(ctx, victim, evictions.EvictOptions{StrategyName: PluginName}) {
+			return false
+		}
+	}
+	return true
+}
+
+// simulation overlays pods removed from and placed onto nodes over the pods assigned to nodes.
+type simulation struct {
+	podsAssignedToNode podutil.GetPodsAssignedToNodeFunc
+	// removed holds the removed pods keyed by their node and UID, the pods placed elsewhere keep their UID
+	removed map[string]bool
+	added   map[string][]*v1.Pod
+}
+
+func newSimulation(getPodsAssignedToNode podutil.GetPodsAssignedToNodeFunc) *simulation {
+	return &simulation{
+		podsAssignedToNode: getPodsAssignedToNode,
+		removed:            map[string]bool{},
+		added:              map[string][]*v1.Pod{},
+	}
+}
+
+func (s *simulation) fork() *simulation {
+	sim := newSimulation(s.podsAssignedToNode)
+	for key := range s.removed {
+		sim.removed[key] = true
+	}
+	for nodeName, pods := range s.added {
+		sim.added[nodeName] = append([]*v1.Pod{}, pods...)
+	}
+	return sim
+}
+
+func (s *simulation) remove(pods ...*v1.Pod) {
+	for _, pod := range pods {
+		s.removed[removedKey(pod)] = true
+	}
+}
+
+func (s *simulation) restore(pod *v1.Pod) {
+	delete(s.removed, removedKey(pod))
+}
+
+func removedKey(pod *v1.Pod) string {
+	return pod.Spec.NodeName + "/" + string(pod.UID)
+}
+
+func (s *simulation) place(pod *v1.Pod, nodeName string) {
+	placed := pod.DeepCopy()
+	placed.Spec.NodeName = nodeName
+	s.added[nodeName] = append(s.added[nodeName], placed)
+}
+
+// placed tells whether the pod only exists in the simulation
+func (s *simulation) placed(pod *v1.Pod) bool {
+	for _, added := range s.added[pod.Spec.NodeName] {
+		if added == pod {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *simulation) getPodsAssignedToNode(nodeName string, filter podutil.FilterFunc) ([]*v1.Pod, error) {
+	pods, err := s.podsAssignedToNode(nodeName, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result []*v1.Pod
+	for _, pod := range append(append([]*v1.Pod{}, pods...), s.added[nodeName]...) {
+		if s.removed[removedKey(pod)] {
+			continue
+		}
+		if filter == nil || filter(pod) {
+			result = append(result, pod)
+		}
+	}
+	return result, nil
+}
+
+func priority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority != nil {
+		return *pod.Spec.Priority
+	}
+	return 0
+}
+
+func highestPriority(pods []*v1.Pod) int32 {
+	var highest int32
+	for i, pod := range pods {
+		if i == 0 || priority(pod) > highest {
+			highest = priority(pod)
+		}
+	}
+	return highest
+}
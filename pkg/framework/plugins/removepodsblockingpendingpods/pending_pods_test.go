@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsblockingpendingpods
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildPod(name string, cpu int64, nodeName string, priority int32) *v1.Pod {
+	return test.BuildTestPod(name, cpu, 0, nodeName, func(pod *v1.Pod) {
+		pod.UID = types.UID(name)
+		pod.OwnerReferences = test.GetNormalPodOwnerRefList()
+		pod.Spec.Priority = utilptr.To(priority)
+	})
+}
+
+func buildPendingPod(name string, cpu int64, pendingFor time.Duration, apply func(*v1.Pod)) *v1.Pod {
+	return test.BuildTestPod(name, cpu, 0, "", func(pod *v1.Pod) {
+		pod.UID = types.UID(name)
+		pod.Status.Phase = v1.PodPending
+		pod.Status.Conditions = []v1.PodCondition{
+			{
+				Type:               v1.PodScheduled,
+				Status:             v1.ConditionFalse,
+				Reason:             v1.PodReasonUnschedulable,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-pendingFor)),
+			},
+		}
+		if apply != nil {
+			apply(pod)
+		}
+	})
+}
+
+func TestRemovePodsBlockingPendingPods(t *testing.T) {
+	n1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	n2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+	n3 := test.BuildTestNode("n3", 2000, 3000, 10, nil)
+
+	fragmentedPods := []*v1.Pod{
+		buildPod("a", 600, n1.Name, 0),
+		buildPod("b", 600, n1.Name, 0),
+		buildPod("c", 600, n2.Name, 0),
+		buildPod("d", 600, n3.Name, 0),
+	}
+	highPriorityPods := []*v1.Pod{
+		buildPod("a", 600, n1.Name, 0),
+		buildPod("b", 600, n1.Name, 0),
+		buildPod("c", 600, n2.Name, 100),
+		buildPod("d", 600, n3.Name, 100),
+	}
+
+	tests := []struct {
+		description             string
+		nodes                   []*v1.Node
+		pods                    []*v1.Pod
+		maxVictimsPerPod        *uint
+		maxPodsToEvictPerNode   *uint
+		expectedEvictedPodCount uint
+		expectedEvictedPods     []string
+	}{
+		{
+			description:             "Evict the single pod blocking the pending pod",
+			nodes:                   []*v1.Node{n1, n2, n3},
+			pods:                    append(fragmentedPods, buildPendingPod("pending", 1500, time.Hour, nil)),
+			expectedEvictedPodCount: 1,
+			expectedEvictedPods:     []string{"c"},
+		},
+		{
+			description:             "Pods with a higher priority than the pending pod are not evicted",
+			nodes:                   []*v1.Node{n1, n2, n3},
+			pods:                    append(highPriorityPods, buildPendingPod("pending", 1500, time.Hour, nil)),
+			expectedEvictedPodCount: 2,
+			expectedEvictedPods:     []string{"a", "b"},
+		},
+		{
+			description:             "Nodes are not freed up when the eviction limits do not allow all the evictions",
+			nodes:                   []*v1.Node{n1, n2, n3},
+			pods:                    append(highPriorityPods, buildPendingPod("pending", 1500, time.Hour, nil)),
+			maxPodsToEvictPerNode:   utilptr.To[uint](1),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Nodes requiring too many evictions are skipped",
+			nodes:                   []*v1.Node{n1, n2, n3},
+			pods:                    append(highPriorityPods, buildPendingPod("pending", 1500, time.Hour, nil)),
+			maxVictimsPerPod:        utilptr.To[uint](1),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Pods are not evicted when they do not fit another node",
+			nodes:       []*v1.Node{n1, n2},
+			pods: []*v1.Pod{
+				buildPod("a", 600, n1.Name, 0),
+				buildPod("b", 600, n1.Name, 0),
+				buildPod("c", 1200, n2.Name, 0),
+				buildPendingPod("pending", 1500, time.Hour, nil),
+			},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Pods pending for a short time are ignored",
+			nodes:                   []*v1.Node{n1, n2, n3},
+			pods:                    append(fragmentedPods, buildPendingPod("pending", 1500, time.Minute, nil)),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Pods nominated by scheduler preemption are ignored",
+			nodes:       []*v1.Node{n1, n2, n3},
+			pods: append(fragmentedPods, buildPendingPod("pending", 1500, time.Hour, func(pod *v1.Pod) {
+				pod.Status.NominatedNodeName = n1.Name
+			})),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Pending pods fitting a node are left to the scheduler",
+			nodes:                   []*v1.Node{n1, n2, n3},
+			pods:                    append(fragmentedPods, buildPendingPod("pending", 500, time.Hour, nil)),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Evictions account for the room made for previous pending pods",
+			nodes:       []*v1.Node{n1, n2, n3},
+			pods: append(fragmentedPods,
+				buildPendingPod("pending-1", 1500, time.Hour, nil),
+				buildPendingPod("pending-2", 1500, time.Hour, nil),
+			),
+			maxVictimsPerPod:        utilptr.To[uint](1),
+			expectedEvictedPodCount: 1,
+			expectedEvictedPods:     []string{"c"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			var evictedPods []string
+			fakeClient.PrependReactor("create", "pods", podEvictionReactionFunc(&evictedPods))
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				tc.maxPodsToEvictPerNode,
+				nil,
+				tc.nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+			}
+			args := &RemovePodsBlockingPendingPodsArgs{MaxVictimsPerPod: tc.maxVictimsPerPod}
+			SetDefaults_RemovePodsBlockingPendingPodsArgs(args)
+			if err := ValidateRemovePodsBlockingPendingPodsArgs(args); err != nil {
+				t.Fatalf("Invalid args: %v", err)
+			}
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != tc.expectedEvictedPodCount {
+				t.Errorf("Unexpected no of pods evicted: pods evicted: %d, expected: %d", podsEvicted, tc.expectedEvictedPodCount)
+			}
+			sort.Strings(evictedPods)
+			if tc.expectedEvictedPods != nil && !reflect.DeepEqual(evictedPods, tc.expectedEvictedPods) {
+				t.Errorf("Unexpected pods evicted: %v, expected: %v", evictedPods, tc.expectedEvictedPods)
+			}
+		})
+	}
+}
+
+func podEvictionReactionFunc(evictedPods *[]string) func(action core.Action) (bool, runtime.Object, error) {
+	return func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" {
+			createAct, matched := action.(core.CreateActionImpl)
+			if !matched {
+				return false, nil, fmt.Errorf("unable to convert action to core.CreateActionImpl")
+			}
+			if eviction, matched := createAct.Object.(*policyv1.Eviction); matched {
+				*evictedPods = append(*evictedPods, eviction.GetName())
+			}
+		}
+		return false, nil, nil // fallback to the default reactor
+	}
+}
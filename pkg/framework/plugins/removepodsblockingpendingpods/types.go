@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsblockingpendingpods
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsBlockingPendingPodsArgs holds arguments used to configure RemovePodsBlockingPendingPods plugin.
+type RemovePodsBlockingPendingPodsArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Namespaces and LabelSelector restrict the pods evicted to make room for pending pods
+	Namespaces    *api.Namespaces       `json:"namespaces"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+	// MinPendingSeconds is the time a pod has to be unschedulable before room is made for it, defaults to 5 minutes.
+	MinPendingSeconds *uint `json:"minPendingSeconds"`
+	// MaxVictimsPerPod caps the number of pods evicted to make room for a single pending pod, defaults to 3.
+	MaxVictimsPerPod *uint `json:"maxVictimsPerPod"`
+}
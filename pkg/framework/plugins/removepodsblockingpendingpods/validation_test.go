@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsblockingpendingpods
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsBlockingPendingPodsArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *RemovePodsBlockingPendingPodsArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &RemovePodsBlockingPendingPodsArgs{
+				MinPendingSeconds: utilptr.To[uint](0),
+				MaxVictimsPerPod:  utilptr.To[uint](1),
+			},
+			expectError: false,
+		},
+		{
+			description: "unset args, no errors",
+			args:        &RemovePodsBlockingPendingPodsArgs{},
+			expectError: false,
+		},
+		{
+			description: "zero maxVictimsPerPod, expects errors",
+			args: &RemovePodsBlockingPendingPodsArgs{
+				MaxVictimsPerPod: utilptr.To[uint](0),
+			},
+			expectError: true,
+		},
+		{
+			description: "namespace include and exclude, expects errors",
+			args: &RemovePodsBlockingPendingPodsArgs{
+				Namespaces: &api.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects errors",
+			args: &RemovePodsBlockingPendingPodsArgs{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Foo"}},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsBlockingPendingPodsArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Errorf("unexpected arg validation behavior: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfrommaintenancenodes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemovePodsFromMaintenanceNodes"
+
+// RemovePodsFromMaintenanceNodes gradually evicts pods from nodes scheduled for maintenance or
+// reclamation, e.g. spot nodes, before the nodes go away.
+type RemovePodsFromMaintenanceNodes struct {
+	handle       frameworktypes.Handle
+	args         *RemovePodsFromMaintenanceNodesArgs
+	podFilter    podutil.FilterFunc
+	nodeSelector labels.Selector
+	now          func() time.Time
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsFromMaintenanceNodes{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	maintenanceArgs, ok := args.(*RemovePodsFromMaintenanceNodesArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsFromMaintenanceNodesArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithNamespaceFilter(maintenanceArgs.Namespaces, handle.SharedInformerFactory()).
+		WithLabelSelector(maintenanceArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	var nodeSelector labels.Selector
+	if maintenanceArgs.NodeSelector != nil {
+		nodeSelector, err = metav1.LabelSelectorAsSelector(maintenanceArgs.NodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing node selector: %v", err)
+		}
+	}
+
+	return &RemovePodsFromMaintenanceNodes{
+		handle:       handle,
+		args:         maintenanceArgs,
+		podFilter:    podFilter,
+		nodeSelector: nodeSelector,
+		now:          time.Now,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsFromMaintenanceNodes) Name() string {
+	return PluginName
+}
+
+func (d *RemovePodsFromMaintenanceNodes) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	var maintenanceNodes, otherNodes []*v1.Node
+	for _, node := range nodes {
+		if d.scheduledForMaintenance(node) {
+			maintenanceNodes = append(maintenanceNodes, node)
+		} else {
+			otherNodes = append(otherNodes, node)
+		}
+	}
+
+	var options []nodeutil.NodeFitOption
+	if snapshot := d.handle.NodeSnapshot(); snapshot != nil {
+		options = append(options, nodeutil.WithSnapshot(snapshot))
+	}
+	for _, node := range maintenanceNodes {
+		klog.V(1).InfoS("Processing node scheduled for maintenance", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), podutil.WrapFilterFuncs(d.podFilter, d.handle.Evictor().Filter))
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+
+		limit := d.evictionLimit(node, len(pods))
+		// evict the pods with the lowest priority first
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+		evicted := 0
+		for _, pod := range pods {
+			if evicted >= limit || d.handle.Evictor().NodeLimitExceeded(node) {
+				break
+			}
+			// the pods have to be able to move to a node which is not going away
+			if !nodeutil.PodFitsAnyNode(d.handle.GetPodsAssignedToNodeFunc(), pod, otherNodes, options...) {
+				klog.V(2).InfoS("Pod does not fit any node not scheduled for maintenance", "pod", klog.KObj(pod))
+				continue
+			}
+			if !d.handle.Evictor().PreEvictionFilter(pod) {
+				continue
+			}
+			if d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName}) {
+				evicted++
+			}
+		}
+	}
+	return nil
+}
+
+// scheduledForMaintenance tells whether the node matches the node selector or has one of the annotations
+func (d *RemovePodsFromMaintenanceNodes) scheduledForMaintenance(node *v1.Node) bool {
+	if d.nodeSelector != nil && d.nodeSelector.Matches(labels.Set(node.Labels)) {
+		return true
+	}
+	if _, ok := node.Annotations[d.args.NodeAnnotation]; ok && d.args.NodeAnnotation != "" {
+		return true
+	}
+	if _, ok := node.Annotations[d.args.DeadlineAnnotation]; ok && d.args.DeadlineAnnotation != "" {
+		return true
+	}
+	return false
+}
+
+// evictionLimit returns the number of pods to evict from the node in this cycle. Within the
+// urgency window before the deadline of the node the limit increases proportionally to the
+// elapsed part of the window, up to all the pods once the deadline is reached.
+func (d *RemovePodsFromMaintenanceNodes) evictionLimit(node *v1.Node, pods int) int {
+	limit := int(*d.args.MaxPodsToEvictPerNode)
+	value, ok := node.Annotations[d.args.DeadlineAnnotation]
+	if !ok || d.args.DeadlineAnnotation == "" {
+		return limit
+	}
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		klog.ErrorS(err, "Unable to parse the deadline of the node", "node", klog.KObj(node), "annotation", d.args.DeadlineAnnotation)
+		return limit
+	}
+
+	remaining := deadline.Sub(d.now())
+	window := time.Duration(*d.args.UrgencyWindowSeconds) * time.Second
+	if remaining <= 0 || window == 0 {
+		return pods
+	}
+	if remaining >= window {
+		return limit
+	}
+	urgent := int(math.Ceil(float64(pods) * float64(window-remaining) / float64(window)))
+	klog.V(2).InfoS("Node deadline is approaching", "node", klog.KObj(node), "deadline", deadline, "limit", urgent)
+	if urgent > limit {
+		return urgent
+	}
+	return limit
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfrommaintenancenodes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+const (
+	maintenanceLabel   = "example.com/maintenance"
+	drainAnnotation    = "example.com/drain"
+	deadlineAnnotation = "example.com/terminates-at"
+)
+
+func TestRemovePodsFromMaintenanceNodes(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	maintenanceNode := func(apply func(*v1.Node)) *v1.Node {
+		return test.BuildTestNode("m1", 2000, 3000, 10, apply)
+	}
+	withDeadline := func(deadline time.Time) func(*v1.Node) {
+		return func(node *v1.Node) {
+			node.Annotations = map[string]string{deadlineAnnotation: deadline.Format(time.RFC3339)}
+		}
+	}
+	labeled := maintenanceNode(func(node *v1.Node) {
+		node.Labels[maintenanceLabel] = "planned"
+	})
+	healthy := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	tests := []struct {
+		description             string
+		nodes                   []*v1.Node
+		args                    RemovePodsFromMaintenanceNodesArgs
+		expectedEvictedPodCount uint
+	}{
+		{
+			description: "Evict a pod per cycle from a node selected by its labels",
+			nodes:       []*v1.Node{labeled, healthy},
+			args: RemovePodsFromMaintenanceNodesArgs{
+				NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{maintenanceLabel: "planned"}},
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Evict pods up to the per node rate",
+			nodes:       []*v1.Node{labeled, healthy},
+			args: RemovePodsFromMaintenanceNodesArgs{
+				NodeSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{maintenanceLabel: "planned"}},
+				MaxPodsToEvictPerNode: utilptr.To[uint](3),
+			},
+			expectedEvictedPodCount: 3,
+		},
+		{
+			description: "Evict a pod from a node selected by its annotation",
+			nodes: []*v1.Node{maintenanceNode(func(node *v1.Node) {
+				node.Annotations = map[string]string{drainAnnotation: ""}
+			}), healthy},
+			args:                    RemovePodsFromMaintenanceNodesArgs{NodeAnnotation: drainAnnotation},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Nodes not scheduled for maintenance are ignored",
+			nodes:       []*v1.Node{maintenanceNode(nil), healthy},
+			args: RemovePodsFromMaintenanceNodesArgs{
+				NodeSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{maintenanceLabel: "planned"}},
+				NodeAnnotation:     drainAnnotation,
+				DeadlineAnnotation: deadlineAnnotation,
+			},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "The rate is used before the urgency window",
+			nodes:                   []*v1.Node{maintenanceNode(withDeadline(now.Add(2 * time.Hour))), healthy},
+			args:                    RemovePodsFromMaintenanceNodesArgs{DeadlineAnnotation: deadlineAnnotation},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Evictions increase within the urgency window",
+			nodes:                   []*v1.Node{maintenanceNode(withDeadline(now.Add(30 * time.Minute))), healthy},
+			args:                    RemovePodsFromMaintenanceNodesArgs{DeadlineAnnotation: deadlineAnnotation},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "All pods are evicted once the deadline is reached",
+			nodes:                   []*v1.Node{maintenanceNode(withDeadline(now.Add(-time.Minute))), healthy},
+			args:                    RemovePodsFromMaintenanceNodesArgs{DeadlineAnnotation: deadlineAnnotation},
+			expectedEvictedPodCount: 4,
+		},
+		{
+			description: "Pods are kept when no other node can run them",
+			nodes:       []*v1.Node{maintenanceNode(withDeadline(now.Add(-time.Minute)))},
+			args:        RemovePodsFromMaintenanceNodesArgs{DeadlineAnnotation: deadlineAnnotation},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for i := 0; i < 4; i++ {
+				objs = append(objs, test.BuildTestPod(fmt.Sprintf("p%d", i), 100, 0, "m1", func(pod *v1.Pod) {
+					pod.OwnerReferences = test.GetNormalPodOwnerRefList()
+				}))
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				nil,
+				nil,
+				tc.nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+			}
+			args := tc.args
+			SetDefaults_RemovePodsFromMaintenanceNodesArgs(&args)
+			if err := ValidateRemovePodsFromMaintenanceNodesArgs(&args); err != nil {
+				t.Fatalf("Invalid args: %v", err)
+			}
+			plugin, err := New(&args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			plugin.(*RemovePodsFromMaintenanceNodes).now = func() time.Time { return now }
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != tc.expectedEvictedPodCount {
+				t.Errorf("Unexpected no of pods evicted: pods evicted: %d, expected: %d", podsEvicted, tc.expectedEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfrommaintenancenodes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsFromMaintenanceNodesArgs holds arguments used to configure RemovePodsFromMaintenanceNodes plugin.
+type RemovePodsFromMaintenanceNodesArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Namespaces    *api.Namespaces       `json:"namespaces"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+	// NodeSelector selects the nodes scheduled for maintenance by their labels
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector"`
+	// NodeAnnotation selects the nodes scheduled for maintenance having the annotation
+	NodeAnnotation string `json:"nodeAnnotation"`
+	// DeadlineAnnotation selects the nodes scheduled for maintenance having the annotation,
+	// its value is the RFC 3339 time the node terminates at
+	DeadlineAnnotation string `json:"deadlineAnnotation"`
+	// MaxPodsToEvictPerNode is the number of pods evicted from a node in a descheduling cycle, defaults to 1
+	MaxPodsToEvictPerNode *uint `json:"maxPodsToEvictPerNode"`
+	// UrgencyWindowSeconds is the time before the deadline in which the evictions increase
+	// until all the pods are evicted, defaults to one hour
+	UrgencyWindowSeconds *uint `json:"urgencyWindowSeconds"`
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfrommaintenancenodes
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// ValidateRemovePodsFromMaintenanceNodesArgs validates RemovePodsFromMaintenanceNodes arguments
+func ValidateRemovePodsFromMaintenanceNodesArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsFromMaintenanceNodesArgs)
+	if args.NodeSelector == nil && args.NodeAnnotation == "" && args.DeadlineAnnotation == "" {
+		return fmt.Errorf("one of nodeSelector, nodeAnnotation or deadlineAnnotation must be set")
+	}
+	if args.NodeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.NodeSelector); err != nil {
+			return fmt.Errorf("failed to get node selector from strategy's params: %+v", err)
+		}
+	}
+	if args.MaxPodsToEvictPerNode != nil && *args.MaxPodsToEvictPerNode == 0 {
+		return fmt.Errorf("maxPodsToEvictPerNode must be greater than 0")
+	}
+
+	// At most one of include/exclude can be set
+	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
+		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		return err
+	}
+
+	if args.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
+			return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfrommaintenancenodes
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsFromMaintenanceNodesArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *RemovePodsFromMaintenanceNodesArgs
+		expectError bool
+	}{
+		{
+			description: "node selector, no errors",
+			args: &RemovePodsFromMaintenanceNodesArgs{
+				NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"maintenance": "true"}},
+			},
+			expectError: false,
+		},
+		{
+			description: "node and deadline annotations, no errors",
+			args: &RemovePodsFromMaintenanceNodesArgs{
+				NodeAnnotation:        "example.com/maintenance",
+				DeadlineAnnotation:    "example.com/termination-time",
+				MaxPodsToEvictPerNode: utilptr.To[uint](5),
+				UrgencyWindowSeconds:  utilptr.To[uint](0),
+			},
+			expectError: false,
+		},
+		{
+			description: "no maintenance node selection, expects errors",
+			args:        &RemovePodsFromMaintenanceNodesArgs{},
+			expectError: true,
+		},
+		{
+			description: "invalid node selector, expects errors",
+			args: &RemovePodsFromMaintenanceNodesArgs{
+				NodeSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "maintenance", Operator: "Foo"}},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "zero maxPodsToEvictPerNode, expects errors",
+			args: &RemovePodsFromMaintenanceNodesArgs{
+				NodeAnnotation:        "example.com/maintenance",
+				MaxPodsToEvictPerNode: utilptr.To[uint](0),
+			},
+			expectError: true,
+		},
+		{
+			description: "namespace include and exclude, expects errors",
+			args: &RemovePodsFromMaintenanceNodesArgs{
+				NodeAnnotation: "example.com/maintenance",
+				Namespaces: &api.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects errors",
+			args: &RemovePodsFromMaintenanceNodesArgs{
+				NodeAnnotation: "example.com/maintenance",
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Foo"}},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsFromMaintenanceNodesArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Errorf("unexpected arg validation behavior: %v", err)
+			}
+		})
+	}
+}
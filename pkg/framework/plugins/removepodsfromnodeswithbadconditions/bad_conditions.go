@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodeswithbadconditions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemovePodsFromNodesWithBadConditions"
+
+// RemovePodsFromNodesWithBadConditions evicts pods from nodes reporting bad conditions,
+// such as MemoryPressure or the conditions of the node problem detector.
+type RemovePodsFromNodesWithBadConditions struct {
+	handle    frameworktypes.Handle
+	args      *RemovePodsFromNodesWithBadConditionsArgs
+	podFilter podutil.FilterFunc
+	now       func() time.Time
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsFromNodesWithBadConditions{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	badConditionsArgs, ok := args.(*RemovePodsFromNodesWithBadConditionsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsFromNodesWithBadConditionsArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithNamespaceFilter(badConditionsArgs.Namespaces, handle.SharedInformerFactory()).
+		WithLabelSelector(badConditionsArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsFromNodesWithBadConditions{
+		handle:    handle,
+		args:      badConditionsArgs,
+		podFilter: podFilter,
+		now:       time.Now,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsFromNodesWithBadConditions) Name() string {
+	return PluginName
+}
+
+func (d *RemovePodsFromNodesWithBadConditions) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	healthyNodeExists := false
+	for _, node := range nodes {
+		if len(d.badConditions(node, false)) == 0 {
+			healthyNodeExists = true
+			break
+		}
+	}
+
+	for _, node := range nodes {
+		var conditions []BadNodeCondition
+		for _, condition := range d.badConditions(node, true) {
+			if condition.RequireHealthyNodes && !healthyNodeExists {
+				klog.V(2).InfoS("Node reports a bad condition but no healthy node exists", "node", klog.KObj(node), "condition", condition.Type)
+				continue
+			}
+			conditions = append(conditions, condition)
+		}
+		if len(conditions) == 0 {
+			continue
+		}
+		klog.V(1).InfoS("Processing node with bad conditions", "node", klog.KObj(node), "condition", conditions[0].Type)
+
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// evict the pods with the lowest priority first
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+		for _, pod := range pods {
+			if d.handle.Evictor().NodeLimitExceeded(node) {
+				break
+			}
+			if d.handle.Evictor().Filter(pod) && d.handle.Evictor().PreEvictionFilter(pod) {
+				d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			}
+		}
+	}
+	return nil
+}
+
+// badConditions returns the configured conditions the node reports, optionally only those
+// reported for at least their minimum duration.
+func (d *RemovePodsFromNodesWithBadConditions) badConditions(node *v1.Node, checkDuration bool) []BadNodeCondition {
+	var conditions []BadNodeCondition
+	for _, badCondition := range d.args.Conditions {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type != badCondition.Type || condition.Status != badCondition.Status {
+				continue
+			}
+			if checkDuration && badCondition.MinDurationSeconds != nil &&
+				d.now().Sub(condition.LastTransitionTime.Time) < time.Duration(*badCondition.MinDurationSeconds)*time.Second {
+				continue
+			}
+			conditions = append(conditions, badCondition)
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodeswithbadconditions
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestRemovePodsFromNodesWithBadConditions(t *testing.T) {
+	withCondition := func(conditionType v1.NodeConditionType, status v1.ConditionStatus, since time.Duration) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			node.Status.Conditions = append(node.Status.Conditions, v1.NodeCondition{
+				Type:               conditionType,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+			})
+		}
+	}
+	memoryPressure := test.BuildTestNode("n1", 2000, 3000, 10, withCondition(v1.NodeMemoryPressure, v1.ConditionTrue, time.Hour))
+	kernelDeadlock := test.BuildTestNode("n2", 2000, 3000, 10, withCondition("KernelDeadlock", v1.ConditionTrue, time.Hour))
+	kernelDeadlockRecent := test.BuildTestNode("n3", 2000, 3000, 10, withCondition("KernelDeadlock", v1.ConditionTrue, time.Minute))
+	healthy := test.BuildTestNode("n4", 2000, 3000, 10, withCondition(v1.NodeMemoryPressure, v1.ConditionFalse, time.Hour))
+
+	buildPods := func(node *v1.Node) []*v1.Pod {
+		var pods []*v1.Pod
+		for _, name := range []string{"a", "b"} {
+			pods = append(pods, test.BuildTestPod(node.Name+"-"+name, 100, 0, node.Name, func(pod *v1.Pod) {
+				pod.OwnerReferences = test.GetNormalPodOwnerRefList()
+			}))
+		}
+		return pods
+	}
+	buildPodsWithPriorities := func(node *v1.Node) []*v1.Pod {
+		var pods []*v1.Pod
+		for _, priority := range []struct {
+			name     string
+			priority int32
+		}{{"high", 1000}, {"low", 10}, {"medium", 100}} {
+			pods = append(pods, test.BuildTestPod(node.Name+"-"+priority.name, 100, 0, node.Name, func(pod *v1.Pod) {
+				pod.OwnerReferences = test.GetNormalPodOwnerRefList()
+				test.SetPodPriority(pod, priority.priority)
+			}))
+		}
+		return pods
+	}
+	kernelDeadlockCondition := BadNodeCondition{Type: "KernelDeadlock", MinDurationSeconds: utilptr.To[uint](600)}
+
+	tests := []struct {
+		description             string
+		nodes                   []*v1.Node
+		conditions              []BadNodeCondition
+		buildPods               func(node *v1.Node) []*v1.Pod
+		maxPodsToEvictPerNode   *uint
+		expectedEvictedPodCount uint
+		expectedEvictedPods     []string
+	}{
+		{
+			description:             "Evict pods from a node under memory pressure",
+			nodes:                   []*v1.Node{memoryPressure, healthy},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "Evictions are limited per node",
+			nodes:                   []*v1.Node{memoryPressure, healthy},
+			maxPodsToEvictPerNode:   utilptr.To[uint](1),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Pods with the lowest priority are evicted first",
+			nodes:                   []*v1.Node{memoryPressure, healthy},
+			buildPods:               buildPodsWithPriorities,
+			maxPodsToEvictPerNode:   utilptr.To[uint](2),
+			expectedEvictedPodCount: 2,
+			expectedEvictedPods:     []string{"n1-low", "n1-medium"},
+		},
+		{
+			description:             "Custom conditions are not checked by default",
+			nodes:                   []*v1.Node{kernelDeadlock, healthy},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Evict pods from a node reporting a custom condition",
+			nodes:                   []*v1.Node{kernelDeadlock, kernelDeadlockRecent, healthy},
+			conditions:              []BadNodeCondition{kernelDeadlockCondition},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Pods are only evicted when a healthy node exists",
+			nodes:       []*v1.Node{kernelDeadlock, kernelDeadlockRecent},
+			conditions: []BadNodeCondition{
+				{Type: "KernelDeadlock", MinDurationSeconds: utilptr.To[uint](600), RequireHealthyNodes: true},
+			},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Pods are evicted when a healthy node exists",
+			nodes:       []*v1.Node{kernelDeadlock, healthy},
+			conditions: []BadNodeCondition{
+				{Type: "KernelDeadlock", RequireHealthyNodes: true},
+			},
+			expectedEvictedPodCount: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			podsOnNode := buildPods
+			if tc.buildPods != nil {
+				podsOnNode = tc.buildPods
+			}
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+				for _, pod := range podsOnNode(node) {
+					objs = append(objs, pod)
+				}
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			var evictedPods []string
+			fakeClient.PrependReactor("create", "pods", podEvictionReactionFunc(&evictedPods))
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				tc.maxPodsToEvictPerNode,
+				nil,
+				tc.nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+			}
+			args := &RemovePodsFromNodesWithBadConditionsArgs{Conditions: tc.conditions}
+			SetDefaults_RemovePodsFromNodesWithBadConditionsArgs(args)
+			if err := ValidateRemovePodsFromNodesWithBadConditionsArgs(args); err != nil {
+				t.Fatalf("Invalid args: %v", err)
+			}
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != tc.expectedEvictedPodCount {
+				t.Errorf("Unexpected no of pods evicted: pods evicted: %d, expected: %d", podsEvicted, tc.expectedEvictedPodCount)
+			}
+			if tc.expectedEvictedPods != nil && !reflect.DeepEqual(evictedPods, tc.expectedEvictedPods) {
+				t.Errorf("Unexpected pods evicted: %v, expected: %v", evictedPods, tc.expectedEvictedPods)
+			}
+		})
+	}
+}
+
+func podEvictionReactionFunc(evictedPods *[]string) func(action core.Action) (bool, runtime.Object, error) {
+	return func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" {
+			createAct, matched := action.(core.CreateActionImpl)
+			if !matched {
+				return false, nil, fmt.Errorf("unable to convert action to core.CreateActionImpl")
+			}
+			if eviction, matched := createAct.Object.(*policyv1.Eviction); matched {
+				*evictedPods = append(*evictedPods, eviction.GetName())
+			}
+		}
+		return false, nil, nil // fallback to the default reactor
+	}
+}
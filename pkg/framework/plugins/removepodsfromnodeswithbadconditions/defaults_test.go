@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodeswithbadconditions
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestSetDefaults_RemovePodsFromNodesWithBadConditionsArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *RemovePodsFromNodesWithBadConditionsArgs
+		want *RemovePodsFromNodesWithBadConditionsArgs
+	}{
+		{
+			name: "RemovePodsFromNodesWithBadConditionsArgs empty",
+			in:   &RemovePodsFromNodesWithBadConditionsArgs{},
+			want: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{
+					{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue},
+					{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue},
+					{Type: v1.NodePIDPressure, Status: v1.ConditionTrue},
+				},
+			},
+		},
+		{
+			name: "RemovePodsFromNodesWithBadConditionsArgs with value",
+			in: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{
+					{Type: v1.NodeReady, Status: v1.ConditionFalse},
+					{Type: "KernelDeadlock", RequireHealthyNodes: true},
+				},
+			},
+			want: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{
+					{Type: v1.NodeReady, Status: v1.ConditionFalse},
+					{Type: "KernelDeadlock", Status: v1.ConditionTrue, RequireHealthyNodes: true},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemovePodsFromNodesWithBadConditionsArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
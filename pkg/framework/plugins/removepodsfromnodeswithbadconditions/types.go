@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodeswithbadconditions
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemovePodsFromNodesWithBadConditionsArgs holds arguments used to configure RemovePodsFromNodesWithBadConditions plugin.
+type RemovePodsFromNodesWithBadConditionsArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Namespaces    *api.Namespaces       `json:"namespaces"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+	// Conditions are the bad node conditions, pods are evicted from nodes reporting any of them.
+	// Defaults to the MemoryPressure, DiskPressure and PIDPressure conditions.
+	Conditions []BadNodeCondition `json:"conditions"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// BadNodeCondition is a node condition in a status for which pods are evicted from the node.
+type BadNodeCondition struct {
+	Type v1.NodeConditionType `json:"type"`
+	// Status of the condition, defaults to True.
+	Status v1.ConditionStatus `json:"status"`
+	// MinDurationSeconds the condition must have been in the status for, as of its lastTransitionTime.
+	MinDurationSeconds *uint `json:"minDurationSeconds"`
+	// RequireHealthyNodes only evicts pods for the condition when at least one of the
+	// nodes does not report any of the bad conditions.
+	RequireHealthyNodes bool `json:"requireHealthyNodes"`
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodeswithbadconditions
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// ValidateRemovePodsFromNodesWithBadConditionsArgs validates RemovePodsFromNodesWithBadConditions arguments
+func ValidateRemovePodsFromNodesWithBadConditionsArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsFromNodesWithBadConditionsArgs)
+	if len(args.Conditions) == 0 {
+		return fmt.Errorf("at least one condition must be set")
+	}
+	for i, condition := range args.Conditions {
+		if condition.Type == "" {
+			return fmt.Errorf("conditions[%d]: type must be set", i)
+		}
+		switch condition.Status {
+		case v1.ConditionTrue, v1.ConditionFalse, v1.ConditionUnknown:
+		default:
+			return fmt.Errorf("conditions[%d]: invalid status %q, must be one of True, False or Unknown", i, condition.Status)
+		}
+	}
+
+	// At most one of include/exclude can be set
+	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
+		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		return err
+	}
+
+	if args.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
+			return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
+		}
+	}
+
+	return nil
+}
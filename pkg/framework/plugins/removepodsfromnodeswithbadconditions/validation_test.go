@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsfromnodeswithbadconditions
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsFromNodesWithBadConditionsArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *RemovePodsFromNodesWithBadConditionsArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{
+					{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue},
+					{Type: v1.NodeReady, Status: v1.ConditionUnknown, MinDurationSeconds: func(i uint) *uint { return &i }(300)},
+				},
+			},
+			expectError: false,
+		},
+		{
+			description: "no conditions, expects errors",
+			args:        &RemovePodsFromNodesWithBadConditionsArgs{},
+			expectError: true,
+		},
+		{
+			description: "empty condition type, expects errors",
+			args: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{{Status: v1.ConditionTrue}},
+			},
+			expectError: true,
+		},
+		{
+			description: "empty condition status, expects errors",
+			args: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{{Type: v1.NodeDiskPressure}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid condition status, expects errors",
+			args: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{{Type: v1.NodeDiskPressure, Status: "Maybe"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "namespace include and exclude, expects errors",
+			args: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue}},
+				Namespaces: &api.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects errors",
+			args: &RemovePodsFromNodesWithBadConditionsArgs{
+				Conditions: []BadNodeCondition{{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue}},
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Foo"}},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsFromNodesWithBadConditionsArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Errorf("unexpected arg validation behavior: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodshavingstalerevision
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestSetDefaults_RemovePodsHavingStaleRevisionArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *RemovePodsHavingStaleRevisionArgs
+		want *RemovePodsHavingStaleRevisionArgs
+	}{
+		{
+			name: "RemovePodsHavingStaleRevisionArgs empty",
+			in:   &RemovePodsHavingStaleRevisionArgs{},
+			want: &RemovePodsHavingStaleRevisionArgs{
+				MinPodAgeSeconds: utilptr.To[uint](3600),
+				MaxPodsPerOwner:  utilptr.To[uint](1),
+			},
+		},
+		{
+			name: "RemovePodsHavingStaleRevisionArgs with value",
+			in: &RemovePodsHavingStaleRevisionArgs{
+				Namespaces:       &api.Namespaces{Exclude: []string{"kube-system"}},
+				MinPodAgeSeconds: utilptr.To[uint](0),
+				MaxPodsPerOwner:  utilptr.To[uint](3),
+			},
+			want: &RemovePodsHavingStaleRevisionArgs{
+				Namespaces:       &api.Namespaces{Exclude: []string{"kube-system"}},
+				MinPodAgeSeconds: utilptr.To[uint](0),
+				MaxPodsPerOwner:  utilptr.To[uint](3),
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemovePodsHavingStaleRevisionArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
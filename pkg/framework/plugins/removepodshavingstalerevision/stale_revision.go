@@ -0,0 +1,321 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodshavingstalerevision
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RemovePodsHavingStaleRevision"
+
+const (
+	revisionAnnotation = "deployment.kubernetes.io/revision"
+	// newReplicaSetAvailableReason is the reason of the Progressing condition of a completed rollout
+	newReplicaSetAvailableReason = "NewReplicaSetAvailable"
+)
+
+// RemovePodsHavingStaleRevision evicts pods running an outdated revision of their owner's
+// pod template once the owner is no longer progressing towards the current revision.
+type RemovePodsHavingStaleRevision struct {
+	handle            frameworktypes.Handle
+	args              *RemovePodsHavingStaleRevisionArgs
+	podFilter         podutil.FilterFunc
+	replicaSetLister  appslisters.ReplicaSetLister
+	deploymentLister  appslisters.DeploymentLister
+	statefulSetLister appslisters.StatefulSetLister
+	podLister         corelisters.PodLister
+	now               func() time.Time
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsHavingStaleRevision{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	staleRevisionArgs, ok := args.(*RemovePodsHavingStaleRevisionArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsHavingStaleRevisionArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithNamespaceFilter(staleRevisionArgs.Namespaces, handle.SharedInformerFactory()).
+		WithLabelSelector(staleRevisionArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	apps := handle.SharedInformerFactory().Apps().V1()
+	return &RemovePodsHavingStaleRevision{
+		handle:            handle,
+		args:              staleRevisionArgs,
+		podFilter:         podFilter,
+		replicaSetLister:  apps.ReplicaSets().Lister(),
+		deploymentLister:  apps.Deployments().Lister(),
+		statefulSetLister: apps.StatefulSets().Lister(),
+		podLister:         handle.SharedInformerFactory().Core().V1().Pods().Lister(),
+		now:               time.Now,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsHavingStaleRevision) Name() string {
+	return PluginName
+}
+
+type staleRevisionPod struct {
+	pod   *v1.Pod
+	node  *v1.Node
+	owner string
+	// replicaSet is set for pods of an outdated ReplicaSet, which recreates any pod
+	// evicted while it still needs it. Only its surplus pods are evicted.
+	replicaSet types.UID
+	surplus    uint
+}
+
+func (d *RemovePodsHavingStaleRevision) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	var candidates []staleRevisionPod
+	for _, node := range nodes {
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		for _, pod := range pods {
+			if d.now().Sub(pod.CreationTimestamp.Time) < time.Duration(*d.args.MinPodAgeSeconds)*time.Second {
+				continue
+			}
+			if candidate, stale := d.staleOwner(pod); stale {
+				candidate.pod, candidate.node = pod, node
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	// evict the oldest pods first
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].pod.CreationTimestamp.Before(&candidates[j].pod.CreationTimestamp)
+	})
+
+	evictedPerOwner := map[string]uint{}
+	evictedPerReplicaSet := map[types.UID]uint{}
+	for _, candidate := range candidates {
+		if evictedPerOwner[candidate.owner] >= *d.args.MaxPodsPerOwner {
+			continue
+		}
+		if candidate.replicaSet != "" && evictedPerReplicaSet[candidate.replicaSet] >= candidate.surplus {
+			continue
+		}
+		if d.handle.Evictor().NodeLimitExceeded(candidate.node) {
+			continue
+		}
+		if !d.handle.Evictor().Filter(candidate.pod) || !d.handle.Evictor().PreEvictionFilter(candidate.pod) {
+			continue
+		}
+		klog.V(2).InfoS("Pod runs a stale revision of its owner", "pod", klog.KObj(candidate.pod), "owner", candidate.owner)
+		if d.handle.Evictor().Evict(ctx, candidate.pod, evictions.EvictOptions{StrategyName: PluginName}) {
+			evictedPerOwner[candidate.owner]++
+			if candidate.replicaSet != "" {
+				evictedPerReplicaSet[candidate.replicaSet]++
+			}
+		}
+	}
+	return nil
+}
+
+// staleOwner returns the top-level owner of the pod and whether the pod runs a stale
+// revision of the owner which is no longer progressing.
+func (d *RemovePodsHavingStaleRevision) staleOwner(pod *v1.Pod) (staleRevisionPod, bool) {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		return staleRevisionPod{}, false
+	}
+	switch ownerRef.Kind {
+	case "ReplicaSet":
+		return d.staleDeployment(pod, ownerRef)
+	case "StatefulSet":
+		owner, stale := d.staleStatefulSet(pod, ownerRef)
+		return staleRevisionPod{owner: owner}, stale
+	}
+	return staleRevisionPod{}, false
+}
+
+// staleDeployment tells whether the pod belongs to an outdated ReplicaSet of a Deployment.
+// Pods the outdated ReplicaSet still needs are not stale, as it would recreate them with
+// the same revision. A stuck or paused rollout keeps the old ReplicaSets scaled up.
+func (d *RemovePodsHavingStaleRevision) staleDeployment(pod *v1.Pod, ownerRef *metav1.OwnerReference) (staleRevisionPod, bool) {
+	rs, err := d.replicaSetLister.ReplicaSets(pod.Namespace).Get(ownerRef.Name)
+	if err != nil {
+		return staleRevisionPod{}, false
+	}
+	rsOwnerRef := metav1.GetControllerOf(rs)
+	if rsOwnerRef == nil || rsOwnerRef.Kind != "Deployment" {
+		return staleRevisionPod{}, false
+	}
+	deployment, err := d.deploymentLister.Deployments(pod.Namespace).Get(rsOwnerRef.Name)
+	if err != nil || deployment.UID != rsOwnerRef.UID {
+		return staleRevisionPod{}, false
+	}
+	candidate := staleRevisionPod{owner: "Deployment/" + deployment.Namespace + "/" + deployment.Name, replicaSet: rs.UID}
+	if deploymentProgressing(deployment) {
+		return candidate, false
+	}
+
+	replicaSets, err := d.replicaSetLister.ReplicaSets(pod.Namespace).List(labels.Everything())
+	if err != nil {
+		return candidate, false
+	}
+	var newest *appsv1.ReplicaSet
+	newestRevision := int64(-1)
+	for _, other := range replicaSets {
+		if ref := metav1.GetControllerOf(other); ref == nil || ref.UID != deployment.UID {
+			continue
+		}
+		revision, err := strconv.ParseInt(other.Annotations[revisionAnnotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		if revision > newestRevision {
+			newest, newestRevision = other, revision
+		}
+	}
+	if newest == nil {
+		return candidate, false
+	}
+
+	if hash, ok := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+		if hash == newest.Labels[appsv1.DefaultDeploymentUniqueLabelKey] {
+			return candidate, false
+		}
+	} else if newest.UID == rs.UID {
+		return candidate, false
+	}
+	candidate.surplus = d.replicaSetSurplus(rs)
+	return candidate, candidate.surplus > 0
+}
+
+// replicaSetSurplus returns the number of active pods of the ReplicaSet exceeding its
+// desired replicas, that is the pods it does not recreate once evicted.
+func (d *RemovePodsHavingStaleRevision) replicaSetSurplus(rs *appsv1.ReplicaSet) uint {
+	selector, err := metav1.LabelSelectorAsSelector(rs.Spec.Selector)
+	if err != nil {
+		return 0
+	}
+	pods, err := d.podLister.Pods(rs.Namespace).List(selector)
+	if err != nil {
+		return 0
+	}
+	active := 0
+	for _, pod := range pods {
+		if ref := metav1.GetControllerOfNoCopy(pod); ref == nil || ref.UID != rs.UID {
+			continue
+		}
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		active++
+	}
+	desired := 1
+	if rs.Spec.Replicas != nil {
+		desired = int(*rs.Spec.Replicas)
+	}
+	if active <= desired {
+		return 0
+	}
+	return uint(active - desired)
+}
+
+// deploymentProgressing tells whether the deployment controller is still rolling out the
+// current revision. Paused deployments, completed rollouts and rollouts which exceeded
+// their progress deadline are not progressing.
+func deploymentProgressing(deployment *appsv1.Deployment) bool {
+	if deployment.Spec.Paused {
+		return false
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return true
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Status == v1.ConditionTrue && condition.Reason != newReplicaSetAvailableReason
+		}
+	}
+	return false
+}
+
+func (d *RemovePodsHavingStaleRevision) staleStatefulSet(pod *v1.Pod, ownerRef *metav1.OwnerReference) (string, bool) {
+	statefulSet, err := d.statefulSetLister.StatefulSets(pod.Namespace).Get(ownerRef.Name)
+	if err != nil || statefulSet.UID != ownerRef.UID {
+		return "", false
+	}
+	owner := "StatefulSet/" + statefulSet.Namespace + "/" + statefulSet.Name
+	if statefulSet.Status.UpdateRevision == "" || statefulSetProgressing(statefulSet) {
+		return owner, false
+	}
+
+	hash, ok := pod.Labels[appsv1.ControllerRevisionHashLabelKey]
+	if !ok || hash == statefulSet.Status.UpdateRevision {
+		return owner, false
+	}
+
+	// pods below the partition are meant to keep running the previous revision
+	strategy := statefulSet.Spec.UpdateStrategy
+	if strategy.RollingUpdate != nil && strategy.RollingUpdate.Partition != nil {
+		ordinal, err := strconv.Atoi(pod.Name[strings.LastIndex(pod.Name, "-")+1:])
+		if err != nil || int32(ordinal) < *strategy.RollingUpdate.Partition {
+			return owner, false
+		}
+	}
+	return owner, true
+}
+
+// statefulSetProgressing tells whether the statefulset controller is still rolling out the
+// current revision. Statefulsets with the OnDelete strategy never replace their pods and
+// rolling updates stop progressing as soon as any replica is not ready.
+func statefulSetProgressing(statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return false
+	}
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return true
+	}
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	return statefulSet.Status.ReadyReplicas >= replicas
+}
@@ -0,0 +1,446 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodshavingstalerevision
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func buildDeployment(apply func(*appsv1.Deployment)) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "default", UID: "deployment-uid", Generation: 2},
+		Spec:       appsv1.DeploymentSpec{Replicas: utilptr.To[int32](3)},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: v1.ConditionTrue, Reason: newReplicaSetAvailableReason},
+			},
+		},
+	}
+	if apply != nil {
+		apply(deployment)
+	}
+	return deployment
+}
+
+func buildReplicaSet(hash, revision string, replicas int32) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "deployment-" + hash,
+			Namespace:   "default",
+			UID:         types.UID("rs-" + hash),
+			Labels:      map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash},
+			Annotations: map[string]string{revisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Deployment", APIVersion: "apps/v1", Name: "deployment", UID: "deployment-uid", Controller: utilptr.To(true)},
+			},
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: utilptr.To(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash}},
+		},
+	}
+}
+
+func buildStatefulSet(apply func(*appsv1.StatefulSet)) *appsv1.StatefulSet {
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "statefulset", Namespace: "default", UID: "statefulset-uid", Generation: 1},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       utilptr.To[int32](3),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			ReadyReplicas:      3,
+			UpdateRevision:     "statefulset-new",
+		},
+	}
+	if apply != nil {
+		apply(statefulSet)
+	}
+	return statefulSet
+}
+
+func buildPod(name, nodeName string, age time.Duration, ownerKind, ownerName string, ownerUID types.UID, labels map[string]string) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		pod.UID = types.UID(name)
+		pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-age))
+		pod.Labels = labels
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{Kind: ownerKind, APIVersion: "apps/v1", Name: ownerName, UID: ownerUID, Controller: utilptr.To(true)},
+		}
+	})
+}
+
+func TestRemovePodsHavingStaleRevision(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("n2", 2000, 3000, 10, nil)
+
+	oldRS := buildReplicaSet("old", "1", 0)
+	newRS := buildReplicaSet("new", "2", 3)
+	rsPod := func(name, nodeName string, age time.Duration, rs *appsv1.ReplicaSet) *v1.Pod {
+		return buildPod(name, nodeName, age, "ReplicaSet", rs.Name, rs.UID, map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: rs.Labels[appsv1.DefaultDeploymentUniqueLabelKey]})
+	}
+	deploymentPods := []*v1.Pod{
+		rsPod("p1", node1.Name, 48*time.Hour, oldRS),
+		rsPod("p2", node2.Name, 24*time.Hour, oldRS),
+		rsPod("p3", node1.Name, 2*time.Hour, newRS),
+	}
+	ssPod := func(name, nodeName, revision string) *v1.Pod {
+		return buildPod(name, nodeName, 48*time.Hour, "StatefulSet", "statefulset", "statefulset-uid", map[string]string{appsv1.ControllerRevisionHashLabelKey: revision})
+	}
+	statefulSetPods := []*v1.Pod{
+		ssPod("statefulset-0", node1.Name, "statefulset-old"),
+		ssPod("statefulset-1", node2.Name, "statefulset-old"),
+		ssPod("statefulset-2", node2.Name, "statefulset-new"),
+	}
+
+	tests := []struct {
+		description             string
+		objs                    []runtime.Object
+		pods                    []*v1.Pod
+		minPodAgeSeconds        *uint
+		maxPodsPerOwner         *uint
+		maxPodsToEvictPerNode   *uint
+		expectedEvictedPodCount uint
+	}{
+		{
+			description:             "Evict the oldest stale pod of a completed rollout",
+			objs:                    []runtime.Object{buildDeployment(nil), oldRS, newRS},
+			pods:                    deploymentPods,
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Evict all stale pods of a deployment within the per owner limit",
+			objs:                    []runtime.Object{buildDeployment(nil), oldRS, newRS},
+			pods:                    deploymentPods,
+			maxPodsPerOwner:         utilptr.To[uint](5),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "Evictions are limited per node",
+			objs:                    []runtime.Object{buildDeployment(nil), oldRS, newRS},
+			pods:                    append(deploymentPods, rsPod("p4", node1.Name, 24*time.Hour, oldRS)),
+			maxPodsPerOwner:         utilptr.To[uint](5),
+			maxPodsToEvictPerNode:   utilptr.To[uint](1),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description:             "Pods younger than the minimum age are kept",
+			objs:                    []runtime.Object{buildDeployment(nil), oldRS, newRS},
+			pods:                    deploymentPods,
+			minPodAgeSeconds:        utilptr.To[uint](72 * 3600),
+			maxPodsPerOwner:         utilptr.To[uint](5),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Pods still needed by the old replicaset of a stuck rollout are kept",
+			objs:                    []runtime.Object{buildDeployment(nil), buildReplicaSet("old", "1", 2), newRS},
+			pods:                    deploymentPods,
+			maxPodsPerOwner:         utilptr.To[uint](5),
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Only the surplus pods of an old replicaset are evicted",
+			objs:                    []runtime.Object{buildDeployment(nil), buildReplicaSet("old", "1", 1), newRS},
+			pods:                    deploymentPods,
+			maxPodsPerOwner:         utilptr.To[uint](5),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Evict stale pods of a paused deployment",
+			objs: []runtime.Object{buildDeployment(func(deployment *appsv1.Deployment) {
+				deployment.Spec.Paused = true
+				deployment.Status.Conditions[0].Reason = "ReplicaSetUpdated"
+			}), oldRS, newRS},
+			pods:                    deploymentPods,
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Evict stale pods of a deployment which exceeded its progress deadline",
+			objs: []runtime.Object{buildDeployment(func(deployment *appsv1.Deployment) {
+				deployment.Status.Conditions[0].Status = v1.ConditionFalse
+				deployment.Status.Conditions[0].Reason = "ProgressDeadlineExceeded"
+			}), oldRS, newRS},
+			pods:                    deploymentPods,
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Pods of a progressing deployment are kept",
+			objs: []runtime.Object{buildDeployment(func(deployment *appsv1.Deployment) {
+				deployment.Status.Conditions[0].Reason = "ReplicaSetUpdated"
+			}), oldRS, newRS},
+			pods:                    deploymentPods,
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "Pods of a deployment not yet observed by its controller are kept",
+			objs: []runtime.Object{buildDeployment(func(deployment *appsv1.Deployment) {
+				deployment.Generation = 3
+			}), oldRS, newRS},
+			pods:                    deploymentPods,
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Evict stale pods of a statefulset with the OnDelete strategy",
+			objs:                    []runtime.Object{buildStatefulSet(nil)},
+			pods:                    statefulSetPods,
+			maxPodsPerOwner:         utilptr.To[uint](5),
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "Pods below the partition of a statefulset are kept",
+			objs: []runtime.Object{buildStatefulSet(func(statefulSet *appsv1.StatefulSet) {
+				statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+					Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: utilptr.To[int32](1)},
+				}
+				statefulSet.Status.ReadyReplicas = 2
+			})},
+			pods:                    statefulSetPods,
+			maxPodsPerOwner:         utilptr.To[uint](5),
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "Pods of a statefulset rolling out with all replicas ready are kept",
+			objs: []runtime.Object{buildStatefulSet(func(statefulSet *appsv1.StatefulSet) {
+				statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}
+			})},
+			pods:                    statefulSetPods,
+			maxPodsPerOwner:         utilptr.To[uint](5),
+			expectedEvictedPodCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{node1, node2}
+			objs := append([]runtime.Object{node1, node2}, tc.objs...)
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				tc.maxPodsToEvictPerNode,
+				nil,
+				nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+			}
+			args := &RemovePodsHavingStaleRevisionArgs{
+				MinPodAgeSeconds: tc.minPodAgeSeconds,
+				MaxPodsPerOwner:  tc.maxPodsPerOwner,
+			}
+			SetDefaults_RemovePodsHavingStaleRevisionArgs(args)
+			if err := ValidateRemovePodsHavingStaleRevisionArgs(args); err != nil {
+				t.Fatalf("Invalid args: %v", err)
+			}
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, nodes)
+			if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != tc.expectedEvictedPodCount {
+				t.Errorf("Unexpected no of pods evicted: pods evicted: %d, expected: %d", podsEvicted, tc.expectedEvictedPodCount)
+			}
+		})
+	}
+}
+
+func TestRemovePodsHavingStaleRevisionReplacement(t *testing.T) {
+	node1 := test.BuildTestNode("n1", 2000, 3000, 10, nil)
+
+	oldRS := buildReplicaSet("old", "1", 0)
+	newRS := buildReplicaSet("new", "2", 2)
+	ssPodLabels := func(revision string) map[string]string {
+		return map[string]string{appsv1.ControllerRevisionHashLabelKey: revision}
+	}
+
+	tests := []struct {
+		description string
+		objs        []runtime.Object
+		pods        []*v1.Pod
+		// replacement is created by the owner once the stale pod is evicted
+		replacement *v1.Pod
+	}{
+		{
+			description: "Deployment pod replaced by the newest replicaset",
+			objs:        []runtime.Object{buildDeployment(nil), oldRS, newRS},
+			pods: []*v1.Pod{
+				buildPod("p1", node1.Name, 48*time.Hour, "ReplicaSet", oldRS.Name, oldRS.UID, oldRS.Spec.Selector.MatchLabels),
+				buildPod("p2", node1.Name, 2*time.Hour, "ReplicaSet", newRS.Name, newRS.UID, newRS.Spec.Selector.MatchLabels),
+			},
+			replacement: buildPod("p3", node1.Name, 2*time.Hour, "ReplicaSet", newRS.Name, newRS.UID, newRS.Spec.Selector.MatchLabels),
+		},
+		{
+			description: "StatefulSet pod recreated with the update revision",
+			objs:        []runtime.Object{buildStatefulSet(nil)},
+			pods: []*v1.Pod{
+				buildPod("statefulset-0", node1.Name, 48*time.Hour, "StatefulSet", "statefulset", "statefulset-uid", ssPodLabels("statefulset-old")),
+				buildPod("statefulset-1", node1.Name, 48*time.Hour, "StatefulSet", "statefulset", "statefulset-uid", ssPodLabels("statefulset-new")),
+			},
+			replacement: buildPod("statefulset-0", node1.Name, 2*time.Hour, "StatefulSet", "statefulset", "statefulset-uid", ssPodLabels("statefulset-new")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{node1}
+			objs := append([]runtime.Object{node1}, tc.objs...)
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+			}
+			args := &RemovePodsHavingStaleRevisionArgs{}
+			SetDefaults_RemovePodsHavingStaleRevisionArgs(args)
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			runCycle := func() uint {
+				handle.PodEvictorImpl = evictions.NewPodEvictor(
+					evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+					nil,
+					nil,
+					nodes,
+					false,
+					&events.FakeRecorder{},
+				)
+				plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, nodes)
+				return handle.PodEvictorImpl.TotalEvicted()
+			}
+
+			if podsEvicted := runCycle(); podsEvicted != 1 {
+				t.Fatalf("Expected the stale pod to be evicted, got %d evictions", podsEvicted)
+			}
+
+			stale := tc.pods[0]
+			if err := fakeClient.CoreV1().Pods(stale.Namespace).Delete(ctx, stale.Name, metav1.DeleteOptions{}); err != nil {
+				t.Fatalf("Unable to delete pod %q: %v", stale.Name, err)
+			}
+			if _, err := fakeClient.CoreV1().Pods(tc.replacement.Namespace).Create(ctx, tc.replacement, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("Unable to create pod %q: %v", tc.replacement.Name, err)
+			}
+			podLister := sharedInformerFactory.Core().V1().Pods().Lister()
+			if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+				if pod, err := podLister.Pods(stale.Namespace).Get(stale.Name); err == nil && pod.CreationTimestamp.Equal(&stale.CreationTimestamp) {
+					return false, nil
+				}
+				_, err := podLister.Pods(tc.replacement.Namespace).Get(tc.replacement.Name)
+				return err == nil, nil
+			}); err != nil {
+				t.Fatalf("Replacement pod %q not observed: %v", tc.replacement.Name, err)
+			}
+
+			if podsEvicted := runCycle(); podsEvicted != 0 {
+				t.Errorf("Expected the replacement pod not to be stale, got %d evictions", podsEvicted)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ package removepodshavingtoomanyrestarts
 
 import (
 	"k8s.io/apimachinery/pkg/runtime"
+	utilptr "k8s.io/utils/ptr"
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -40,4 +41,12 @@ func SetDefaults_RemovePodsHavingTooManyRestartsArgs(obj runtime.Object) {
 	if args.States == nil {
 		args.States = nil
 	}
+	if args.RestartRate != nil {
+		if args.RestartRate.WindowSeconds == nil {
+			args.RestartRate.WindowSeconds = utilptr.To[uint](3600)
+		}
+		if args.RestartRate.MinCrashingPodsPerNode == nil {
+			args.RestartRate.MinCrashingPodsPerNode = utilptr.To[uint](1)
+		}
+	}
 }
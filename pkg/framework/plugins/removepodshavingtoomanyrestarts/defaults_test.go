@@ -20,7 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilptr "k8s.io/utils/ptr"
 	"sigs.k8s.io/descheduler/pkg/api"
 )
 
@@ -58,12 +58,23 @@ func TestSetDefaults_RemovePodsHavingTooManyRestartsArgs(t *testing.T) {
 				States:                  []string{string(v1.PodRunning)},
 			},
 		},
+		{
+			name: "RemovePodsHavingTooManyRestartsArgs with restart rate",
+			in: &RemovePodsHavingTooManyRestartsArgs{
+				RestartRate: &RestartRate{Restarts: 3},
+			},
+			want: &RemovePodsHavingTooManyRestartsArgs{
+				RestartRate: &RestartRate{
+					Restarts:               3,
+					WindowSeconds:          utilptr.To[uint](3600),
+					MinCrashingPodsPerNode: utilptr.To[uint](1),
+				},
+			},
+		},
 	}
 	for _, tc := range tests {
-		scheme := runtime.NewScheme()
-		utilruntime.Must(AddToScheme(scheme))
 		t.Run(tc.name, func(t *testing.T) {
-			scheme.Default(tc.in)
+			SetDefaults_RemovePodsHavingTooManyRestartsArgs(tc.in)
 			if diff := cmp.Diff(tc.in, tc.want); diff != "" {
 				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
 			}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodshavingtoomanyrestarts
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// The plugin is rebuilt on every descheduling cycle, so the observed restarts
+// are kept at the package level to compute restart rates across cycles.
+var defaultRestartTracker = newRestartTracker()
+
+// restartEvent records restarts of a container noticed in one observation.
+type restartEvent struct {
+	time     time.Time
+	restarts int32
+	reason   string
+}
+
+type containerRestarts struct {
+	init         bool
+	restartCount int32
+	events       []restartEvent
+}
+
+type podRestarts struct {
+	lastSeen   time.Time
+	containers map[string]*containerRestarts
+}
+
+// restartTracker remembers container restart counts between descheduling cycles.
+type restartTracker struct {
+	lock      sync.Mutex
+	retention time.Duration
+	pods      map[types.UID]*podRestarts
+}
+
+func newRestartTracker() *restartTracker {
+	return &restartTracker{
+		pods: make(map[types.UID]*podRestarts),
+	}
+}
+
+// ensureRetention makes sure restarts are remembered for at least the given window.
+func (t *restartTracker) ensureRetention(window time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if window > t.retention {
+		t.retention = window
+	}
+}
+
+// prune forgets restarts older than the retention and pods not seen within it.
+func (t *restartTracker) prune(now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	cutoff := now.Add(-t.retention)
+	for uid, pod := range t.pods {
+		if pod.lastSeen.Before(cutoff) {
+			delete(t.pods, uid)
+			continue
+		}
+		for _, container := range pod.containers {
+			events := container.events[:0]
+			for _, event := range container.events {
+				if !event.time.Before(cutoff) {
+					events = append(events, event)
+				}
+			}
+			container.events = events
+		}
+	}
+}
+
+// observe records the restart counts of all containers of the pod. Restarts
+// noticed the first time a pod is seen are attributed to the pod start time,
+// so pods crashing right after being created are caught in the first cycle.
+func (t *restartTracker) observe(pod *v1.Pod, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	tracked, ok := t.pods[pod.UID]
+	if !ok {
+		tracked = &podRestarts{containers: make(map[string]*containerRestarts)}
+		t.pods[pod.UID] = tracked
+	}
+	tracked.lastSeen = now
+
+	startTime := pod.CreationTimestamp.Time
+	if pod.Status.StartTime != nil {
+		startTime = pod.Status.StartTime.Time
+	}
+
+	record := func(statuses []v1.ContainerStatus, init bool) {
+		for _, status := range statuses {
+			key := status.Name
+			if init {
+				key = "init/" + key
+			}
+			reason := ""
+			if status.LastTerminationState.Terminated != nil {
+				reason = status.LastTerminationState.Terminated.Reason
+			}
+			container, ok := tracked.containers[key]
+			if !ok {
+				container = &containerRestarts{init: init, restartCount: status.RestartCount}
+				tracked.containers[key] = container
+				if status.RestartCount > 0 && !startTime.IsZero() {
+					container.events = append(container.events, restartEvent{time: startTime, restarts: status.RestartCount, reason: reason})
+				}
+				continue
+			}
+			if status.RestartCount > container.restartCount {
+				container.events = append(container.events, restartEvent{time: now, restarts: status.RestartCount - container.restartCount, reason: reason})
+			}
+			container.restartCount = status.RestartCount
+		}
+	}
+	record(pod.Status.ContainerStatuses, false)
+	record(pod.Status.InitContainerStatuses, true)
+}
+
+// restartsWithin returns the number of restarts of the pod recorded within the
+// window, only counting the given termination reasons when any are set.
+func (t *restartTracker) restartsWithin(pod *v1.Pod, now time.Time, window time.Duration, reasons sets.Set[string], includingInitContainers bool) int32 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	tracked, ok := t.pods[pod.UID]
+	if !ok {
+		return 0
+	}
+	cutoff := now.Add(-window)
+	var restarts int32
+	for _, container := range tracked.containers {
+		if container.init && !includingInitContainers {
+			continue
+		}
+		for _, event := range container.events {
+			if event.time.Before(cutoff) {
+				continue
+			}
+			if reasons.Len() > 0 && !reasons.Has(event.reason) {
+				continue
+			}
+			restarts += event.restarts
+		}
+	}
+	return restarts
+}
@@ -19,6 +19,7 @@ package removepodshavingtoomanyrestarts
 import (
 	"context"
 	"fmt"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,6 +40,8 @@ type RemovePodsHavingTooManyRestarts struct {
 	handle    frameworktypes.Handle
 	args      *RemovePodsHavingTooManyRestartsArgs
 	podFilter podutil.FilterFunc
+	tracker   *restartTracker
+	now       func() time.Time
 }
 
 var _ frameworktypes.DeschedulePlugin = &RemovePodsHavingTooManyRestarts{}
@@ -50,17 +53,10 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		return nil, fmt.Errorf("want args to be of type RemovePodsHavingTooManyRestartsArgs, got %T", args)
 	}
 
-	var includedNamespaces, excludedNamespaces sets.Set[string]
-	if tooManyRestartsArgs.Namespaces != nil {
-		includedNamespaces = sets.New(tooManyRestartsArgs.Namespaces.Include...)
-		excludedNamespaces = sets.New(tooManyRestartsArgs.Namespaces.Exclude...)
-	}
-
 	// We can combine Filter and PreEvictionFilter since for this strategy it does not matter where we run PreEvictionFilter
 	podFilter, err := podutil.NewOptions().
 		WithFilter(podutil.WrapFilterFuncs(handle.Evictor().Filter, handle.Evictor().PreEvictionFilter)).
-		WithNamespaces(includedNamespaces).
-		WithoutNamespaces(excludedNamespaces).
+		WithNamespaceFilter(tooManyRestartsArgs.Namespaces, handle.SharedInformerFactory()).
 		WithLabelSelector(tooManyRestartsArgs.LabelSelector).
 		BuildFilterFunc()
 	if err != nil {
@@ -98,10 +94,16 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		})
 	}
 
+	if tooManyRestartsArgs.RestartRate != nil && tooManyRestartsArgs.RestartRate.WindowSeconds != nil {
+		defaultRestartTracker.ensureRetention(time.Duration(*tooManyRestartsArgs.RestartRate.WindowSeconds) * time.Second)
+	}
+
 	return &RemovePodsHavingTooManyRestarts{
 		handle:    handle,
 		args:      tooManyRestartsArgs,
 		podFilter: podFilter,
+		tracker:   defaultRestartTracker,
+		now:       time.Now,
 	}, nil
 }
 
@@ -112,6 +114,9 @@ func (d *RemovePodsHavingTooManyRestarts) Name() string {
 
 // Deschedule extension point implementation for the plugin
 func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	if d.args.RestartRate != nil {
+		d.tracker.prune(d.now())
+	}
 	for _, node := range nodes {
 		klog.V(2).InfoS("Processing node", "node", klog.KObj(node))
 		pods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
@@ -121,6 +126,14 @@ func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes
 				Err: fmt.Errorf("error listing pods on a node: %v", err),
 			}
 		}
+		if d.args.RestartRate != nil {
+			pods, err = d.filterByRestartRate(node, pods)
+			if err != nil {
+				return &frameworktypes.Status{
+					Err: fmt.Errorf("error listing pods on a node: %v", err),
+				}
+			}
+		}
 		totalPods := len(pods)
 		for i := 0; i < totalPods; i++ {
 			d.handle.Evictor().Evict(ctx, pods[i], evictions.EvictOptions{StrategyName: PluginName})
@@ -132,6 +145,49 @@ func (d *RemovePodsHavingTooManyRestarts) Deschedule(ctx context.Context, nodes
 	return nil
 }
 
+// filterByRestartRate records the restarts of all pods on the node and keeps the
+// given pods restarting faster than the configured rate. No pods are kept when
+// fewer than MinCrashingPodsPerNode pods on the node exceed the rate.
+func (d *RemovePodsHavingTooManyRestarts) filterByRestartRate(node *v1.Node, pods []*v1.Pod) ([]*v1.Pod, error) {
+	rate := d.args.RestartRate
+	now := d.now()
+	window := time.Hour
+	if rate.WindowSeconds != nil {
+		window = time.Duration(*rate.WindowSeconds) * time.Second
+	}
+	reasons := sets.New(rate.TerminationReasons...)
+
+	// every pod on the node is observed, not only the evictable ones, since
+	// pods that cannot be evicted still tell whether the node itself is faulty
+	allPods, err := podutil.ListAllPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), nil)
+	if err != nil {
+		return nil, err
+	}
+	crashing := sets.New[string]()
+	for _, pod := range allPods {
+		d.tracker.observe(pod, now)
+		restarts := d.tracker.restartsWithin(pod, now, window, reasons, d.args.IncludingInitContainers)
+		if restarts >= rate.Restarts {
+			crashing.Insert(string(pod.UID))
+		}
+	}
+
+	if rate.MinCrashingPodsPerNode != nil && uint(crashing.Len()) < *rate.MinCrashingPodsPerNode {
+		klog.V(4).InfoS("Not enough pods exceeding the restart rate on node", "node", klog.KObj(node), "crashingPods", crashing.Len(), "minCrashingPodsPerNode", *rate.MinCrashingPodsPerNode)
+		return nil, nil
+	}
+
+	var result []*v1.Pod
+	for _, pod := range pods {
+		if crashing.Has(string(pod.UID)) {
+			result = append(result, pod)
+		} else {
+			klog.V(4).InfoS("ignoring pod for eviction due to restart rate not exceeding the threshold", "pod", klog.KObj(pod))
+		}
+	}
+	return result, nil
+}
+
 // validateCanEvict looks at tooManyRestartsArgs to see if pod can be evicted given the args.
 func validateCanEvict(pod *v1.Pod, tooManyRestartsArgs *RemovePodsHavingTooManyRestartsArgs) error {
 	var err error
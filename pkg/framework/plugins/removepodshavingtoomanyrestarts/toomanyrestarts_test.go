@@ -20,14 +20,19 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
@@ -345,9 +350,7 @@ func TestRemovePodsHavingTooManyRestarts(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				policyv1.SchemeGroupVersion.String(),
-				false,
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
 				tc.maxPodsToEvictPerNode,
 				tc.maxNoOfPodsToEvictPerNamespace,
 				tc.nodes,
@@ -396,3 +399,202 @@ func TestRemovePodsHavingTooManyRestarts(t *testing.T) {
 		})
 	}
 }
+
+func TestRemovePodsHavingTooManyRestartsRestartRate(t *testing.T) {
+	now := time.Now()
+	node1 := test.BuildTestNode("node1", 2000, 3000, 10, nil)
+
+	buildPod := func(name string, age time.Duration, restarts int32, reason string) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, node1.Name, func(pod *v1.Pod) {
+			pod.UID = types.UID(name)
+			pod.ObjectMeta.OwnerReferences = test.GetNormalPodOwnerRefList()
+			pod.Status.StartTime = &metav1.Time{Time: now.Add(-age)}
+			pod.Status.ContainerStatuses = []v1.ContainerStatus{
+				{
+					Name:         "app",
+					RestartCount: restarts,
+					LastTerminationState: v1.ContainerState{
+						Terminated: &v1.ContainerStateTerminated{Reason: reason},
+					},
+				},
+			}
+		})
+	}
+
+	rate := func(restarts int32, reasons []string, minCrashingPods uint) *RestartRate {
+		return &RestartRate{
+			Restarts:               restarts,
+			WindowSeconds:          utilptr.To[uint](3600),
+			TerminationReasons:     reasons,
+			MinCrashingPodsPerNode: utilptr.To(minCrashingPods),
+		}
+	}
+
+	tests := []struct {
+		description             string
+		pods                    []*v1.Pod
+		args                    RemovePodsHavingTooManyRestartsArgs
+		observedBefore          []*v1.Pod
+		expectedEvictedPodCount uint
+	}{
+		{
+			description: "long-lived pod with many restarts is not evicted, recently created crashing pod is",
+			pods: []*v1.Pod{
+				buildPod("old", 48*time.Hour, 100, "Error"),
+				buildPod("new", 5*time.Minute, 5, "Error"),
+			},
+			args:                    RemovePodsHavingTooManyRestartsArgs{RestartRate: rate(3, nil, 1)},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "restarts since the previous cycle are counted",
+			pods: []*v1.Pod{
+				buildPod("old", 48*time.Hour, 105, "Error"),
+			},
+			observedBefore: []*v1.Pod{
+				buildPod("old", 48*time.Hour, 100, "Error"),
+			},
+			args:                    RemovePodsHavingTooManyRestartsArgs{RestartRate: rate(3, nil, 1)},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "restarts since the previous cycle below the rate",
+			pods: []*v1.Pod{
+				buildPod("old", 48*time.Hour, 102, "Error"),
+			},
+			observedBefore: []*v1.Pod{
+				buildPod("old", 48*time.Hour, 100, "Error"),
+			},
+			args:                    RemovePodsHavingTooManyRestartsArgs{RestartRate: rate(3, nil, 1)},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "only restarts with matching termination reasons are counted",
+			pods: []*v1.Pod{
+				buildPod("oom", 5*time.Minute, 5, "OOMKilled"),
+				buildPod("error", 5*time.Minute, 5, "Error"),
+			},
+			args:                    RemovePodsHavingTooManyRestartsArgs{RestartRate: rate(3, []string{"OOMKilled"}, 1)},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "not enough crashing pods on the node",
+			pods: []*v1.Pod{
+				buildPod("crashing", 5*time.Minute, 5, "Error"),
+				buildPod("healthy", 5*time.Minute, 0, ""),
+			},
+			args:                    RemovePodsHavingTooManyRestartsArgs{RestartRate: rate(3, nil, 2)},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "several crashing pods on the node",
+			pods: []*v1.Pod{
+				buildPod("crashing-1", 5*time.Minute, 5, "Error"),
+				buildPod("crashing-2", 5*time.Minute, 5, "OOMKilled"),
+				buildPod("healthy", 5*time.Minute, 0, ""),
+			},
+			args:                    RemovePodsHavingTooManyRestartsArgs{RestartRate: rate(3, nil, 2)},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "total restarts threshold still applies with a restart rate",
+			pods: []*v1.Pod{
+				buildPod("new", 5*time.Minute, 5, "Error"),
+			},
+			args:                    RemovePodsHavingTooManyRestartsArgs{PodRestartThreshold: 10, RestartRate: rate(3, nil, 1)},
+			expectedEvictedPodCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			objs = append(objs, node1)
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				nil,
+				nil,
+				[]*v1.Node{node1},
+				false,
+				&events.FakeRecorder{},
+			)
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+			}
+			evictorFilter, err := defaultevictor.New(&defaultevictor.DefaultEvictorArgs{}, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			handle.PodEvictorImpl = podEvictor
+			handle.EvictorFilterImpl = evictorFilter.(frameworktypes.EvictorPlugin)
+
+			plugin, err := New(&tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			tracker := newRestartTracker()
+			tracker.ensureRetention(time.Hour)
+			for _, pod := range tc.observedBefore {
+				tracker.observe(pod, now.Add(-10*time.Minute))
+			}
+			plugin.(*RemovePodsHavingTooManyRestarts).tracker = tracker
+			plugin.(*RemovePodsHavingTooManyRestarts).now = func() time.Time { return now }
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, []*v1.Node{node1})
+			actualEvictedPodCount := podEvictor.TotalEvicted()
+			if actualEvictedPodCount != tc.expectedEvictedPodCount {
+				t.Errorf("Test %#v failed, expected %v pod evictions, but got %v pod evictions\n", tc.description, tc.expectedEvictedPodCount, actualEvictedPodCount)
+			}
+		})
+	}
+}
+
+func TestRestartTrackerPrune(t *testing.T) {
+	now := time.Now()
+	pod := test.BuildTestPod("pod", 100, 0, "node1", func(pod *v1.Pod) {
+		pod.UID = "pod"
+		pod.Status.StartTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{{Name: "app", RestartCount: 5}}
+	})
+
+	tracker := newRestartTracker()
+	tracker.ensureRetention(time.Hour)
+	tracker.observe(pod, now.Add(-30*time.Minute))
+	pod.Status.ContainerStatuses[0].RestartCount = 8
+	tracker.observe(pod, now.Add(-20*time.Minute))
+
+	if got := tracker.restartsWithin(pod, now, 3*time.Hour, sets.New[string](), false); got != 8 {
+		t.Errorf("expected 8 restarts before pruning, got %v", got)
+	}
+	tracker.prune(now)
+	if got := tracker.restartsWithin(pod, now, 3*time.Hour, sets.New[string](), false); got != 3 {
+		t.Errorf("expected 3 restarts after pruning, got %v", got)
+	}
+	tracker.prune(now.Add(2 * time.Hour))
+	if _, ok := tracker.pods[pod.UID]; ok {
+		t.Errorf("expected pod not seen within the retention to be forgotten")
+	}
+}
@@ -30,4 +30,23 @@ type RemovePodsHavingTooManyRestartsArgs struct {
 	PodRestartThreshold     int32                 `json:"podRestartThreshold"`
 	IncludingInitContainers bool                  `json:"includingInitContainers"`
 	States                  []string              `json:"states"`
+	RestartRate             *RestartRate          `json:"restartRate,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// RestartRate evicts pods based on how often their containers restarted within
+// a time window rather than on the total number of restarts.
+type RestartRate struct {
+	// Restarts is the number of restarts within the window at which a pod should be evicted.
+	Restarts int32 `json:"restarts"`
+	// WindowSeconds is the length of the window restarts are counted in.
+	WindowSeconds *uint `json:"windowSeconds,omitempty"`
+	// TerminationReasons only counts restarts of containers that last terminated
+	// with one of the listed reasons (e.g. OOMKilled, Error). All restarts are
+	// counted when empty.
+	TerminationReasons []string `json:"terminationReasons,omitempty"`
+	// MinCrashingPodsPerNode only evicts pods from a node once at least this many
+	// pods on the node exceed the rate, pointing at a problem with the node itself.
+	MinCrashingPodsPerNode *uint `json:"minCrashingPodsPerNode,omitempty"`
 }
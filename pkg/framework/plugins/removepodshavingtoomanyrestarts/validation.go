@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/descheduler/pkg/api"
 )
 
 // ValidateRemovePodsHavingTooManyRestartsArgs validates RemovePodsHavingTooManyRestarts arguments
@@ -29,6 +31,9 @@ func ValidateRemovePodsHavingTooManyRestartsArgs(obj runtime.Object) error {
 	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
 		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
 	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		return err
+	}
 
 	if args.LabelSelector != nil {
 		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
@@ -36,8 +41,26 @@ func ValidateRemovePodsHavingTooManyRestartsArgs(obj runtime.Object) error {
 		}
 	}
 
-	if args.PodRestartThreshold < 1 {
-		return fmt.Errorf("invalid PodsHavingTooManyRestarts threshold")
+	if args.RestartRate == nil {
+		if args.PodRestartThreshold < 1 {
+			return fmt.Errorf("invalid PodsHavingTooManyRestarts threshold")
+		}
+	} else {
+		// the total restarts threshold is optional when evicting by restart rate
+		if args.PodRestartThreshold < 0 {
+			return fmt.Errorf("invalid PodsHavingTooManyRestarts threshold")
+		}
+		if args.RestartRate.Restarts < 1 {
+			return fmt.Errorf("restartRate.restarts must be greater than 0")
+		}
+		if args.RestartRate.WindowSeconds != nil && *args.RestartRate.WindowSeconds == 0 {
+			return fmt.Errorf("restartRate.windowSeconds must be greater than 0")
+		}
+		for _, reason := range args.RestartRate.TerminationReasons {
+			if reason == "" {
+				return fmt.Errorf("restartRate.terminationReasons must not contain empty reasons")
+			}
+		}
 	}
 
 	allowedStates := sets.New(
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	utilptr "k8s.io/utils/ptr"
 )
 
 func TestValidateRemovePodsHavingTooManyRestartsArgs(t *testing.T) {
@@ -59,6 +60,46 @@ func TestValidateRemovePodsHavingTooManyRestartsArgs(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			description: "restart rate without PodRestartThreshold, no errors",
+			args: &RemovePodsHavingTooManyRestartsArgs{
+				RestartRate: &RestartRate{
+					Restarts:           3,
+					WindowSeconds:      utilptr.To[uint](600),
+					TerminationReasons: []string{"OOMKilled", "Error"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			description: "invalid restart rate Restarts arg, expects errors",
+			args: &RemovePodsHavingTooManyRestartsArgs{
+				RestartRate: &RestartRate{
+					Restarts: 0,
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid restart rate WindowSeconds arg, expects errors",
+			args: &RemovePodsHavingTooManyRestartsArgs{
+				RestartRate: &RestartRate{
+					Restarts:      3,
+					WindowSeconds: utilptr.To[uint](0),
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "empty restart rate termination reason, expects errors",
+			args: &RemovePodsHavingTooManyRestartsArgs{
+				RestartRate: &RestartRate{
+					Restarts:           3,
+					TerminationReasons: []string{""},
+				},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range testCases {
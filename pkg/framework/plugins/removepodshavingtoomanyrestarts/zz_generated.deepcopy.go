@@ -46,6 +46,11 @@ func (in *RemovePodsHavingTooManyRestartsArgs) DeepCopyInto(out *RemovePodsHavin
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RestartRate != nil {
+		in, out := &in.RestartRate, &out.RestartRate
+		*out = new(RestartRate)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -66,3 +71,34 @@ func (in *RemovePodsHavingTooManyRestartsArgs) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartRate) DeepCopyInto(out *RestartRate) {
+	*out = *in
+	if in.WindowSeconds != nil {
+		in, out := &in.WindowSeconds, &out.WindowSeconds
+		*out = new(uint)
+		**out = **in
+	}
+	if in.TerminationReasons != nil {
+		in, out := &in.TerminationReasons, &out.TerminationReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinCrashingPodsPerNode != nil {
+		in, out := &in.MinCrashingPodsPerNode, &out.MinCrashingPodsPerNode
+		*out = new(uint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartRate.
+func (in *RestartRate) DeepCopy() *RestartRate {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartRate)
+	in.DeepCopyInto(out)
+	return out
+}
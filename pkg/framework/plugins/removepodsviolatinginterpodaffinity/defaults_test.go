@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinginterpodaffinity
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestSetDefaults_RemovePodsViolatingInterPodAffinityArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *RemovePodsViolatingInterPodAffinityArgs
+		want *RemovePodsViolatingInterPodAffinityArgs
+	}{
+		{
+			name: "RemovePodsViolatingInterPodAffinityArgs empty",
+			in:   &RemovePodsViolatingInterPodAffinityArgs{},
+			want: &RemovePodsViolatingInterPodAffinityArgs{
+				PodAffinityType: []string{requiredDuringSchedulingIgnoredDuringExecution},
+			},
+		},
+		{
+			name: "RemovePodsViolatingInterPodAffinityArgs with value",
+			in: &RemovePodsViolatingInterPodAffinityArgs{
+				Namespaces: &api.Namespaces{Include: []string{"default"}},
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"foo": "bar"},
+				},
+				PodAffinityType: []string{preferredDuringSchedulingIgnoredDuringExecution},
+			},
+			want: &RemovePodsViolatingInterPodAffinityArgs{
+				Namespaces: &api.Namespaces{Include: []string{"default"}},
+				LabelSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"foo": "bar"},
+				},
+				PodAffinityType: []string{preferredDuringSchedulingIgnoredDuringExecution},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RemovePodsViolatingInterPodAffinityArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
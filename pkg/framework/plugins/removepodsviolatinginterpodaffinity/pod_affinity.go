@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinginterpodaffinity
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/pkg/utils"
+)
+
+const (
+	PluginName = "RemovePodsViolatingInterPodAffinity"
+
+	requiredDuringSchedulingIgnoredDuringExecution  = "requiredDuringSchedulingIgnoredDuringExecution"
+	preferredDuringSchedulingIgnoredDuringExecution = "preferredDuringSchedulingIgnoredDuringExecution"
+)
+
+// RemovePodsViolatingInterPodAffinity evicts pods whose required pod affinity is no longer
+// satisfied in the topology domain of their node, or optionally whose preferred pod affinity
+// is better satisfied on another node.
+type RemovePodsViolatingInterPodAffinity struct {
+	handle    frameworktypes.Handle
+	args      *RemovePodsViolatingInterPodAffinityArgs
+	podFilter podutil.FilterFunc
+}
+
+var _ frameworktypes.DeschedulePlugin = &RemovePodsViolatingInterPodAffinity{}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	interPodAffinityArgs, ok := args.(*RemovePodsViolatingInterPodAffinityArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RemovePodsViolatingInterPodAffinityArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithNamespaceFilter(interPodAffinityArgs.Namespaces, handle.SharedInformerFactory()).
+		WithLabelSelector(interPodAffinityArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	return &RemovePodsViolatingInterPodAffinity{
+		handle:    handle,
+		podFilter: podFilter,
+		args:      interPodAffinityArgs,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RemovePodsViolatingInterPodAffinity) Name() string {
+	return PluginName
+}
+
+func (d *RemovePodsViolatingInterPodAffinity) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	// the affinity terms are matched against all the pods, not only the ones the plugin may evict
+	pods, err := podutil.ListPodsOnNodes(nodes, d.getPodsAssignedToNode(), nil)
+	if err != nil {
+		return &frameworktypes.Status{
+			Err: fmt.Errorf("error listing all pods: %v", err),
+		}
+	}
+	podsInANamespace := podutil.GroupByNamespace(pods)
+	nodeMap := utils.CreateNodeMap(nodes)
+	fitOptions := d.nodeFitOptions(nodes)
+
+	for _, podAffinityType := range d.args.PodAffinityType {
+		klog.V(2).InfoS("Executing for podAffinityType", "podAffinity", podAffinityType)
+		var violates func(pod *v1.Pod, node *v1.Node) bool
+		switch podAffinityType {
+		case requiredDuringSchedulingIgnoredDuringExecution:
+			// the pod must fit on another node satisfying its pod affinity, otherwise evicting it
+			// leaves it pending
+			violates = func(pod *v1.Pod, node *v1.Node) bool {
+				return utils.CheckPodAffinityViolated(pod, podsInANamespace, nodeMap) &&
+					nodeutil.PodFitsAnyOtherNode(d.handle.GetPodsAssignedToNodeFunc(), pod, nodes, fitOptions...)
+			}
+		case preferredDuringSchedulingIgnoredDuringExecution:
+			// the pod must fit on another node where more of its preferred pod affinity is satisfied
+			violates = func(pod *v1.Pod, node *v1.Node) bool {
+				if pod.Spec.Affinity == nil || pod.Spec.Affinity.PodAffinity == nil || len(pod.Spec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution) == 0 {
+					return false
+				}
+				weight := utils.GetPodAffinityWeight(pod, node, podsInANamespace, nodeMap)
+				for _, other := range nodes {
+					if other.Name == node.Name || utils.GetPodAffinityWeight(pod, other, podsInANamespace, nodeMap) <= weight {
+						continue
+					}
+					if len(nodeutil.NodeFit(d.handle.GetPodsAssignedToNodeFunc(), pod, other, fitOptions...)) == 0 {
+						return true
+					}
+				}
+				return false
+			}
+		default:
+			klog.ErrorS(nil, "Invalid podAffinityType", "podAffinity", podAffinityType)
+			continue
+		}
+		if status := d.processNodes(ctx, nodes, violates); status != nil {
+			return status
+		}
+	}
+	return nil
+}
+
+func (d *RemovePodsViolatingInterPodAffinity) processNodes(ctx context.Context, nodes []*v1.Node, violates func(*v1.Pod, *v1.Node) bool) *frameworktypes.Status {
+	for _, node := range nodes {
+		klog.V(2).InfoS("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(
+			node.Name,
+			d.getPodsAssignedToNode(),
+			podutil.WrapFilterFuncs(d.podFilter, func(pod *v1.Pod) bool {
+				return violates(pod, node)
+			}),
+		)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		// sort the evict-able Pods based on priority, if there are multiple pods with same priority, they are sorted based on QoS tiers.
+		podutil.SortPodsBasedOnPriorityLowToHigh(pods)
+		for _, pod := range pods {
+			if d.handle.Evictor().Filter(pod) && d.handle.Evictor().PreEvictionFilter(pod) {
+				d.handle.Evictor().Evict(ctx, pod, evictions.EvictOptions{StrategyName: PluginName})
+			}
+			if d.handle.Evictor().NodeLimitExceeded(node) {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// getPodsAssignedToNode lists the pods from the node snapshot when available, so pods
+// evicted for one pod affinity type are not processed again for the other.
+func (d *RemovePodsViolatingInterPodAffinity) getPodsAssignedToNode() podutil.GetPodsAssignedToNodeFunc {
+	if snapshot := d.handle.NodeSnapshot(); snapshot != nil {
+		return snapshot.GetPodsAssignedToNode
+	}
+	return d.handle.GetPodsAssignedToNodeFunc()
+}
+
+// nodeFitOptions checks the pod affinity of the pods on top of the default predicates
+func (d *RemovePodsViolatingInterPodAffinity) nodeFitOptions(nodes []*v1.Node) []nodeutil.NodeFitOption {
+	options := []nodeutil.NodeFitOption{
+		nodeutil.WithPredicates(nodeutil.DefaultNodeFitPredicates.Clone().Insert(nodeutil.InterPodAffinityPredicate)),
+		nodeutil.WithNodes(nodes),
+	}
+	if snapshot := d.handle.NodeSnapshot(); snapshot != nil {
+		options = append(options, nodeutil.WithSnapshot(snapshot))
+	}
+	return options
+}
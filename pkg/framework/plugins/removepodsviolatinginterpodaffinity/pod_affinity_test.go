@@ -0,0 +1,197 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinginterpodaffinity
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/events"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+func TestPodAffinity(t *testing.T) {
+	withZone := func(zone string) func(node *v1.Node) {
+		return func(node *v1.Node) {
+			node.Labels = map[string]string{"zone": zone}
+		}
+	}
+	nodeA := test.BuildTestNode("nodeA", 2000, 3000, 10, withZone("a"))
+	nodeB := test.BuildTestNode("nodeB", 2000, 3000, 10, withZone("b"))
+	nodeNoZone := test.BuildTestNode("nodeNoZone", 2000, 3000, 10, nil)
+
+	term := func(app string) v1.PodAffinityTerm {
+		return v1.PodAffinityTerm{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+			TopologyKey:   "zone",
+		}
+	}
+	buildPod := func(name, nodeName, app string, affinity *v1.PodAffinity) *v1.Pod {
+		return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+			pod.OwnerReferences = test.GetNormalPodOwnerRefList()
+			pod.Labels = map[string]string{"app": app}
+			if affinity != nil {
+				pod.Spec.Affinity = &v1.Affinity{PodAffinity: affinity}
+			}
+		})
+	}
+	required := func(app string) *v1.PodAffinity {
+		return &v1.PodAffinity{RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{term(app)}}
+	}
+	preferred := func(app string) *v1.PodAffinity {
+		return &v1.PodAffinity{PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{{Weight: 50, PodAffinityTerm: term(app)}}}
+	}
+	db := buildPod("db", nodeB.Name, "db", nil)
+
+	tests := []struct {
+		description             string
+		pods                    []*v1.Pod
+		nodes                   []*v1.Node
+		podAffinityType         []string
+		expectedEvictedPodCount uint
+	}{
+		{
+			description:             "Evict pod whose required affinity is not satisfied in its zone",
+			pods:                    []*v1.Pod{db, buildPod("web", nodeA.Name, "web", required("db"))},
+			nodes:                   []*v1.Node{nodeA, nodeB},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Pod whose required affinity is satisfied in its zone is not evicted",
+			pods:                    []*v1.Pod{db, buildPod("web", nodeB.Name, "web", required("db"))},
+			nodes:                   []*v1.Node{nodeA, nodeB},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Pod is not evicted when no node satisfies its required affinity",
+			pods:                    []*v1.Pod{buildPod("web", nodeA.Name, "web", required("db"))},
+			nodes:                   []*v1.Node{nodeA, nodeB},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "First pod of a group with affinity to itself is not evicted",
+			pods:                    []*v1.Pod{buildPod("cache", nodeA.Name, "cache", required("cache"))},
+			nodes:                   []*v1.Node{nodeA, nodeB},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Evict pod on a node missing the topology label",
+			pods:                    []*v1.Pod{db, buildPod("web", nodeNoZone.Name, "web", required("db"))},
+			nodes:                   []*v1.Node{nodeA, nodeB, nodeNoZone},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Preferred affinity is ignored by default",
+			pods:                    []*v1.Pod{db, buildPod("web", nodeA.Name, "web", preferred("db"))},
+			nodes:                   []*v1.Node{nodeA, nodeB},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description:             "Evict pod whose preferred affinity is better satisfied on another node",
+			pods:                    []*v1.Pod{db, buildPod("web", nodeA.Name, "web", preferred("db"))},
+			nodes:                   []*v1.Node{nodeA, nodeB},
+			podAffinityType:         []string{requiredDuringSchedulingIgnoredDuringExecution, preferredDuringSchedulingIgnoredDuringExecution},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description:             "Pod whose preferred affinity is satisfied is not evicted",
+			pods:                    []*v1.Pod{db, buildPod("web", nodeB.Name, "web", preferred("db"))},
+			nodes:                   []*v1.Node{nodeA, nodeB},
+			podAffinityType:         []string{preferredDuringSchedulingIgnoredDuringExecution},
+			expectedEvictedPodCount: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var objs []runtime.Object
+			for _, node := range tc.nodes {
+				objs = append(objs, node)
+			}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				nil,
+				nil,
+				tc.nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+			}
+			args := &RemovePodsViolatingInterPodAffinityArgs{PodAffinityType: tc.podAffinityType}
+			SetDefaults_RemovePodsViolatingInterPodAffinityArgs(args)
+			plugin, err := New(args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, tc.nodes)
+			if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != tc.expectedEvictedPodCount {
+				t.Errorf("Unexpected no of pods evicted: pods evicted: %d, expected: %d", podsEvicted, tc.expectedEvictedPodCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinginterpodaffinity
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// ValidateRemovePodsViolatingInterPodAffinityArgs validates RemovePodsViolatingInterPodAffinity arguments
+func ValidateRemovePodsViolatingInterPodAffinityArgs(obj runtime.Object) error {
+	args := obj.(*RemovePodsViolatingInterPodAffinityArgs)
+	if len(args.PodAffinityType) == 0 {
+		return fmt.Errorf("podAffinityType needs to be set")
+	}
+	for _, podAffinityType := range args.PodAffinityType {
+		if podAffinityType != requiredDuringSchedulingIgnoredDuringExecution && podAffinityType != preferredDuringSchedulingIgnoredDuringExecution {
+			return fmt.Errorf("invalid podAffinityType %q, must be one of %q or %q", podAffinityType, requiredDuringSchedulingIgnoredDuringExecution, preferredDuringSchedulingIgnoredDuringExecution)
+		}
+	}
+
+	// At most one of include/exclude can be set
+	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
+		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		return err
+	}
+
+	if args.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
+			return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removepodsviolatinginterpodaffinity
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRemovePodsViolatingInterPodAffinityArgs(t *testing.T) {
+	testCases := []struct {
+		description string
+		args        *RemovePodsViolatingInterPodAffinityArgs
+		expectError bool
+	}{
+		{
+			description: "valid arg, no errors",
+			args: &RemovePodsViolatingInterPodAffinityArgs{
+				PodAffinityType: []string{requiredDuringSchedulingIgnoredDuringExecution, preferredDuringSchedulingIgnoredDuringExecution},
+			},
+			expectError: false,
+		},
+		{
+			description: "empty podAffinityType, expects errors",
+			args:        &RemovePodsViolatingInterPodAffinityArgs{},
+			expectError: true,
+		},
+		{
+			description: "invalid podAffinityType, expects errors",
+			args: &RemovePodsViolatingInterPodAffinityArgs{
+				PodAffinityType: []string{requiredDuringSchedulingIgnoredDuringExecution, "requiredDuringSchedulingRequiredDuringExecution"},
+			},
+			expectError: true,
+		},
+		{
+			description: "namespace include and exclude, expects errors",
+			args: &RemovePodsViolatingInterPodAffinityArgs{
+				PodAffinityType: []string{requiredDuringSchedulingIgnoredDuringExecution},
+				Namespaces: &api.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid label selector, expects errors",
+			args: &RemovePodsViolatingInterPodAffinityArgs{
+				PodAffinityType: []string{requiredDuringSchedulingIgnoredDuringExecution},
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Foo"}},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRemovePodsViolatingInterPodAffinityArgs(tc.args)
+
+			hasError := err != nil
+			if tc.expectError != hasError {
+				t.Errorf("unexpected arg validation behavior: %v", err)
+			}
+		})
+	}
+}
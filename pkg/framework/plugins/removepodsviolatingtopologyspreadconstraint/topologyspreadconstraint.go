@@ -66,9 +66,10 @@ type topologySpreadConstraint struct {
 
 // RemovePodsViolatingTopologySpreadConstraint evicts pods which violate their topology spread constraints
 type RemovePodsViolatingTopologySpreadConstraint struct {
-	handle    frameworktypes.Handle
-	args      *RemovePodsViolatingTopologySpreadConstraintArgs
-	podFilter podutil.FilterFunc
+	handle          frameworktypes.Handle
+	args            *RemovePodsViolatingTopologySpreadConstraintArgs
+	podFilter       podutil.FilterFunc
+	namespaceFilter podutil.FilterFunc
 }
 
 var _ frameworktypes.BalancePlugin = &RemovePodsViolatingTopologySpreadConstraint{}
@@ -88,10 +89,19 @@ func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plug
 		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
 	}
 
+	// namespaces are checked once per namespace in Balance rather than for every pod
+	namespaceFilter, err := podutil.NewOptions().
+		WithNamespaceFilter(pluginArgs.Namespaces, handle.SharedInformerFactory()).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing namespace filter function: %v", err)
+	}
+
 	return &RemovePodsViolatingTopologySpreadConstraint{
-		handle:    handle,
-		podFilter: podFilter,
-		args:      pluginArgs,
+		handle:          handle,
+		podFilter:       podFilter,
+		namespaceFilter: namespaceFilter,
+		args:            pluginArgs,
 	}, nil
 }
 
@@ -121,11 +131,6 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Contex
 
 	klog.V(1).Info("Processing namespaces for topology spread constraints")
 	podsForEviction := make(map[*v1.Pod]struct{})
-	var includedNamespaces, excludedNamespaces sets.Set[string]
-	if d.args.Namespaces != nil {
-		includedNamespaces = sets.New(d.args.Namespaces.Include...)
-		excludedNamespaces = sets.New(d.args.Namespaces.Exclude...)
-	}
 
 	pods, err := podutil.ListPodsOnNodes(nodes, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
 	if err != nil {
@@ -142,8 +147,8 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) Balance(ctx context.Contex
 	for namespace := range namespacedPods {
 		klog.V(4).InfoS("Processing namespace for topology spread constraints", "namespace", namespace)
 
-		if (len(includedNamespaces) > 0 && !includedNamespaces.Has(namespace)) ||
-			(len(excludedNamespaces) > 0 && excludedNamespaces.Has(namespace)) {
+		// the namespace filter only looks at the namespace of the pod
+		if !d.namespaceFilter(namespacedPods[namespace][0]) {
 			continue
 		}
 
@@ -306,6 +311,10 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) balanceDomains(
 	sortedDomains := sortDomains(constraintTopologies, isEvictable)
 	getPodsAssignedToNode := d.handle.GetPodsAssignedToNodeFunc()
 	topologyBalanceNodeFit := utilpointer.BoolDeref(d.args.TopologyBalanceNodeFit, true)
+	var nodeFitOptions []node.NodeFitOption
+	if snapshot := d.handle.NodeSnapshot(); snapshot != nil {
+		nodeFitOptions = append(nodeFitOptions, node.WithSnapshot(snapshot))
+	}
 
 	eligibleNodes := filterEligibleNodes(nodes, tsc)
 	nodesBelowIdealAvg := filterNodesBelowIdealAvg(eligibleNodes, sortedDomains, tsc.TopologyKey, idealAvg)
@@ -361,7 +370,7 @@ func (d *RemovePodsViolatingTopologySpreadConstraint) balanceDomains(
 			// This is because the chosen pods aren't sorted, but immovable pods still count as "evicted" toward the PTS algorithm.
 			// So, a better selection heuristic could improve performance.
 
-			if topologyBalanceNodeFit && !node.PodFitsAnyOtherNode(getPodsAssignedToNode, aboveToEvict[k], nodesBelowIdealAvg) {
+			if topologyBalanceNodeFit && !node.PodFitsAnyOtherNode(getPodsAssignedToNode, aboveToEvict[k], nodesBelowIdealAvg, nodeFitOptions...) {
 				klog.V(2).InfoS("ignoring pod for eviction as it does not fit on any other node", "pod", klog.KObj(aboveToEvict[k]))
 				continue
 			}
@@ -1457,9 +1457,7 @@ func TestTopologySpreadConstraint(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				fakeClient,
-				"v1",
-				false,
+				evictions.NewEvictionAPIBackend(fakeClient, "v1"),
 				nil,
 				nil,
 				tc.nodes,
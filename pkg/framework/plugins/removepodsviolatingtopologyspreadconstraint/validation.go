@@ -24,6 +24,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/descheduler/pkg/api"
 )
 
 // ValidateRemovePodsViolatingTopologySpreadConstraintArgs validates RemovePodsViolatingTopologySpreadConstraint arguments
@@ -35,6 +37,9 @@ func ValidateRemovePodsViolatingTopologySpreadConstraintArgs(obj runtime.Object)
 	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
 		errs = append(errs, fmt.Errorf("only one of Include/Exclude namespaces can be set"))
 	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		errs = append(errs, err)
+	}
 
 	if args.LabelSelector != nil {
 		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotatelonglivedpods
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_RotateLongLivedPodsArgs
+// TODO: the final default values would be discussed in community
+func SetDefaults_RotateLongLivedPodsArgs(obj runtime.Object) {
+	args := obj.(*RotateLongLivedPodsArgs)
+	if args.Namespaces == nil {
+		args.Namespaces = nil
+	}
+	if args.LabelSelector == nil {
+		args.LabelSelector = nil
+	}
+	if args.MaxPodLifeTimeJitterSeconds == nil {
+		args.MaxPodLifeTimeJitterSeconds = utilptr.To[uint](0)
+	}
+	if args.MaxPodsPerOwner == nil {
+		args.MaxPodsPerOwner = utilptr.To(intstr.FromInt32(1))
+	}
+	if args.TimeZone == "" {
+		args.TimeZone = "UTC"
+	}
+}
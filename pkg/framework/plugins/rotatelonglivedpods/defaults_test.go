@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotatelonglivedpods
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilptr "k8s.io/utils/ptr"
+)
+
+func TestSetDefaults_RotateLongLivedPodsArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *RotateLongLivedPodsArgs
+		want *RotateLongLivedPodsArgs
+	}{
+		{
+			name: "RotateLongLivedPodsArgs empty",
+			in:   &RotateLongLivedPodsArgs{},
+			want: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeJitterSeconds: utilptr.To[uint](0),
+				MaxPodsPerOwner:             utilptr.To(intstr.FromInt32(1)),
+				TimeZone:                    "UTC",
+			},
+		},
+		{
+			name: "RotateLongLivedPodsArgs with value",
+			in: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds:       utilptr.To[uint](86400),
+				MaxPodLifeTimeJitterSeconds: utilptr.To[uint](3600),
+				MaxPodsPerOwner:             utilptr.To(intstr.FromString("25%")),
+				TimeWindows:                 []TimeWindow{{Start: "22:00", End: "04:00", Days: []string{"Saturday", "Sunday"}}},
+				TimeZone:                    "Europe/Prague",
+			},
+			want: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds:       utilptr.To[uint](86400),
+				MaxPodLifeTimeJitterSeconds: utilptr.To[uint](3600),
+				MaxPodsPerOwner:             utilptr.To(intstr.FromString("25%")),
+				TimeWindows:                 []TimeWindow{{Start: "22:00", End: "04:00", Days: []string{"Saturday", "Sunday"}}},
+				TimeZone:                    "Europe/Prague",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_RotateLongLivedPodsArgs(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("Got unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
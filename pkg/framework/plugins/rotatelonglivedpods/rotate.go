@@ -0,0 +1,260 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotatelonglivedpods
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+)
+
+const PluginName = "RotateLongLivedPods"
+
+var weekDays = func() map[string]time.Weekday {
+	days := map[string]time.Weekday{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		days[day.String()] = day
+	}
+	return days
+}()
+
+// RotateLongLivedPods evicts pods exceeding their maximum lifetime gradually,
+// a limited number of pods per owner in each descheduling cycle, so long-lived
+// workloads get refreshed without all replicas being evicted at once.
+type RotateLongLivedPods struct {
+	handle      frameworktypes.Handle
+	args        *RotateLongLivedPodsArgs
+	podFilter   podutil.FilterFunc
+	location    *time.Location
+	timeWindows []timeWindow
+	now         func() time.Time
+}
+
+var _ frameworktypes.DeschedulePlugin = &RotateLongLivedPods{}
+
+type timeWindow struct {
+	start time.Duration
+	end   time.Duration
+	days  sets.Set[time.Weekday]
+}
+
+// New builds plugin from its arguments while passing a handle
+func New(args runtime.Object, handle frameworktypes.Handle) (frameworktypes.Plugin, error) {
+	rotateArgs, ok := args.(*RotateLongLivedPodsArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type RotateLongLivedPodsArgs, got %T", args)
+	}
+
+	podFilter, err := podutil.NewOptions().
+		WithNamespaceFilter(rotateArgs.Namespaces, handle.SharedInformerFactory()).
+		WithLabelSelector(rotateArgs.LabelSelector).
+		BuildFilterFunc()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pod filter function: %v", err)
+	}
+
+	location, err := time.LoadLocation(rotateArgs.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeZone %q: %v", rotateArgs.TimeZone, err)
+	}
+
+	var timeWindows []timeWindow
+	for _, window := range rotateArgs.TimeWindows {
+		start, err := parseTimeOfDay(window.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time window start %q: %v", window.Start, err)
+		}
+		end, err := parseTimeOfDay(window.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time window end %q: %v", window.End, err)
+		}
+		days := sets.New[time.Weekday]()
+		for _, day := range window.Days {
+			weekDay, ok := weekDays[day]
+			if !ok {
+				return nil, fmt.Errorf("invalid time window day %q", day)
+			}
+			days.Insert(weekDay)
+		}
+		timeWindows = append(timeWindows, timeWindow{start: start, end: end, days: days})
+	}
+
+	return &RotateLongLivedPods{
+		handle:      handle,
+		args:        rotateArgs,
+		podFilter:   podFilter,
+		location:    location,
+		timeWindows: timeWindows,
+		now:         time.Now,
+	}, nil
+}
+
+// Name retrieves the plugin name
+func (d *RotateLongLivedPods) Name() string {
+	return PluginName
+}
+
+type rotationCandidate struct {
+	pod   *v1.Pod
+	node  *v1.Node
+	owner string
+}
+
+// Deschedule extension point implementation for the plugin
+func (d *RotateLongLivedPods) Deschedule(ctx context.Context, nodes []*v1.Node) *frameworktypes.Status {
+	now := d.now()
+	if !d.inTimeWindow(now.In(d.location)) {
+		klog.V(2).InfoS("Outside of the time windows, skipping pod rotation")
+		return nil
+	}
+
+	replicas := map[string]int{}
+	var candidates []rotationCandidate
+	for _, node := range nodes {
+		klog.V(1).InfoS("Processing node", "node", klog.KObj(node))
+		pods, err := podutil.ListPodsOnANode(node.Name, d.handle.GetPodsAssignedToNodeFunc(), d.podFilter)
+		if err != nil {
+			return &frameworktypes.Status{
+				Err: fmt.Errorf("error listing pods on a node: %v", err),
+			}
+		}
+		for _, pod := range pods {
+			owner := ownerKey(pod)
+			if owner == "" {
+				continue
+			}
+			replicas[owner]++
+			if now.Sub(pod.CreationTimestamp.Time) >= d.maxPodLifeTime(pod) {
+				candidates = append(candidates, rotationCandidate{pod: pod, node: node, owner: owner})
+			}
+		}
+	}
+
+	// rotate the oldest pods first
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].pod.CreationTimestamp.Before(&candidates[j].pod.CreationTimestamp)
+	})
+
+	evictedPerOwner := map[string]int{}
+	for _, candidate := range candidates {
+		if evictedPerOwner[candidate.owner] >= d.maxPodsForOwner(replicas[candidate.owner]) {
+			continue
+		}
+		if d.handle.Evictor().NodeLimitExceeded(candidate.node) {
+			continue
+		}
+		if !d.handle.Evictor().Filter(candidate.pod) || !d.handle.Evictor().PreEvictionFilter(candidate.pod) {
+			continue
+		}
+		klog.V(2).InfoS("Rotating long-lived pod", "pod", klog.KObj(candidate.pod), "owner", candidate.owner)
+		if d.handle.Evictor().Evict(ctx, candidate.pod, evictions.EvictOptions{StrategyName: PluginName}) {
+			evictedPerOwner[candidate.owner]++
+		}
+	}
+	return nil
+}
+
+// maxPodLifeTime returns the lifetime of the pod including its jitter. The
+// jitter is derived from the pod UID so it stays the same between cycles.
+func (d *RotateLongLivedPods) maxPodLifeTime(pod *v1.Pod) time.Duration {
+	maxPodLifeTime := time.Duration(*d.args.MaxPodLifeTimeSeconds) * time.Second
+	if d.args.MaxPodLifeTimeJitterSeconds == nil || *d.args.MaxPodLifeTimeJitterSeconds == 0 {
+		return maxPodLifeTime
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(pod.UID))
+	jitter := uint64(hash.Sum32()) % uint64(*d.args.MaxPodLifeTimeJitterSeconds)
+	return maxPodLifeTime + time.Duration(jitter)*time.Second
+}
+
+// maxPodsForOwner resolves MaxPodsPerOwner against the number of replicas of
+// an owner. At least one pod is rotated per owner so percentages do not stall
+// the rotation of small owners.
+func (d *RotateLongLivedPods) maxPodsForOwner(replicas int) int {
+	if d.args.MaxPodsPerOwner == nil {
+		return 1
+	}
+	maxPods, err := intstr.GetScaledValueFromIntOrPercent(d.args.MaxPodsPerOwner, replicas, false)
+	if err != nil || maxPods < 1 {
+		return 1
+	}
+	return maxPods
+}
+
+// inTimeWindow checks whether now falls into any of the time windows.
+func (d *RotateLongLivedPods) inTimeWindow(now time.Time) bool {
+	if len(d.timeWindows) == 0 {
+		return true
+	}
+	timeOfDay := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute + time.Duration(now.Second())*time.Second
+	for _, window := range d.timeWindows {
+		day := now.Weekday()
+		if window.start < window.end {
+			if timeOfDay < window.start || timeOfDay >= window.end {
+				continue
+			}
+		} else {
+			// the window spans midnight, the part after midnight belongs to the window of the previous day
+			if timeOfDay < window.start && timeOfDay >= window.end {
+				continue
+			}
+			if timeOfDay < window.start {
+				day = (day + 6) % 7
+			}
+		}
+		if window.days.Len() == 0 || window.days.Has(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// ownerKey identifies the owner of a pod by its controller, or its first owner
+// when none of the owner references is marked as controller.
+func ownerKey(pod *v1.Pod) string {
+	ownerRef := metav1.GetControllerOf(pod)
+	if ownerRef == nil {
+		ownerRefs := podutil.OwnerRef(pod)
+		if len(ownerRefs) == 0 {
+			return ""
+		}
+		ownerRef = &ownerRefs[0]
+	}
+	return fmt.Sprintf("%s/%s/%s", pod.Namespace, ownerRef.Kind, ownerRef.Name)
+}
+
+// parseTimeOfDay parses a HH:MM time into the duration since midnight.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,362 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotatelonglivedpods
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
+	frameworkfake "sigs.k8s.io/descheduler/pkg/framework/fake"
+	"sigs.k8s.io/descheduler/pkg/framework/plugins/defaultevictor"
+	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
+	"sigs.k8s.io/descheduler/test"
+)
+
+// a Wednesday
+var testNow = time.Date(2024, time.June, 5, 10, 0, 0, 0, time.UTC)
+
+func buildTestPod(name, nodeName, owner string, age time.Duration) *v1.Pod {
+	return test.BuildTestPod(name, 100, 0, nodeName, func(pod *v1.Pod) {
+		pod.UID = types.UID(name)
+		pod.CreationTimestamp = metav1.NewTime(testNow.Add(-age))
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{Kind: "ReplicaSet", APIVersion: "v1", Name: owner, Controller: utilptr.To(true)},
+		}
+	})
+}
+
+func TestRotateLongLivedPods(t *testing.T) {
+	node1 := test.BuildTestNode("node1", 2000, 3000, 10, nil)
+	node2 := test.BuildTestNode("node2", 2000, 3000, 10, nil)
+
+	ownerPods := func(owner string, count int, age time.Duration) []*v1.Pod {
+		var pods []*v1.Pod
+		for i := 0; i < count; i++ {
+			nodeName := node1.Name
+			if i%2 == 1 {
+				nodeName = node2.Name
+			}
+			pods = append(pods, buildTestPod(fmt.Sprintf("%s-%d", owner, i), nodeName, owner, age+time.Duration(i)*time.Minute))
+		}
+		return pods
+	}
+
+	tests := []struct {
+		description             string
+		pods                    []*v1.Pod
+		args                    *RotateLongLivedPodsArgs
+		expectedEvictedPodCount uint
+		expectedEvictedPods     []string
+	}{
+		{
+			description: "no pods exceeding their lifetime",
+			pods:        ownerPods("rs-a", 4, time.Hour),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+			},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "one pod per owner is rotated by default, oldest first",
+			pods:        ownerPods("rs-a", 4, 48*time.Hour),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+			},
+			expectedEvictedPodCount: 1,
+			expectedEvictedPods:     []string{"rs-a-3"},
+		},
+		{
+			description: "owners are rotated independently",
+			pods:        append(ownerPods("rs-a", 4, 48*time.Hour), ownerPods("rs-b", 2, 48*time.Hour)...),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+			},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "pods below their lifetime are not counted against the owner limit",
+			pods:        append(ownerPods("rs-a", 2, time.Hour), buildTestPod("rs-a-old", node1.Name, "rs-a", 48*time.Hour)),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+				MaxPodsPerOwner:       utilptr.To(intstr.FromInt32(2)),
+			},
+			expectedEvictedPodCount: 1,
+			expectedEvictedPods:     []string{"rs-a-old"},
+		},
+		{
+			description: "number of pods per owner",
+			pods:        ownerPods("rs-a", 4, 48*time.Hour),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+				MaxPodsPerOwner:       utilptr.To(intstr.FromInt32(3)),
+			},
+			expectedEvictedPodCount: 3,
+		},
+		{
+			description: "percentage of pods per owner",
+			pods:        ownerPods("rs-a", 4, 48*time.Hour),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+				MaxPodsPerOwner:       utilptr.To(intstr.FromString("50%")),
+			},
+			expectedEvictedPodCount: 2,
+		},
+		{
+			description: "percentage of pods per owner rotates at least one pod",
+			pods:        ownerPods("rs-a", 4, 48*time.Hour),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+				MaxPodsPerOwner:       utilptr.To(intstr.FromString("10%")),
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "pods without owner are not rotated",
+			pods: []*v1.Pod{
+				test.BuildTestPod("bare", 100, 0, node1.Name, func(pod *v1.Pod) {
+					pod.CreationTimestamp = metav1.NewTime(testNow.Add(-48 * time.Hour))
+				}),
+			},
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+			},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "inside of a time window",
+			pods:        ownerPods("rs-a", 4, 48*time.Hour),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+				TimeWindows:           []TimeWindow{{Start: "09:00", End: "11:00", Days: []string{"Wednesday"}}},
+			},
+			expectedEvictedPodCount: 1,
+		},
+		{
+			description: "outside of the time windows",
+			pods:        ownerPods("rs-a", 4, 48*time.Hour),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+				TimeWindows: []TimeWindow{
+					{Start: "22:00", End: "04:00"},
+					{Start: "09:00", End: "11:00", Days: []string{"Saturday", "Sunday"}},
+				},
+			},
+			expectedEvictedPodCount: 0,
+		},
+		{
+			description: "time window in a different time zone",
+			pods:        ownerPods("rs-a", 4, 48*time.Hour),
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](24 * 3600),
+				TimeWindows:           []TimeWindow{{Start: "12:00", End: "13:00"}},
+				TimeZone:              "Etc/GMT-2",
+			},
+			expectedEvictedPodCount: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{node1, node2}
+			objs := []runtime.Object{node1, node2}
+			for _, pod := range tc.pods {
+				objs = append(objs, pod)
+			}
+			fakeClient := fake.NewSimpleClientset(objs...)
+			var evictedPods []string
+			fakeClient.PrependReactor("create", "pods", podEvictionReactionFunc(&evictedPods))
+
+			sharedInformerFactory := informers.NewSharedInformerFactory(fakeClient, 0)
+			podInformer := sharedInformerFactory.Core().V1().Pods().Informer()
+
+			getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(podInformer)
+			if err != nil {
+				t.Errorf("Build get pods assigned to node function error: %v", err)
+			}
+
+			podEvictor := evictions.NewPodEvictor(
+				evictions.NewEvictionAPIBackend(fakeClient, policyv1.SchemeGroupVersion.String()),
+				nil,
+				nil,
+				nodes,
+				false,
+				&events.FakeRecorder{},
+			)
+
+			evictorFilter, err := defaultevictor.New(
+				&defaultevictor.DefaultEvictorArgs{},
+				&frameworkfake.HandleImpl{
+					ClientsetImpl:                 fakeClient,
+					GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+					SharedInformerFactoryImpl:     sharedInformerFactory,
+				},
+			)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+
+			handle := &frameworkfake.HandleImpl{
+				ClientsetImpl:                 fakeClient,
+				GetPodsAssignedToNodeFuncImpl: getPodsAssignedToNode,
+				PodEvictorImpl:                podEvictor,
+				SharedInformerFactoryImpl:     sharedInformerFactory,
+				EvictorFilterImpl:             evictorFilter.(frameworktypes.EvictorPlugin),
+			}
+			SetDefaults_RotateLongLivedPodsArgs(tc.args)
+			if err := ValidateRotateLongLivedPodsArgs(tc.args); err != nil {
+				t.Fatalf("Invalid args: %v", err)
+			}
+			plugin, err := New(tc.args, handle)
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			plugin.(*RotateLongLivedPods).now = func() time.Time { return testNow }
+
+			sharedInformerFactory.Start(ctx.Done())
+			sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+			plugin.(frameworktypes.DeschedulePlugin).Deschedule(ctx, nodes)
+			if podsEvicted := podEvictor.TotalEvicted(); podsEvicted != tc.expectedEvictedPodCount {
+				t.Errorf("Unexpected no of pods evicted: pods evicted: %d, expected: %d", podsEvicted, tc.expectedEvictedPodCount)
+			}
+			if tc.expectedEvictedPods != nil && fmt.Sprint(evictedPods) != fmt.Sprint(tc.expectedEvictedPods) {
+				t.Errorf("Unexpected pods evicted: %v, expected: %v", evictedPods, tc.expectedEvictedPods)
+			}
+		})
+	}
+}
+
+func TestMaxPodLifeTimeJitter(t *testing.T) {
+	plugin := &RotateLongLivedPods{
+		args: &RotateLongLivedPodsArgs{
+			MaxPodLifeTimeSeconds:       utilptr.To[uint](3600),
+			MaxPodLifeTimeJitterSeconds: utilptr.To[uint](1800),
+		},
+	}
+
+	lifetimes := map[time.Duration]bool{}
+	for i := 0; i < 10; i++ {
+		pod := buildTestPod(fmt.Sprintf("pod-%d", i), "node1", "rs-a", 0)
+		lifetime := plugin.maxPodLifeTime(pod)
+		if lifetime < time.Hour || lifetime >= 90*time.Minute {
+			t.Errorf("Lifetime %v of pod %s out of the jitter interval", lifetime, pod.Name)
+		}
+		if again := plugin.maxPodLifeTime(pod); again != lifetime {
+			t.Errorf("Lifetime of pod %s changed from %v to %v", pod.Name, lifetime, again)
+		}
+		lifetimes[lifetime] = true
+	}
+	if len(lifetimes) < 2 {
+		t.Errorf("Expected the lifetimes of the pods to be spread, got %v", lifetimes)
+	}
+}
+
+func TestInTimeWindow(t *testing.T) {
+	tests := []struct {
+		description string
+		windows     []TimeWindow
+		now         time.Time
+		expected    bool
+	}{
+		{
+			description: "no windows",
+			now:         testNow,
+			expected:    true,
+		},
+		{
+			description: "end of the window is excluded",
+			windows:     []TimeWindow{{Start: "09:00", End: "10:00"}},
+			now:         testNow,
+			expected:    false,
+		},
+		{
+			description: "window spanning midnight before midnight",
+			windows:     []TimeWindow{{Start: "22:00", End: "04:00", Days: []string{"Wednesday"}}},
+			now:         time.Date(2024, time.June, 5, 23, 0, 0, 0, time.UTC),
+			expected:    true,
+		},
+		{
+			description: "window spanning midnight belongs to the previous day after midnight",
+			windows:     []TimeWindow{{Start: "22:00", End: "04:00", Days: []string{"Wednesday"}}},
+			now:         time.Date(2024, time.June, 6, 1, 0, 0, 0, time.UTC),
+			expected:    true,
+		},
+		{
+			description: "window spanning midnight on another day",
+			windows:     []TimeWindow{{Start: "22:00", End: "04:00", Days: []string{"Wednesday"}}},
+			now:         time.Date(2024, time.June, 5, 1, 0, 0, 0, time.UTC),
+			expected:    false,
+		},
+		{
+			description: "window starting and ending at the same time covers the whole day",
+			windows:     []TimeWindow{{Start: "00:00", End: "00:00", Days: []string{"Wednesday"}}},
+			now:         testNow,
+			expected:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			args := &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				TimeWindows:           tc.windows,
+			}
+			SetDefaults_RotateLongLivedPodsArgs(args)
+			plugin, err := New(args, &frameworkfake.HandleImpl{})
+			if err != nil {
+				t.Fatalf("Unable to initialize the plugin: %v", err)
+			}
+			if got := plugin.(*RotateLongLivedPods).inTimeWindow(tc.now); got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func podEvictionReactionFunc(evictedPods *[]string) func(action core.Action) (bool, runtime.Object, error) {
+	return func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "eviction" {
+			createAct, matched := action.(core.CreateActionImpl)
+			if !matched {
+				return false, nil, fmt.Errorf("unable to convert action to core.CreateActionImpl")
+			}
+			if eviction, matched := createAct.Object.(*policyv1.Eviction); matched {
+				*evictedPods = append(*evictedPods, eviction.GetName())
+			}
+		}
+		return false, nil, nil // fallback to the default reactor
+	}
+}
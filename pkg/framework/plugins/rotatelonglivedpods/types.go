@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotatelonglivedpods
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// +k8s:deepcopy-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RotateLongLivedPodsArgs holds arguments used to configure RotateLongLivedPods plugin.
+type RotateLongLivedPodsArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Namespaces    *api.Namespaces       `json:"namespaces"`
+	LabelSelector *metav1.LabelSelector `json:"labelSelector"`
+	// MaxPodLifeTimeSeconds is the age after which a pod is rotated.
+	MaxPodLifeTimeSeconds *uint `json:"maxPodLifeTimeSeconds"`
+	// MaxPodLifeTimeJitterSeconds spreads the age at which pods are rotated over
+	// the given interval so replicas created together do not expire together.
+	MaxPodLifeTimeJitterSeconds *uint `json:"maxPodLifeTimeJitterSeconds"`
+	// MaxPodsPerOwner caps the number (or percentage of the replicas) of pods
+	// evicted per owner in a descheduling cycle, defaults to 1.
+	MaxPodsPerOwner *intstr.IntOrString `json:"maxPodsPerOwner"`
+	// TimeWindows restricts the rotation to the given times of day. Pods are
+	// rotated at any time when empty.
+	TimeWindows []TimeWindow `json:"timeWindows"`
+	// TimeZone is the IANA time zone the time windows are in, defaults to UTC.
+	TimeZone string `json:"timeZone"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// TimeWindow is a daily time interval pods can be rotated in.
+type TimeWindow struct {
+	// Start is the beginning of the window in HH:MM format.
+	Start string `json:"start"`
+	// End is the end of the window in HH:MM format. Windows ending before
+	// they start span midnight.
+	End string `json:"end"`
+	// Days limits the window to the given week days (e.g. Monday). The window
+	// applies to every day when empty.
+	Days []string `json:"days"`
+}
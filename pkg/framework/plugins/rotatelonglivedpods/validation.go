@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotatelonglivedpods
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+// ValidateRotateLongLivedPodsArgs validates RotateLongLivedPods arguments
+func ValidateRotateLongLivedPodsArgs(obj runtime.Object) error {
+	args := obj.(*RotateLongLivedPodsArgs)
+	if args.MaxPodLifeTimeSeconds == nil || *args.MaxPodLifeTimeSeconds == 0 {
+		return fmt.Errorf("maxPodLifeTimeSeconds must be greater than 0")
+	}
+
+	if args.MaxPodsPerOwner != nil {
+		maxPods, err := intstr.GetScaledValueFromIntOrPercent(args.MaxPodsPerOwner, 100, false)
+		if err != nil {
+			return fmt.Errorf("invalid maxPodsPerOwner: %v", err)
+		}
+		if maxPods <= 0 {
+			return fmt.Errorf("maxPodsPerOwner must be greater than 0")
+		}
+	}
+
+	if _, err := time.LoadLocation(args.TimeZone); err != nil {
+		return fmt.Errorf("invalid timeZone %q: %v", args.TimeZone, err)
+	}
+	for _, window := range args.TimeWindows {
+		if _, err := parseTimeOfDay(window.Start); err != nil {
+			return fmt.Errorf("invalid time window start %q: %v", window.Start, err)
+		}
+		if _, err := parseTimeOfDay(window.End); err != nil {
+			return fmt.Errorf("invalid time window end %q: %v", window.End, err)
+		}
+		for _, day := range window.Days {
+			if _, ok := weekDays[day]; !ok {
+				return fmt.Errorf("invalid time window day %q", day)
+			}
+		}
+	}
+
+	// At most one of include/exclude can be set
+	if args.Namespaces != nil && len(args.Namespaces.Include) > 0 && len(args.Namespaces.Exclude) > 0 {
+		return fmt.Errorf("only one of Include/Exclude namespaces can be set")
+	}
+	if err := api.ValidateNamespaces(args.Namespaces); err != nil {
+		return err
+	}
+
+	if args.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(args.LabelSelector); err != nil {
+			return fmt.Errorf("failed to get label selectors from strategy's params: %+v", err)
+		}
+	}
+
+	return nil
+}
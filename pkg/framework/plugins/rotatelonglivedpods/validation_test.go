@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rotatelonglivedpods
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilptr "k8s.io/utils/ptr"
+
+	"sigs.k8s.io/descheduler/pkg/api"
+)
+
+func TestValidateRotateLongLivedPodsArgs(t *testing.T) {
+	tests := []struct {
+		description string
+		args        *RotateLongLivedPodsArgs
+		expectError bool
+	}{
+		{
+			description: "valid args",
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				MaxPodsPerOwner:       utilptr.To(intstr.FromString("20%")),
+				TimeWindows:           []TimeWindow{{Start: "22:00", End: "04:00", Days: []string{"Saturday"}}},
+				TimeZone:              "UTC",
+			},
+		},
+		{
+			description: "missing maxPodLifeTimeSeconds",
+			args:        &RotateLongLivedPodsArgs{},
+			expectError: true,
+		},
+		{
+			description: "zero maxPodsPerOwner",
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				MaxPodsPerOwner:       utilptr.To(intstr.FromInt32(0)),
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid maxPodsPerOwner percentage",
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				MaxPodsPerOwner:       utilptr.To(intstr.FromString("half")),
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid time window",
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				TimeWindows:           []TimeWindow{{Start: "25:00", End: "04:00"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid time window end",
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				TimeWindows:           []TimeWindow{{Start: "22:00", End: "4am"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid time window day",
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				TimeWindows:           []TimeWindow{{Start: "22:00", End: "04:00", Days: []string{"Someday"}}},
+			},
+			expectError: true,
+		},
+		{
+			description: "invalid time zone",
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				TimeZone:              "Nowhere/Somewhere",
+			},
+			expectError: true,
+		},
+		{
+			description: "include and exclude namespaces both set",
+			args: &RotateLongLivedPodsArgs{
+				MaxPodLifeTimeSeconds: utilptr.To[uint](3600),
+				Namespaces: &api.Namespaces{
+					Include: []string{"default"},
+					Exclude: []string{"kube-system"},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := ValidateRotateLongLivedPodsArgs(tc.args)
+			if hasError := err != nil; hasError != tc.expectError {
+				t.Errorf("Unexpected validation result: %v", err)
+			}
+		})
+	}
+}
@@ -22,13 +22,16 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/descheduler/metrics"
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
 	frameworktypes "sigs.k8s.io/descheduler/pkg/framework/types"
 	"sigs.k8s.io/descheduler/pkg/tracing"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
@@ -44,6 +47,22 @@ type evictorImpl struct {
 	podEvictor        *evictions.PodEvictor
 	filter            podutil.FilterFunc
 	preEvictionFilter podutil.FilterFunc
+	auditRecorder     audit.Recorder
+	snapshot          *nodeutil.Snapshot
+	// pluginName is the name of the deschedule or balance plugin currently running
+	pluginName string
+	// auditedFilters are the rejections already recorded in the audit log. Profiles are
+	// built every descheduling cycle, so each rejection is recorded once per cycle.
+	auditedFilters sets.Set[filterRejection]
+}
+
+// filterRejection identifies a pod rejected by the filter of an evictor plugin
+// while a deschedule or balance plugin was running
+type filterRejection struct {
+	pod            types.UID
+	plugin         string
+	evictor        string
+	extensionPoint string
 }
 
 var _ frameworktypes.Evictor = &evictorImpl{}
@@ -61,19 +80,55 @@ func (ei *evictorImpl) PreEvictionFilter(pod *v1.Pod) bool {
 // Evict evicts a pod (no pre-check performed)
 func (ei *evictorImpl) Evict(ctx context.Context, pod *v1.Pod, opts evictions.EvictOptions) bool {
 	opts.ProfileName = ei.profileName
-	return ei.podEvictor.EvictPod(ctx, pod, opts)
+	evicted := ei.podEvictor.EvictPod(ctx, pod, opts)
+	if evicted && ei.snapshot != nil {
+		ei.snapshot.RemovePod(pod)
+	}
+	return evicted
 }
 
 func (ei *evictorImpl) NodeLimitExceeded(node *v1.Node) bool {
 	return ei.podEvictor.NodeLimitExceeded(node)
 }
 
+func (ei *evictorImpl) EvictionsAllowed(pods []*v1.Pod) bool {
+	return ei.podEvictor.EvictionsAllowed(pods)
+}
+
+func (ei *evictorImpl) EvictedOwnerPods(ownerUID types.UID) sets.Set[types.UID] {
+	return ei.podEvictor.EvictedOwnerPods(ownerUID)
+}
+
+// auditFilter records the pods rejected by the filter of an evictor plugin
+func (ei *evictorImpl) auditFilter(extensionPoint, evictorName string, filter podutil.FilterFunc) podutil.FilterFunc {
+	if ei.auditRecorder == nil {
+		return filter
+	}
+	return func(pod *v1.Pod) bool {
+		if filter(pod) {
+			return true
+		}
+		rejection := filterRejection{pod: pod.UID, plugin: ei.pluginName, evictor: evictorName, extensionPoint: extensionPoint}
+		if ei.auditedFilters.Has(rejection) {
+			return false
+		}
+		ei.auditedFilters.Insert(rejection)
+		record := audit.NewRecord(pod, audit.OutcomeFiltered)
+		record.Profile = ei.profileName
+		record.Plugin = ei.pluginName
+		record.Message = fmt.Sprintf("rejected by the %v %v", evictorName, extensionPoint)
+		ei.auditRecorder.Record(record)
+		return false
+	}
+}
+
 // handleImpl implements the framework handle which gets passed to plugins
 type handleImpl struct {
 	clientSet                 clientset.Interface
 	getPodsAssignedToNodeFunc podutil.GetPodsAssignedToNodeFunc
 	sharedInformerFactory     informers.SharedInformerFactory
 	evictor                   *evictorImpl
+	snapshot                  *nodeutil.Snapshot
 }
 
 var _ frameworktypes.Handle = &handleImpl{}
@@ -98,6 +153,11 @@ func (hi *handleImpl) Evictor() frameworktypes.Evictor {
 	return hi.evictor
 }
 
+// NodeSnapshot retrieves the node snapshot of the descheduling cycle
+func (hi *handleImpl) NodeSnapshot() *nodeutil.Snapshot {
+	return hi.snapshot
+}
+
 type filterPlugin interface {
 	frameworktypes.Plugin
 	Filter(pod *v1.Pod) bool
@@ -111,6 +171,7 @@ type preEvictionFilterPlugin interface {
 type profileImpl struct {
 	profileName string
 	podEvictor  *evictions.PodEvictor
+	evictor     *evictorImpl
 
 	deschedulePlugins        []frameworktypes.DeschedulePlugin
 	balancePlugins           []frameworktypes.BalancePlugin
@@ -132,6 +193,8 @@ type handleImplOpts struct {
 	sharedInformerFactory     informers.SharedInformerFactory
 	getPodsAssignedToNodeFunc podutil.GetPodsAssignedToNodeFunc
 	podEvictor                *evictions.PodEvictor
+	auditRecorder             audit.Recorder
+	snapshot                  *nodeutil.Snapshot
 }
 
 // WithClientSet sets clientSet for the scheduling frameworkImpl.
@@ -159,6 +222,21 @@ func WithGetPodsAssignedToNodeFnc(getPodsAssignedToNodeFunc podutil.GetPodsAssig
 	}
 }
 
+// WithAuditRecorder records the pods rejected by the evictor plugins.
+func WithAuditRecorder(auditRecorder audit.Recorder) Option {
+	return func(o *handleImplOpts) {
+		o.auditRecorder = auditRecorder
+	}
+}
+
+// WithNodeSnapshot shares the node snapshot of the descheduling cycle with the plugins,
+// pods evicted through the profile are removed from it.
+func WithNodeSnapshot(snapshot *nodeutil.Snapshot) Option {
+	return func(o *handleImplOpts) {
+		o.snapshot = snapshot
+	}
+}
+
 func getPluginConfig(pluginName string, pluginConfigs []api.PluginConfig) (*api.PluginConfig, int) {
 	for idx, pluginConfig := range pluginConfigs {
 		if pluginConfig.Name == pluginName {
@@ -253,11 +331,16 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 		clientSet:                 hOpts.clientSet,
 		getPodsAssignedToNodeFunc: hOpts.getPodsAssignedToNodeFunc,
 		sharedInformerFactory:     hOpts.sharedInformerFactory,
+		snapshot:                  hOpts.snapshot,
 		evictor: &evictorImpl{
-			profileName: config.Name,
-			podEvictor:  hOpts.podEvictor,
+			profileName:    config.Name,
+			podEvictor:     hOpts.podEvictor,
+			auditRecorder:  hOpts.auditRecorder,
+			snapshot:       hOpts.snapshot,
+			auditedFilters: sets.New[filterRejection](),
 		},
 	}
+	pi.evictor = handle.evictor
 
 	pluginNames := append(config.Plugins.Deschedule.Enabled, config.Plugins.Balance.Enabled...)
 	pluginNames = append(pluginNames, config.Plugins.Filter.Enabled...)
@@ -288,13 +371,13 @@ func NewProfile(config api.DeschedulerProfile, reg pluginregistry.Registry, opts
 	filters := []podutil.FilterFunc{}
 	for _, pluginName := range config.Plugins.Filter.Enabled {
 		pi.filterPlugins = append(pi.filterPlugins, plugins[pluginName].(filterPlugin))
-		filters = append(filters, plugins[pluginName].(filterPlugin).Filter)
+		filters = append(filters, handle.evictor.auditFilter("filter", pluginName, plugins[pluginName].(filterPlugin).Filter))
 	}
 
 	preEvictionFilters := []podutil.FilterFunc{}
 	for _, pluginName := range config.Plugins.PreEvictionFilter.Enabled {
 		pi.preEvictionFilterPlugins = append(pi.preEvictionFilterPlugins, plugins[pluginName].(preEvictionFilterPlugin))
-		preEvictionFilters = append(preEvictionFilters, plugins[pluginName].(preEvictionFilterPlugin).PreEvictionFilter)
+		preEvictionFilters = append(preEvictionFilters, handle.evictor.auditFilter("preEvictionFilter", pluginName, plugins[pluginName].(preEvictionFilterPlugin).PreEvictionFilter))
 	}
 
 	handle.evictor.filter = podutil.WrapFilterFuncs(filters...)
@@ -311,6 +394,7 @@ func (d profileImpl) RunDeschedulePlugins(ctx context.Context, nodes []*v1.Node)
 		defer span.End()
 		evicted := d.podEvictor.TotalEvicted()
 		strategyStart := time.Now()
+		d.evictor.pluginName = pl.Name()
 		status := pl.Deschedule(ctx, nodes)
 		metrics.DeschedulerStrategyDuration.With(map[string]string{"strategy": pl.Name(), "profile": d.profileName}).Observe(time.Since(strategyStart).Seconds())
 
@@ -339,6 +423,7 @@ func (d profileImpl) RunBalancePlugins(ctx context.Context, nodes []*v1.Node) *f
 		defer span.End()
 		evicted := d.podEvictor.TotalEvicted()
 		strategyStart := time.Now()
+		d.evictor.pluginName = pl.Name()
 		status := pl.Balance(ctx, nodes)
 		metrics.DeschedulerStrategyDuration.With(map[string]string{"strategy": pl.Name(), "profile": d.profileName}).Observe(time.Since(strategyStart).Seconds())
 
@@ -16,9 +16,12 @@ import (
 	"k8s.io/client-go/informers"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/events"
 
 	"sigs.k8s.io/descheduler/pkg/api"
+	"sigs.k8s.io/descheduler/pkg/descheduler/audit"
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 	fakeplugin "sigs.k8s.io/descheduler/pkg/framework/fake/plugin"
 	"sigs.k8s.io/descheduler/pkg/framework/pluginregistry"
@@ -244,7 +247,7 @@ func TestProfileDescheduleBalanceExtensionPointsEviction(t *testing.T) {
 			eventBroadcaster, eventRecorder := utils.GetRecorderAndBroadcaster(ctx, eventClient)
 			defer eventBroadcaster.Shutdown()
 
-			podEvictor := evictions.NewPodEvictor(client, "policy/v1", false, nil, nil, nodes, true, eventRecorder)
+			podEvictor := evictions.NewPodEvictor(evictions.NewEvictionAPIBackend(client, "policy/v1"), nil, nil, nodes, true, eventRecorder)
 
 			prfl, err := NewProfile(
 				test.config,
@@ -393,7 +396,7 @@ func TestProfileExtensionPoints(t *testing.T) {
 	eventBroadcaster, eventRecorder := utils.GetRecorderAndBroadcaster(ctx, eventClient)
 	defer eventBroadcaster.Shutdown()
 
-	podEvictor := evictions.NewPodEvictor(client, "policy/v1", false, nil, nil, nodes, true, eventRecorder)
+	podEvictor := evictions.NewPodEvictor(evictions.NewEvictionAPIBackend(client, "policy/v1"), nil, nil, nodes, true, eventRecorder)
 
 	prfl, err := NewProfile(
 		api.DeschedulerProfile{
@@ -605,7 +608,7 @@ func TestProfileExtensionPointOrdering(t *testing.T) {
 	eventBroadcaster, eventRecorder := utils.GetRecorderAndBroadcaster(ctx, eventClient)
 	defer eventBroadcaster.Shutdown()
 
-	podEvictor := evictions.NewPodEvictor(client, "policy/v1", false, nil, nil, nodes, true, eventRecorder)
+	podEvictor := evictions.NewPodEvictor(evictions.NewEvictionAPIBackend(client, "policy/v1"), nil, nil, nodes, true, eventRecorder)
 
 	prfl, err := NewProfile(
 		api.DeschedulerProfile{
@@ -693,3 +696,206 @@ func TestProfileExtensionPointOrdering(t *testing.T) {
 		t.Errorf("check for balance invocation order failed. Results are not deep equal. mismatch (-want +got):\n%s", diff)
 	}
 }
+
+type fakeAuditRecorder struct {
+	records []audit.Record
+}
+
+func (r *fakeAuditRecorder) Record(record audit.Record) {
+	r.records = append(r.records, record)
+}
+
+func TestProfileAuditsFilteredPods(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1}
+	p1 := testutils.BuildTestPod("p1", 200, 0, n1.Name, func(pod *v1.Pod) {
+		pod.UID = "p1"
+	})
+
+	pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+
+	fakeFilterPlugin := &fakeplugin.FakeFilterPlugin{PluginName: "RejectAll"}
+	fakeFilterPlugin.AddReactor(string(frameworktypes.FilterExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		return true, false, nil
+	})
+	fakeFilterPlugin.AddReactor(string(frameworktypes.PreEvictionFilterExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		return true, false, nil
+	})
+	pluginregistry.Register(
+		"RejectAll",
+		fakeplugin.NewFakeFilterPluginFncFromFake(fakeFilterPlugin),
+		&fakeplugin.FakeFilterPlugin{},
+		&fakeplugin.FakeFilterPluginArgs{},
+		fakeplugin.ValidateFakePluginArgs,
+		fakeplugin.SetDefaults_FakePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	fakePlugin := fakeplugin.FakePlugin{PluginName: "FakePlugin"}
+	fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		if dAction, ok := action.(fakeplugin.DescheduleAction); ok {
+			// plugins commonly filter the same pod several times, it is only recorded once
+			for i := 0; i < 3; i++ {
+				if dAction.Handle().Evictor().Filter(p1) && dAction.Handle().Evictor().PreEvictionFilter(p1) {
+					dAction.Handle().Evictor().Evict(ctx, p1, evictions.EvictOptions{StrategyName: "FakePlugin"})
+				}
+			}
+			return true, false, nil
+		}
+		return false, false, nil
+	})
+	pluginregistry.Register(
+		"FakePlugin",
+		fakeplugin.NewPluginFncFromFake(&fakePlugin),
+		&fakeplugin.FakePlugin{},
+		&fakeplugin.FakePluginArgs{},
+		fakeplugin.ValidateFakePluginArgs,
+		fakeplugin.SetDefaults_FakePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	client := fakeclientset.NewSimpleClientset(n1, p1)
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	recorder := &fakeAuditRecorder{}
+	podEvictor := evictions.NewPodEvictor(evictions.NewRecordOnlyBackend(nil, "policy/v1"), nil, nil, nodes, false, &events.FakeRecorder{}, evictions.WithAuditRecorder(recorder))
+
+	prfl, err := NewProfile(
+		api.DeschedulerProfile{
+			Name: "strategy-test-profile",
+			PluginConfigs: []api.PluginConfig{
+				{
+					Name: "FakePlugin",
+					Args: &fakeplugin.FakePluginArgs{},
+				},
+				{
+					Name: "RejectAll",
+					Args: &fakeplugin.FakeFilterPluginArgs{},
+				},
+			},
+			Plugins: api.Plugins{
+				Deschedule: api.PluginSet{
+					Enabled: []string{"FakePlugin"},
+				},
+				Filter: api.PluginSet{
+					Enabled: []string{"RejectAll"},
+				},
+			},
+		},
+		pluginregistry.PluginRegistry,
+		WithClientSet(client),
+		WithSharedInformerFactory(sharedInformerFactory),
+		WithPodEvictor(podEvictor),
+		WithAuditRecorder(recorder),
+	)
+	if err != nil {
+		t.Fatalf("unable to create profile: %v", err)
+	}
+
+	prfl.RunDeschedulePlugins(ctx, nodes)
+
+	expected := []audit.Record{
+		{
+			Profile:   "strategy-test-profile",
+			Plugin:    "FakePlugin",
+			Namespace: p1.Namespace,
+			Pod:       p1.Name,
+			PodUID:    string(p1.UID),
+			Node:      n1.Name,
+			Outcome:   audit.OutcomeFiltered,
+			Message:   "rejected by the RejectAll filter",
+		},
+	}
+	if diff := cmp.Diff(expected, recorder.records); diff != "" {
+		t.Errorf("Unexpected audit records (-want +got):\n%s", diff)
+	}
+}
+
+func TestProfileEvictionUpdatesNodeSnapshot(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	n1 := testutils.BuildTestNode("n1", 2000, 3000, 10, nil)
+	nodes := []*v1.Node{n1}
+	p1 := testutils.BuildTestPod("p1", 200, 0, n1.Name, nil)
+	p2 := testutils.BuildTestPod("p2", 300, 0, n1.Name, nil)
+
+	pluginregistry.PluginRegistry = pluginregistry.NewRegistry()
+
+	fakePlugin := fakeplugin.FakePlugin{PluginName: "FakePlugin"}
+	fakePlugin.AddReactor(string(frameworktypes.DescheduleExtensionPoint), func(action fakeplugin.Action) (handled, filter bool, err error) {
+		if dAction, ok := action.(fakeplugin.DescheduleAction); ok {
+			dAction.Handle().Evictor().Evict(ctx, p1, evictions.EvictOptions{StrategyName: "FakePlugin"})
+			return true, false, nil
+		}
+		return false, false, nil
+	})
+	pluginregistry.Register(
+		"FakePlugin",
+		fakeplugin.NewPluginFncFromFake(&fakePlugin),
+		&fakeplugin.FakePlugin{},
+		&fakeplugin.FakePluginArgs{},
+		fakeplugin.ValidateFakePluginArgs,
+		fakeplugin.SetDefaults_FakePluginArgs,
+		pluginregistry.PluginRegistry,
+	)
+
+	client := fakeclientset.NewSimpleClientset(n1, p1, p2)
+	sharedInformerFactory := informers.NewSharedInformerFactory(client, 0)
+	getPodsAssignedToNode, err := podutil.BuildGetPodsAssignedToNodeFunc(sharedInformerFactory.Core().V1().Pods().Informer())
+	if err != nil {
+		t.Fatalf("build get pods assigned to node function error: %v", err)
+	}
+	sharedInformerFactory.Start(ctx.Done())
+	sharedInformerFactory.WaitForCacheSync(ctx.Done())
+
+	snapshot := nodeutil.NewSnapshot(client, sharedInformerFactory.Core().V1().Nodes().Lister(), getPodsAssignedToNode)
+	if nodeInfo, err := snapshot.NodeInfo(n1.Name); err != nil || len(nodeInfo.Pods) != 2 {
+		t.Fatalf("Expected 2 pods in the snapshot, got %v (%v)", nodeInfo, err)
+	}
+	podEvictor := evictions.NewPodEvictor(evictions.NewRecordOnlyBackend(nil, "policy/v1"), nil, nil, nodes, false, &events.FakeRecorder{})
+
+	prfl, err := NewProfile(
+		api.DeschedulerProfile{
+			Name: "strategy-test-profile",
+			PluginConfigs: []api.PluginConfig{
+				{
+					Name: "FakePlugin",
+					Args: &fakeplugin.FakePluginArgs{},
+				},
+			},
+			Plugins: api.Plugins{
+				Deschedule: api.PluginSet{
+					Enabled: []string{"FakePlugin"},
+				},
+			},
+		},
+		pluginregistry.PluginRegistry,
+		WithClientSet(client),
+		WithSharedInformerFactory(sharedInformerFactory),
+		WithPodEvictor(podEvictor),
+		WithGetPodsAssignedToNodeFnc(getPodsAssignedToNode),
+		WithNodeSnapshot(snapshot),
+	)
+	if err != nil {
+		t.Fatalf("unable to create profile: %v", err)
+	}
+
+	prfl.RunDeschedulePlugins(ctx, nodes)
+
+	nodeInfo, err := snapshot.NodeInfo(n1.Name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(nodeInfo.Pods) != 1 || nodeInfo.Pods[0].Name != p2.Name {
+		t.Errorf("Expected only p2 to be left in the snapshot, got %v", nodeInfo.Pods)
+	}
+	if cpu := nodeInfo.Requested[v1.ResourceCPU].MilliValue(); cpu != 300 {
+		t.Errorf("Expected 300m cpu requested after the eviction, got %dm", cpu)
+	}
+}
@@ -20,10 +20,13 @@ import (
 	"context"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 
 	"sigs.k8s.io/descheduler/pkg/descheduler/evictions"
+	nodeutil "sigs.k8s.io/descheduler/pkg/descheduler/node"
 	podutil "sigs.k8s.io/descheduler/pkg/descheduler/pod"
 )
 
@@ -36,6 +39,9 @@ type Handle interface {
 	Evictor() Evictor
 	GetPodsAssignedToNodeFunc() podutil.GetPodsAssignedToNodeFunc
 	SharedInformerFactory() informers.SharedInformerFactory
+	// NodeSnapshot returns the nodes and pods snapshot of the current descheduling cycle,
+	// kept up to date with the evictions. Nil when no snapshot is available.
+	NodeSnapshot() *nodeutil.Snapshot
 }
 
 // Evictor defines an interface for filtering and evicting pods
@@ -49,6 +55,10 @@ type Evictor interface {
 	Evict(context.Context, *v1.Pod, evictions.EvictOptions) bool
 	// NodeLimitExceeded checks if the number of evictions for a node was exceeded
 	NodeLimitExceeded(node *v1.Node) bool
+	// EvictionsAllowed checks the eviction limits leave room to evict all the given pods
+	EvictionsAllowed(pods []*v1.Pod) bool
+	// EvictedOwnerPods gives the UIDs of the owner's pods evicted during the current descheduling cycle
+	EvictedOwnerPods(ownerUID types.UID) sets.Set[types.UID]
 }
 
 // Status describes result of an extension point invocation
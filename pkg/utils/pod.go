@@ -73,6 +73,22 @@ func IsPodTerminating(pod *v1.Pod) bool {
 	return pod.DeletionTimestamp != nil
 }
 
+// GetPodReadyCondition returns the Ready condition of the pod, nil when not reported.
+func GetPodReadyCondition(pod *v1.Pod) *v1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == v1.PodReady {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsPodReady returns true if the pod Ready condition is true.
+func IsPodReady(pod *v1.Pod) bool {
+	condition := GetPodReadyCondition(pod)
+	return condition != nil && condition.Status == v1.ConditionTrue
+}
+
 // IsStaticPod returns true if the pod is a static pod.
 func IsStaticPod(pod *v1.Pod) bool {
 	source, err := GetPodSource(pod)
@@ -343,6 +343,96 @@ func CheckPodsWithAntiAffinityExist(pod *v1.Pod, pods map[string][]*v1.Pod, node
 	return false
 }
 
+// CheckPodAffinityViolated checks if any required pod affinity term of the pod is not satisfied by
+// another pod in the topology domain of its node. Like the scheduler, a term the pod matches itself
+// is satisfied when no other pod matches it, so the first pod of a group can be scheduled.
+func CheckPodAffinityViolated(pod *v1.Pod, pods map[string][]*v1.Pod, nodeMap map[string]*v1.Node) bool {
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.PodAffinity == nil {
+		return false
+	}
+	node, ok := nodeMap[pod.Spec.NodeName]
+	if !ok {
+		return false
+	}
+	for _, term := range affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution {
+		if _, ok := node.Labels[term.TopologyKey]; !ok {
+			klog.V(1).InfoS("Node of the pod is missing the PodAffinity topology label", "pod", klog.KObj(pod), "topologyKey", term.TopologyKey)
+			return true
+		}
+		namespaces := getNamespacesFromPodAffinityTerm(pod, &term)
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			klog.ErrorS(err, "Unable to convert LabelSelector into Selector")
+			return false
+		}
+		matchesAny, matchesInDomain := false, false
+		for namespace := range namespaces {
+			for _, existingPod := range pods[namespace] {
+				if (existingPod.Namespace == pod.Namespace && existingPod.Name == pod.Name) || !podMatchesTermsNamespaceAndSelector(existingPod, namespaces, selector) {
+					continue
+				}
+				matchesAny = true
+				if nodeHavingExistingPod, ok := nodeMap[existingPod.Spec.NodeName]; ok && hasSameLabelValue(node, nodeHavingExistingPod, term.TopologyKey) {
+					matchesInDomain = true
+					break
+				}
+			}
+		}
+		if matchesInDomain || (!matchesAny && podMatchesTermsNamespaceAndSelector(pod, namespaces, selector)) {
+			continue
+		}
+		klog.V(1).InfoS("Found Pod violating PodAffinity", "pod with affinity", klog.KObj(pod), "topologyKey", term.TopologyKey)
+		return true
+	}
+	return false
+}
+
+// GetPodAffinityWeight returns the sum of the weights of the preferred pod affinity terms of the
+// pod which other pods in the topology domain of the node satisfy.
+func GetPodAffinityWeight(pod *v1.Pod, node *v1.Node, pods map[string][]*v1.Pod, nodeMap map[string]*v1.Node) int32 {
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.PodAffinity == nil {
+		return 0
+	}
+	var sumWeights int32
+	for _, weightedTerm := range affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		term := weightedTerm.PodAffinityTerm
+		if _, ok := node.Labels[term.TopologyKey]; !ok {
+			continue
+		}
+		namespaces := getNamespacesFromPodAffinityTerm(pod, &term)
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			klog.ErrorS(err, "Unable to convert LabelSelector into Selector")
+			continue
+		}
+	pods:
+		for namespace := range namespaces {
+			for _, existingPod := range pods[namespace] {
+				if (existingPod.Namespace == pod.Namespace && existingPod.Name == pod.Name) || !podMatchesTermsNamespaceAndSelector(existingPod, namespaces, selector) {
+					continue
+				}
+				if nodeHavingExistingPod, ok := nodeMap[existingPod.Spec.NodeName]; ok && hasSameLabelValue(node, nodeHavingExistingPod, term.TopologyKey) {
+					sumWeights += weightedTerm.Weight
+					break pods
+				}
+			}
+		}
+	}
+	return sumWeights
+}
+
+// PodMatchesAffinityTerm checks if the pod matches the namespaces and the label
+// selector of the pod (anti-)affinity term of affinityPod.
+func PodMatchesAffinityTerm(pod, affinityPod *v1.Pod, term *v1.PodAffinityTerm) (bool, error) {
+	selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+	if err != nil {
+		return false, err
+	}
+	return podMatchesTermsNamespaceAndSelector(pod, getNamespacesFromPodAffinityTerm(affinityPod, term), selector), nil
+}
+
 // getPodAntiAffinityTerms gets the antiaffinity terms for the given pod.
 func getPodAntiAffinityTerms(podAntiAffinity *v1.PodAntiAffinity) (terms []v1.PodAffinityTerm) {
 	if podAntiAffinity != nil {
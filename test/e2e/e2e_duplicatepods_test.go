@@ -173,9 +173,7 @@ func TestRemoveDuplicates(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				clientSet,
-				evictionPolicyGroupVersion,
-				false,
+				evictions.NewEvictionAPIBackend(clientSet, evictionPolicyGroupVersion),
 				nil,
 				nil,
 				nodes,
@@ -159,9 +159,7 @@ func runPodLifetimePlugin(
 	}
 
 	podEvictor := evictions.NewPodEvictor(
-		clientset,
-		evictionPolicyGroupVersion,
-		false,
+		evictions.NewEvictionAPIBackend(clientset, evictionPolicyGroupVersion),
 		nil,
 		maxPodsToEvictPerNamespace,
 		nodes,
@@ -1450,9 +1448,7 @@ func initPodEvictorOrFail(t *testing.T, clientSet clientset.Interface, getPodsAs
 	eventRecorder := &events.FakeRecorder{}
 
 	return evictions.NewPodEvictor(
-		clientSet,
-		evictionPolicyGroupVersion,
-		false,
+		evictions.NewEvictionAPIBackend(clientSet, evictionPolicyGroupVersion),
 		nil,
 		nil,
 		nodes,
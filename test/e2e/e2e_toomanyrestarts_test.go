@@ -163,9 +163,7 @@ func TestTooManyRestarts(t *testing.T) {
 			eventRecorder := &events.FakeRecorder{}
 
 			podEvictor := evictions.NewPodEvictor(
-				clientSet,
-				evictionPolicyGroupVersion,
-				false,
+				evictions.NewEvictionAPIBackend(clientSet, evictionPolicyGroupVersion),
 				nil,
 				nil,
 				nodes,